@@ -1,3 +1,15 @@
 module github.com/faiakak/table-renderer
 
 go 1.21
+
+require (
+	github.com/andybalholm/brotli v1.1.1
+	github.com/jmoiron/sqlx v1.4.0
+	golang.org/x/text v0.14.0
+	gorm.io/gorm v1.25.12
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+)
@@ -13,7 +13,7 @@ func main() {
 
 	// Example 1: Simple table with user data
 	fmt.Println("=== Example 1: User Data Table ===")
-	userData := tablerenderer.TableData{
+	userData := tablerenderer.DatabasePaginatedData{
 		Headers: []string{"ID", "Name", "Email", "Age"},
 		Rows: [][]interface{}{
 			{1, "John Doe", "john@example.com", 30},
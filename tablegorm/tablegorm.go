@@ -0,0 +1,168 @@
+// Package tablegorm wires table state to GORM queries: the scopes apply the
+// current page, sort order, search term and filters of the table options, and Find
+// loads a page back into DatabasePaginatedData.
+package tablegorm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faiakak/table-renderer/tablerenderer"
+)
+
+// Paginate limits a query to the current page of options.Pagination. With
+// Pagination.SkipCount it loads one more row, telling whether a next page
+// exists.
+func Paginate(options tablerenderer.TableOptions) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		pagination := options.Pagination
+		if pagination == nil || !pagination.Enabled {
+			return db
+		}
+		pageSize := tablerenderer.CalculateDatabaseLimit(pagination.PageSize)
+		limit := pageSize
+		if pagination.SkipCount {
+			limit++
+		}
+		return db.Offset(tablerenderer.CalculateDatabaseOffset(pagination.CurrentPage, pageSize)).Limit(limit)
+	}
+}
+
+// Sort orders a query by the column options.Sorting sorts by. whitelist maps
+// the sortable headers to database columns, e.g. {"name": "users.name"};
+// other headers are ignored so query parameters cannot inject SQL. A
+// Sorting.NullsPosition puts empty values first or last on any database.
+func Sort(options tablerenderer.TableOptions, whitelist map[string]string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sorting := options.Sorting
+		if sorting == nil || !sorting.Enabled || sorting.SortBy == "" {
+			return db
+		}
+		column, ok := whitelist[sorting.SortBy]
+		if !ok {
+			return db
+		}
+		order := clause.OrderByColumn{
+			Column: clause.Column{Name: column},
+			Desc:   strings.EqualFold(sorting.SortOrder, "desc"),
+		}
+		// Postgres sorts nulls as the largest value and MySQL as the
+		// smallest; ordering by the IS NULL test first works on both
+		nulls := ""
+		switch sorting.NullsPosition {
+		case tablerenderer.NullsFirst:
+			nulls = "? IS NULL DESC, "
+		case tablerenderer.NullsLast:
+			nulls = "? IS NULL, "
+		}
+		if nulls == "" {
+			return db.Order(order)
+		}
+		direction := " ASC"
+		if order.Desc {
+			direction = " DESC"
+		}
+		return db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  nulls + "?" + direction,
+			Vars: []interface{}{order.Column, order.Column},
+		}})
+	}
+}
+
+// Search keeps the records where any of columns contains the search term of
+// options.Search, ignoring case. The term is matched literally, with its %
+// and _ escaped as in a FilterContains filter.
+func Search(options tablerenderer.TableOptions, columns []string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		search := options.Search
+		if search == nil || !search.Enabled || len(columns) == 0 {
+			return db
+		}
+		term := strings.TrimSpace(search.SearchTerm)
+		minLength := search.MinLength
+		if minLength < 1 {
+			minLength = 1
+		}
+		if len([]rune(term)) < minLength {
+			return db
+		}
+
+		conditions := make([]clause.Expression, len(columns))
+		for i, column := range columns {
+			filter := tablerenderer.Filter{Field: column, Operator: tablerenderer.FilterContains, Values: []string{term}}
+			condition, args, err := filter.SQL(db.Statement.Quote(column))
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			conditions[i] = clause.Expr{SQL: condition, Vars: args}
+		}
+		return db.Where(clause.Or(conditions...))
+	}
+}
+
+// Filter keeps the records passing every filter of options.Filters.
+// whitelist maps the filterable fields to database columns, e.g.
+// {"age": "users.age"}; filters on other fields are ignored so query
+// parameters cannot inject SQL.
+func Filter(options tablerenderer.TableOptions, whitelist map[string]string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range options.Filters {
+			column, ok := whitelist[filter.Field]
+			if !ok {
+				continue
+			}
+			condition, args, err := filter.SQL(db.Statement.Quote(column))
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			db = db.Where(condition, args...)
+		}
+		return db
+	}
+}
+
+// Find counts the records matching the search and filters, loads the
+// current page into dest, a pointer to a slice of models, and returns it as
+// paginated data with the total count filled in. filterWhitelist maps the
+// filterable fields to columns as for Filter. With Pagination.SkipCount it
+// skips the count and sets Pagination.HasNext instead.
+func Find(db *gorm.DB, dest interface{}, options tablerenderer.TableOptions, sortWhitelist map[string]string, searchColumns []string, filterWhitelist map[string]string) (tablerenderer.DatabasePaginatedData, error) {
+	skipCount := options.Pagination != nil && options.Pagination.Enabled && options.Pagination.SkipCount
+	var total int64
+	if !skipCount {
+		if err := db.Model(dest).Scopes(Search(options, searchColumns), Filter(options, filterWhitelist)).Count(&total).Error; err != nil {
+			return tablerenderer.DatabasePaginatedData{}, fmt.Errorf("failed to count records: %w", err)
+		}
+	}
+	err := db.Scopes(Search(options, searchColumns), Filter(options, filterWhitelist), Sort(options, sortWhitelist), Paginate(options)).Find(dest).Error
+	if err != nil {
+		return tablerenderer.DatabasePaginatedData{}, fmt.Errorf("failed to load records: %w", err)
+	}
+
+	// Copy the pagination so the caller's options are left unchanged
+	if options.Pagination != nil {
+		pagination := *options.Pagination
+		pagination.TotalCount = int(total)
+		if skipCount {
+			// Drop the extra row Paginate loaded to detect a next page
+			records := reflect.ValueOf(dest).Elem()
+			pageSize := tablerenderer.CalculateDatabaseLimit(pagination.PageSize)
+			pagination.HasNext = records.Len() > pageSize
+			if pagination.HasNext {
+				records.Set(records.Slice(0, pageSize))
+			}
+		}
+		options.Pagination = &pagination
+	}
+	return tablerenderer.DatabasePaginatedData{
+		Data:       dest,
+		TotalCount: int(total),
+		Options:    options,
+	}, nil
+}
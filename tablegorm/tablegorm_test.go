@@ -0,0 +1,135 @@
+package tablegorm
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"github.com/faiakak/table-renderer/tablerenderer"
+)
+
+type user struct {
+	ID   int
+	Name string
+	Age  int
+}
+
+// dryRun returns a session that builds statements without a database
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db
+}
+
+func TestScopes(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope func(db *gorm.DB) *gorm.DB
+		want  string
+	}{
+		{
+			name:  "page",
+			scope: Paginate(tablerenderer.TableOptions{Pagination: &tablerenderer.Pagination{Enabled: true, CurrentPage: 3, PageSize: 20}}),
+			want:  "SELECT * FROM `users` LIMIT 20 OFFSET 40",
+		},
+		{
+			name:  "disabled pagination",
+			scope: Paginate(tablerenderer.TableOptions{Pagination: &tablerenderer.Pagination{CurrentPage: 3, PageSize: 20}}),
+			want:  "SELECT * FROM `users`",
+		},
+		{
+			name:  "sort",
+			scope: Sort(tablerenderer.TableOptions{Sorting: &tablerenderer.Sorting{Enabled: true, SortBy: "name", SortOrder: "DESC"}}, map[string]string{"name": "users.name"}),
+			want:  "SELECT * FROM `users` ORDER BY `users`.`name` DESC",
+		},
+		{
+			name:  "sort column not in the whitelist",
+			scope: Sort(tablerenderer.TableOptions{Sorting: &tablerenderer.Sorting{Enabled: true, SortBy: "name; DROP TABLE users"}}, map[string]string{"name": "users.name"}),
+			want:  "SELECT * FROM `users`",
+		},
+		{
+			name:  "search",
+			scope: Search(tablerenderer.TableOptions{Search: &tablerenderer.Search{Enabled: true, SearchTerm: " ada "}}, []string{"name", "email"}),
+			want:  "SELECT * FROM `users` WHERE (LOWER(`name`) LIKE LOWER(\"%ada%\") ESCAPE '!' OR LOWER(`email`) LIKE LOWER(\"%ada%\") ESCAPE '!')",
+		},
+		{
+			name:  "search wildcards match literally",
+			scope: Search(tablerenderer.TableOptions{Search: &tablerenderer.Search{Enabled: true, SearchTerm: "50%_off!"}}, []string{"name"}),
+			want:  "SELECT * FROM `users` WHERE LOWER(`name`) LIKE LOWER(\"%50!%!_off!!%\") ESCAPE '!'",
+		},
+		{
+			name:  "search term below the minimum length",
+			scope: Search(tablerenderer.TableOptions{Search: &tablerenderer.Search{Enabled: true, SearchTerm: "a", MinLength: 2}}, []string{"name"}),
+			want:  "SELECT * FROM `users`",
+		},
+		{
+			name:  "nulls last",
+			scope: Sort(tablerenderer.TableOptions{Sorting: &tablerenderer.Sorting{Enabled: true, SortBy: "age", NullsPosition: tablerenderer.NullsLast}}, map[string]string{"age": "age"}),
+			want:  "SELECT * FROM `users` ORDER BY `age` IS NULL, `age` ASC",
+		},
+		{
+			name:  "nulls first descending",
+			scope: Sort(tablerenderer.TableOptions{Sorting: &tablerenderer.Sorting{Enabled: true, SortBy: "age", SortOrder: "desc", NullsPosition: tablerenderer.NullsFirst}}, map[string]string{"age": "age"}),
+			want:  "SELECT * FROM `users` ORDER BY `age` IS NULL DESC, `age` DESC",
+		},
+		{
+			name: "filters",
+			scope: Filter(tablerenderer.TableOptions{Filters: []tablerenderer.Filter{
+				{Field: "age", Operator: tablerenderer.FilterBetween, Values: []string{"18", "30"}},
+				{Field: "password", Operator: tablerenderer.FilterEquals, Values: []string{"x"}},
+			}}, map[string]string{"age": "users.age"}),
+			want: "SELECT * FROM `users` WHERE `users`.`age` BETWEEN \"18\" AND \"30\"",
+		},
+		{
+			name:  "page without a count",
+			scope: Paginate(tablerenderer.TableOptions{Pagination: &tablerenderer.Pagination{Enabled: true, CurrentPage: 2, PageSize: 20, SkipCount: true}}),
+			want:  "SELECT * FROM `users` LIMIT 21 OFFSET 20",
+		},
+	}
+	db := dryRun(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Scopes(tt.scope).Find(&[]user{})
+			})
+			if got != tt.want {
+				t.Errorf("SQL = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFilters(t *testing.T) {
+	db := dryRun(t)
+	var queries []string
+	err := db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	options := tablerenderer.TableOptions{
+		Pagination: &tablerenderer.Pagination{Enabled: true, CurrentPage: 1, PageSize: 10},
+		Filters:    []tablerenderer.Filter{{Field: "age", Operator: tablerenderer.FilterGreater, Values: []string{"30"}}},
+	}
+	if _, err := Find(db, &[]user{}, options, nil, nil, map[string]string{"age": "age"}); err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	want := []string{
+		"SELECT count(*) FROM `users` WHERE `age` > \"30\"",
+		"SELECT * FROM `users` WHERE `age` > \"30\" LIMIT 10",
+	}
+	if len(queries) != len(want) {
+		t.Fatalf("queries = %q, want %q", queries, want)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Errorf("query %d = %s, want %s", i, queries[i], want[i])
+		}
+	}
+}
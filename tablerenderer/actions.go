@@ -0,0 +1,111 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Actions holds configuration for the actions column rendered after the
+// data columns, e.g. View, Edit and Delete buttons
+type Actions struct {
+	Header string            `json:"header,omitempty"` // Column header (default: translated "Actions")
+	Items  []Action          `json:"items"`            // Actions in display order
+	Hidden map[string]string `json:"hidden,omitempty"` // Hidden fields added to non-GET action forms, e.g. a CSRF token
+}
+
+// Action is a link or button in the actions column
+type Action struct {
+	Label    string `json:"label"`               // Text shown, also the accessible name
+	URL      string `json:"url"`                 // URL template with {Header} placeholders filled from the row, e.g. "/users/{ID}/edit"
+	Method   string `json:"method,omitempty"`    // HTTP method; other than GET renders a POST form with a "_method" field (default: "GET")
+	Confirm  string `json:"confirm,omitempty"`   // Confirmation question asked before the action runs (needs scripts)
+	Icon     string `json:"icon,omitempty"`      // Icon text shown before the label, e.g. "✎"
+	IconOnly bool   `json:"icon_only,omitempty"` // Show only the icon, keeping the label as title and accessible name
+	Variant  string `json:"variant,omitempty"`   // "primary", "danger" or "" for the default look
+
+	// Visible hides the action for rows where it returns false, e.g. Delete
+	// for locked records (default: always visible)
+	Visible func(row RowView) bool `json:"-"`
+}
+
+// confirmActionScript asks for confirmation before following action links,
+// submitting action forms or clicking bulk action buttons that carry a
+// data-confirm attribute
+const confirmActionScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var ask=function(e){var el=e.target.closest('[data-confirm]');if(el&&c.contains(el)&&!window.confirm(el.getAttribute('data-confirm')))e.preventDefault();};
+c.addEventListener('click',function(e){if(e.target.closest('a[data-confirm],button[data-confirm]'))ask(e);});
+c.addEventListener('submit',function(e){if(e.target.matches('form[data-confirm]'))ask(e);});
+})();`
+
+// hasConfirmActions reports whether any action asks for confirmation
+func hasConfirmActions(actions *Actions) bool {
+	for _, action := range actions.Items {
+		if action.Confirm != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// generateActionsHTML renders the actions of a row
+func generateActionsHTML(actions *Actions, row RowView) template.HTML {
+	var html strings.Builder
+	html.WriteString(`<div class="row-actions">`)
+	for _, action := range actions.Items {
+		if action.Visible != nil && !action.Visible(row) {
+			continue
+		}
+		href := expandURLTemplate(action.URL, row)
+
+		class := "action-btn"
+		if action.Variant == "primary" || action.Variant == "danger" {
+			class += " action-" + action.Variant
+		}
+
+		label := template.HTMLEscapeString(action.Label)
+		content := label
+		if action.Icon != "" {
+			icon := fmt.Sprintf(`<span class="action-icon" aria-hidden="true">%s</span>`, template.HTMLEscapeString(action.Icon))
+			if action.IconOnly {
+				content = icon
+			} else {
+				content = icon + " " + label
+			}
+		}
+		attrs := fmt.Sprintf(`class="%s" title="%s" aria-label="%s"`, class, label, label)
+		confirm := ""
+		if action.Confirm != "" {
+			confirm = fmt.Sprintf(` data-confirm="%s"`, template.HTMLEscapeString(action.Confirm))
+		}
+
+		method := strings.ToUpper(action.Method)
+		if method == "" || method == http.MethodGet {
+			html.WriteString(fmt.Sprintf(`<a href="%s" %s%s>%s</a>`, template.HTMLEscapeString(href), attrs, confirm, content))
+			continue
+		}
+
+		// Forms only submit GET and POST, so other methods are sent as POST
+		// with a method override field
+		html.WriteString(fmt.Sprintf(`<form method="POST" action="%s" class="action-form" data-method="%s"%s>`,
+			template.HTMLEscapeString(href), template.HTMLEscapeString(method), confirm))
+		if method != http.MethodPost {
+			html.WriteString(fmt.Sprintf(`<input type="hidden" name="_method" value="%s">`, template.HTMLEscapeString(method)))
+		}
+		names := make([]string, 0, len(actions.Hidden))
+		for name := range actions.Hidden {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+				template.HTMLEscapeString(name), template.HTMLEscapeString(actions.Hidden[name])))
+		}
+		html.WriteString(fmt.Sprintf(`<button type="submit" %s>%s</button></form>`, attrs, content))
+	}
+	html.WriteString(`</div>`)
+	return template.HTML(html.String())
+}
@@ -0,0 +1,27 @@
+package tablerenderer
+
+import "strconv"
+
+// defaultMaxAllRows is the most rows the "All" page size shows when
+// Pagination.MaxAllRows is not set
+const defaultMaxAllRows = 1000
+
+// pageSizeAll is the page_size parameter value choosing every row
+const pageSizeAll = "all"
+
+// maxAllRows returns the most rows the "All" page size shows
+func maxAllRows(pagination *Pagination) int {
+	if pagination.MaxAllRows > 0 {
+		return pagination.MaxAllRows
+	}
+	return defaultMaxAllRows
+}
+
+// pageSizeValue returns the page_size parameter of the current view: "all"
+// when every row is shown, else the page size
+func pageSizeValue(pagination *Pagination, pageSize int) string {
+	if pagination != nil && pagination.AllRows {
+		return pageSizeAll
+	}
+	return strconv.Itoa(pageSize)
+}
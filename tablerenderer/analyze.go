@@ -0,0 +1,146 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RenderReport describes the size of a rendered table and where it comes from
+type RenderReport struct {
+	TotalBytes      int               `json:"total_bytes"`      // Size of the rendered HTML
+	WhitespaceBytes int               `json:"whitespace_bytes"` // Bytes Minify would save
+	Contributors    []SizeContributor `json:"contributors"`     // Size per part of the output, largest first
+	Suggestions     []string          `json:"suggestions"`      // Options that would reduce the size
+}
+
+// SizeContributor is the size of one part of the rendered output
+type SizeContributor struct {
+	Name    string  `json:"name"`    // "cells", "headers", "styles", "scripts" or "controls"
+	Bytes   int     `json:"bytes"`   // Size in bytes
+	Percent float64 `json:"percent"` // Share of the total size
+}
+
+// whitespaceBetweenTags matches the whitespace minifyHTML collapses
+var whitespaceBetweenTags = regexp.MustCompile(`>\s{2,}<|>[\t\n\r\f]<`)
+
+// preformattedElement matches the elements whose whitespace is content
+var preformattedElement = regexp.MustCompile(`(?is)<pre\b.*?</pre>|<textarea\b.*?</textarea>`)
+
+// minifyHTML collapses each run of whitespace between tags to one space,
+// which renders the same, e.g. between inline elements. The content of pre
+// and textarea elements is left as is.
+func minifyHTML(html string) string {
+	var result strings.Builder
+	last := 0
+	for _, loc := range preformattedElement.FindAllStringIndex(html, -1) {
+		result.WriteString(whitespaceBetweenTags.ReplaceAllString(html[last:loc[0]], "> <"))
+		result.WriteString(html[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	result.WriteString(whitespaceBetweenTags.ReplaceAllString(html[last:], "> <"))
+	return result.String()
+}
+
+// AnalyzeRender renders the table and reports the output size, the biggest
+// contributors to it and options that would make it smaller
+func (r *Renderer) AnalyzeRender(data DatabasePaginatedData) (RenderReport, error) {
+	output, err := r.RenderHTML(data)
+	if err != nil {
+		return RenderReport{}, err
+	}
+
+	total := len(output)
+	styles := elementBytes(output, "style")
+	scripts := elementBytes(output, "script")
+	headers := elementBytes(output, "thead")
+	cells := elementBytes(output, "tbody")
+	controls := total - styles - scripts - headers - cells
+
+	report := RenderReport{
+		TotalBytes:      total,
+		WhitespaceBytes: total - len(minifyHTML(output)),
+	}
+	for _, part := range []struct {
+		name  string
+		bytes int
+	}{
+		{"cells", cells},
+		{"headers", headers},
+		{"styles", styles},
+		{"scripts", scripts},
+		{"controls", controls},
+	} {
+		report.Contributors = append(report.Contributors, SizeContributor{
+			Name:    part.name,
+			Bytes:   part.bytes,
+			Percent: percentOf(part.bytes, total),
+		})
+	}
+	sort.SliceStable(report.Contributors, func(i, j int) bool {
+		return report.Contributors[i].Bytes > report.Contributors[j].Bytes
+	})
+
+	if !data.Options.Minify && percentOf(report.WhitespaceBytes, total) >= 10 {
+		report.Suggestions = append(report.Suggestions,
+			fmt.Sprintf("Set Minify to save %d bytes of whitespace between tags", report.WhitespaceBytes))
+	}
+	if !data.Options.OmitStyles && percentOf(styles, total) >= 20 {
+		report.Suggestions = append(report.Suggestions,
+			fmt.Sprintf("Set OmitStyles and include Renderer.Assets().CSS once in the page layout to save %d bytes per render", styles))
+	}
+	if !data.Options.OmitScripts && percentOf(scripts, total) >= 20 {
+		report.Suggestions = append(report.Suggestions,
+			fmt.Sprintf("Set OmitScripts and include Renderer.Assets().JS once in the page layout to save %d bytes per render", scripts))
+	}
+	if data.Options.Pagination != nil && data.Options.Pagination.Enabled && percentOf(cells, total) >= 80 && data.Options.Pagination.PageSize > 25 {
+		report.Suggestions = append(report.Suggestions,
+			fmt.Sprintf("Reduce the page size (currently %d); cells make up most of the output", data.Options.Pagination.PageSize))
+	}
+
+	return report, nil
+}
+
+// elementBytes returns the combined size of every element named name in
+// html, matching start tags with attributes such as a nonce too. JSON data
+// blocks (<script type="application/json">) are not scripts and are left out.
+func elementBytes(html string, name string) int {
+	start, end := "<"+name, "</"+name+">"
+	total := 0
+	for {
+		i := strings.Index(html, start)
+		if i < 0 {
+			return total
+		}
+		rest := html[i+len(start):]
+		tagEnd := strings.IndexByte(rest, '>')
+		if tagEnd < 0 {
+			return total
+		}
+		attrs := rest[:tagEnd]
+		if attrs != "" && !strings.ContainsAny(attrs[:1], " \t\n/") {
+			// Another element sharing the prefix, e.g. <thead> for <th
+			html = rest
+			continue
+		}
+		if strings.Contains(attrs, `type="application/json"`) {
+			html = rest
+			continue
+		}
+		j := strings.Index(html[i:], end)
+		if j < 0 {
+			return total + len(html) - i
+		}
+		total += j + len(end)
+		html = html[i+j+len(end):]
+	}
+}
+
+// percentOf returns part as a percentage of total
+func percentOf(part int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
@@ -0,0 +1,107 @@
+package tablerenderer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// redactedValue replaces redacted export values
+const redactedValue = "[redacted]"
+
+// anonymizer transforms a cell value before it is exported
+type anonymizer func(value interface{}) interface{}
+
+// exportAnonymizers returns the anonymizer for each column, with nil entries
+// for columns that are exported as-is
+func exportAnonymizers(headers []string, columns []Column, opts ExportOptions) []anonymizer {
+	configs := columnsFor(headers, columns)
+	anonymizers := make([]anonymizer, len(headers))
+	for i, column := range configs {
+		if column == nil {
+			continue
+		}
+		switch column.Anonymize {
+		case "hash":
+			anonymizers[i] = hashAnonymizer(opts.AnonymizeKey)
+		case "redact":
+			anonymizers[i] = redactAnonymizer
+		case "month":
+			anonymizers[i] = monthAnonymizer
+		}
+	}
+	return anonymizers
+}
+
+// hashAnonymizer replaces values with a hex SHA-256 digest, keyed with HMAC
+// when a key is provided so that common values cannot be looked up
+func hashAnonymizer(key string) anonymizer {
+	return func(value interface{}) interface{} {
+		if value == nil {
+			return nil
+		}
+		text := exportValue(value)
+		if key == "" {
+			sum := sha256.Sum256([]byte(text))
+			return hex.EncodeToString(sum[:])
+		}
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(text))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+}
+
+// redactAnonymizer replaces every non-nil value with a fixed marker
+func redactAnonymizer(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return redactedValue
+}
+
+// monthAnonymizer generalizes dates to their month ("2006-01")
+// Values that are not recognizable dates are redacted rather than leaked
+func monthAnonymizer(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v.Format("2006-01")
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return nil
+		}
+		return v.Format("2006-01")
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.Format("2006-01")
+			}
+		}
+	}
+	return redactedValue
+}
+
+// anonymizeRow applies the column anonymizers to a row, returning the row
+// unchanged when no column is anonymized
+func anonymizeRow(row []interface{}, anonymizers []anonymizer) []interface{} {
+	var result []interface{}
+	for i, value := range row {
+		if i >= len(anonymizers) || anonymizers[i] == nil {
+			continue
+		}
+		if result == nil {
+			result = make([]interface{}, len(row))
+			copy(result, row)
+		}
+		result[i] = anonymizers[i](value)
+	}
+	if result == nil {
+		return row
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package tablerenderer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// defaultApproximateMaxPages is the highest page linked when the total is
+// an estimate and Pagination.MaxPages is not set
+const defaultApproximateMaxPages = 1000
+
+// approximatePagination calculates the pagination of an estimated total.
+// The page count is capped at MaxPages, and grows past the estimate when the
+// current page lies beyond it and is full.
+func approximatePagination(currentPageDataCount int, pagination *Pagination) PaginationInfo {
+	maxPages := pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultApproximateMaxPages
+	}
+	currentPage := max(pagination.CurrentPage, 1)
+
+	totalPages := min((pagination.TotalCount+pagination.PageSize-1)/pagination.PageSize, maxPages)
+	if currentPage >= totalPages {
+		totalPages = currentPage
+		if currentPageDataCount >= pagination.PageSize && currentPage < maxPages {
+			totalPages++
+		}
+	}
+
+	startRow := (currentPage-1)*pagination.PageSize + 1
+	return PaginationInfo{
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+		TotalRows:   max(pagination.TotalCount, startRow-1+currentPageDataCount),
+		PageSize:    pagination.PageSize,
+		StartRow:    startRow,
+		EndRow:      startRow - 1 + currentPageDataCount,
+		Approximate: true,
+	}
+}
+
+// formatApproximateCount rounds an estimated count to two significant digits
+// with a K, M or B suffix, e.g. "1.2M" or "35K"
+func formatApproximateCount(count int) string {
+	units := []struct {
+		size   float64
+		suffix string
+	}{{1e9, "B"}, {1e6, "M"}, {1e3, "K"}}
+	for _, unit := range units {
+		// Counts rounding up to the unit, e.g. 999,600, take that unit
+		if float64(count) < unit.size*0.9995 {
+			continue
+		}
+		value := float64(count) / unit.size
+		precision := 0
+		if value < 10 {
+			precision = 1
+		}
+		text := strconv.FormatFloat(value, 'f', precision, 64)
+		return strings.TrimSuffix(text, ".0") + unit.suffix
+	}
+	return strconv.Itoa(count)
+}
@@ -0,0 +1,41 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+)
+
+// ExportArtifact describes a generated export file served for download
+type ExportArtifact struct {
+	Name        string    `json:"name"`                   // File name offered to the client, e.g. "orders.csv"
+	ContentType string    `json:"content_type,omitempty"` // Media type (default: derived from the file extension)
+	Size        int64     `json:"size"`                   // Size in bytes
+	ModTime     time.Time `json:"mod_time"`               // Last modification, used for conditional and range requests
+}
+
+// ServeExportArtifact writes an export artifact as a download, supporting
+// Range and If-Range requests so interrupted downloads of large exports can
+// resume instead of starting over
+func ServeExportArtifact(w http.ResponseWriter, req *http.Request, artifact ExportArtifact, content io.ReadSeeker) {
+	contentType := artifact.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(artifact.Name))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(artifact.Name)}))
+	// A strong validator lets clients resume with If-Range only while the
+	// artifact is unchanged
+	header.Set("ETag", fmt.Sprintf(`"%x-%x"`, artifact.ModTime.UnixNano(), artifact.Size))
+
+	http.ServeContent(w, req, artifact.Name, artifact.ModTime, content)
+}
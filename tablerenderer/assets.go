@@ -0,0 +1,44 @@
+package tablerenderer
+
+import (
+	"html/template"
+	"strings"
+)
+
+// Assets are the CSS and JavaScript rendered tables depend on. The tables
+// need nothing else, e.g. Bootstrap, unless Renderer.Icons is an icon font
+// such as IconsFontAwesome. Include them once in the page layout and render
+// tables with OmitStyles and OmitScripts, so that pages with several tables
+// do not repeat them.
+type Assets struct {
+	CSS string `json:"css"` // Styles of tables, detail tables, tab groups and the print view
+	JS  string `json:"js"`  // Enhancement scripts, run for the tables' script markers; see TableScript
+}
+
+// Assets returns the CSS and JavaScript of the renderer's tables. Plugin
+// assets are not included; plugins still emit them with each table.
+func (r *Renderer) Assets() Assets {
+	css := []string{
+		tableStyles,
+		printStyles,
+		styleElementCSS(detailStyles),
+		styleElementCSS(tabGroupStyles),
+	}
+	return Assets{
+		CSS: strings.Join(css, "\n"),
+		JS:  TableScript(),
+	}
+}
+
+// HTML renders the assets as <style> and <script> elements for the page
+// layout, carrying nonce unless it is empty
+func (a Assets) HTML(nonce string) template.HTML {
+	return template.HTML("<style" + nonceAttr(nonce) + ">" + a.CSS + "</style>\n" +
+		"<script" + nonceAttr(nonce) + ">" + a.JS + "</script>\n")
+}
+
+// styleElementCSS returns the CSS of a <style> element
+func styleElementCSS(style string) string {
+	style = strings.TrimSpace(style)
+	return strings.TrimSuffix(strings.TrimPrefix(style, "<style>"), "</style>")
+}
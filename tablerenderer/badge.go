@@ -0,0 +1,64 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+)
+
+// Badge is a colored label shown for a cell value
+type Badge struct {
+	Label string `json:"label,omitempty"` // Text shown (default: the value)
+	Color string `json:"color,omitempty"` // "green", "red", "yellow", "blue", "gray" or a hex color such as "#6f42c1" (default: "gray")
+}
+
+// BadgeFormat maps cell values to badges, e.g. "active" to a green badge
+type BadgeFormat struct {
+	Badges  map[string]Badge `json:"badges"`            // Badge per value, keyed by the value's text
+	Default *Badge           `json:"default,omitempty"` // Badge for unmapped values (default: plain text)
+}
+
+// badgeColors lists the named badge colors, which map to CSS classes
+var badgeColors = map[string]bool{
+	"green":  true,
+	"red":    true,
+	"yellow": true,
+	"blue":   true,
+	"gray":   true,
+}
+
+// hexColor matches the custom badge colors accepted in inline styles
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// formatBadge renders a value as a badge, reporting false when the value is
+// not mapped and there is no default badge. With classes, custom colors are
+// set by a class from CSPStyles instead of a style attribute.
+func formatBadge(value interface{}, format *BadgeFormat, classes bool) (template.HTML, bool) {
+	text := exportValue(value)
+	badge, ok := format.Badges[text]
+	if !ok {
+		if format.Default == nil {
+			return "", false
+		}
+		badge = *format.Default
+	}
+
+	label := badge.Label
+	if label == "" {
+		label = text
+	}
+
+	class, style := "badge-gray", ""
+	switch {
+	case badgeColors[badge.Color]:
+		class = "badge-" + badge.Color
+	case hexColor.MatchString(badge.Color) && classes:
+		class = "badge-custom " + badgeColorClass(badge.Color)
+	case hexColor.MatchString(badge.Color):
+		class = "badge-custom"
+		style = fmt.Sprintf(` style="background-color: %s; color: #fff"`, badge.Color)
+	}
+
+	return template.HTML(fmt.Sprintf(`<span class="badge %s"%s>%s</span>`,
+		class, style, template.HTMLEscapeString(label))), true
+}
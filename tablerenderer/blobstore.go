@@ -0,0 +1,135 @@
+package tablerenderer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// BlobStore stores generated export artifacts
+type BlobStore interface {
+	// Put stores content under key, replacing any existing blob
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	// Open opens the blob stored under key for reading
+	Open(ctx context.Context, key string) (Blob, error)
+	// Delete removes the blob stored under key
+	Delete(ctx context.Context, key string) error
+}
+
+// Blob is a stored artifact opened for reading
+// It can be passed to ServeExportArtifact together with its Artifact()
+type Blob interface {
+	io.ReadSeekCloser
+	Artifact() ExportArtifact
+}
+
+// cleanBlobKey validates a blob key, rejecting keys that would escape the
+// store's root
+func cleanBlobKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key || strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return cleaned, nil
+}
+
+// FileBlobStore stores blobs as files below a directory
+type FileBlobStore struct {
+	Dir string // Root directory of the store
+}
+
+// NewFileBlobStore creates a filesystem blob store rooted at dir
+func NewFileBlobStore(dir string) *FileBlobStore {
+	return &FileBlobStore{Dir: dir}
+}
+
+// Put implements BlobStore; content is written to a temporary file first
+// so readers never see a partially written artifact
+func (s *FileBlobStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	filename, err := s.filename(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return nil
+}
+
+// Open implements BlobStore
+func (s *FileBlobStore) Open(ctx context.Context, key string) (Blob, error) {
+	filename, err := s.filename(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat blob: %w", err)
+	}
+	return &fileBlob{
+		File: file,
+		artifact: ExportArtifact{
+			Name:    path.Base(key),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		},
+	}, nil
+}
+
+// Delete implements BlobStore
+func (s *FileBlobStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.filename(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	return nil
+}
+
+// filename maps a blob key to a path below the store directory
+func (s *FileBlobStore) filename(key string) (string, error) {
+	cleaned, err := cleanBlobKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(cleaned)), nil
+}
+
+// fileBlob is a Blob backed by an open file
+type fileBlob struct {
+	*os.File
+	artifact ExportArtifact
+}
+
+// Artifact implements Blob
+func (b *fileBlob) Artifact() ExportArtifact {
+	return b.artifact
+}
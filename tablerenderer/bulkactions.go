@@ -0,0 +1,100 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// BulkAction is a button of the bulk actions bar rendered with the
+// selection column. It submits the selection form, carrying the IDs of the
+// checked rows under the checkbox name, to its own endpoint.
+type BulkAction struct {
+	Label   string `json:"label"`             // Button text
+	URL     string `json:"url"`               // Endpoint the selected IDs are submitted to
+	Method  string `json:"method,omitempty"`  // "GET" sends the IDs in the query; others POST, with a "_method" field other than for POST (default: "POST")
+	Confirm string `json:"confirm,omitempty"` // Confirmation question asked before submitting (needs scripts)
+	Variant string `json:"variant,omitempty"` // "primary", "danger" or "" for the default look
+}
+
+// bulkActionsScript disables the bulk action buttons while no row is
+// selected
+const bulkActionsScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var bar=c.querySelector('.bulk-actions');if(!bar)return;
+var sync=function(){var n=c.querySelectorAll('.select-row:checked').length;bar.querySelectorAll('button').forEach(function(b){b.disabled=n===0;});};
+c.addEventListener('change',function(e){if(e.target.matches('.select-row,.select-all'))sync();});
+sync();
+})();`
+
+// DeleteSelected returns a bulk action deleting the selected rows with a
+// DELETE request to url, after confirmation
+func (r *Renderer) DeleteSelected(url string) BulkAction {
+	return BulkAction{
+		Label:   r.translate(LabelDeleteSelected, 1),
+		URL:     url,
+		Method:  http.MethodDelete,
+		Confirm: r.translate(LabelConfirmDelete, 1),
+		Variant: "danger",
+	}
+}
+
+// ExportSelected returns a bulk action requesting the selected rows from
+// url with GET, e.g. an export endpoint filtering by the selected IDs
+func (r *Renderer) ExportSelected(url string) BulkAction {
+	return BulkAction{
+		Label:  r.translate(LabelExportSelected, 1),
+		URL:    url,
+		Method: http.MethodGet,
+	}
+}
+
+// hasConfirmBulkActions reports whether any bulk action asks for
+// confirmation
+func hasConfirmBulkActions(selection *Selection) bool {
+	if selection == nil {
+		return false
+	}
+	for _, action := range selection.BulkActions {
+		if action.Confirm != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// generateBulkActionsHTML renders the bulk action buttons of the selection
+// form. Each button sets the form's action and method for its submission.
+func (r *Renderer) generateBulkActionsHTML(actions []BulkAction) string {
+	if len(actions) == 0 {
+		return ""
+	}
+	var html strings.Builder
+	html.WriteString(fmt.Sprintf(`<div class="bulk-actions" role="group" aria-label="%s">`,
+		template.HTMLEscapeString(r.translate(LabelBulkActions, 1))))
+	for _, action := range actions {
+		class := "action-btn"
+		if action.Variant == "primary" || action.Variant == "danger" {
+			class += " action-" + action.Variant
+		}
+		attrs := fmt.Sprintf(`formaction="%s"`, template.HTMLEscapeString(safeURL(action.URL)))
+		switch method := strings.ToUpper(action.Method); method {
+		case http.MethodGet:
+			attrs += ` formmethod="get"`
+		case "", http.MethodPost:
+			attrs += ` formmethod="post"`
+		default:
+			// The submitting button's name and value are sent with the form,
+			// overriding the method for this action only
+			attrs += fmt.Sprintf(` formmethod="post" name="_method" value="%s"`, template.HTMLEscapeString(method))
+		}
+		if action.Confirm != "" {
+			attrs += fmt.Sprintf(` data-confirm="%s"`, template.HTMLEscapeString(action.Confirm))
+		}
+		html.WriteString(fmt.Sprintf(`<button type="submit" class="%s" %s>%s</button>`,
+			class, attrs, template.HTMLEscapeString(action.Label)))
+	}
+	html.WriteString(`</div>`)
+	return html.String()
+}
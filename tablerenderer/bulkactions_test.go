@@ -0,0 +1,70 @@
+package tablerenderer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBulkActions(t *testing.T) {
+	r := NewRenderer()
+	tests := []struct {
+		name    string
+		policy  JSPolicy
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "with scripts",
+			want: []string{
+				`<form id="users-selection" method="POST" action="" class="selection-form"><input type="hidden" name="csrf" value="token"><div class="bulk-actions" role="group"`,
+				`<button type="submit" class="action-btn action-danger" formaction="/users/delete" formmethod="post" name="_method" value="DELETE" data-confirm="`,
+				`<button type="submit" class="action-btn" formaction="/users/export" formmethod="get">`,
+				`<button type="submit" class="action-btn action-primary" formaction="/users/archive" formmethod="post">Archive</button>`,
+				`name="id" value="2" form="users-selection"`,
+				"querySelectorAll('.select-row:checked')",
+			},
+		},
+		{
+			name:    "without scripts",
+			policy:  JSNone,
+			want:    []string{`class="bulk-actions"`},
+			notWant: []string{"<script", "querySelectorAll"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.RenderHTML(DatabasePaginatedData{
+				Headers: []string{"ID", "Name"},
+				Rows:    [][]interface{}{{1, "Ada"}, {2, "Bob"}},
+				Options: TableOptions{
+					ID:       "users",
+					JSPolicy: tt.policy,
+					Selection: &Selection{
+						Enabled:   true,
+						IDColumn:  "ID",
+						InputName: "id",
+						Form:      &SelectionForm{Hidden: map[string]string{"csrf": "token"}},
+						BulkActions: []BulkAction{
+							r.DeleteSelected("/users/delete"),
+							r.ExportSelected("/users/export"),
+							{Label: "Archive", URL: "/users/archive", Variant: "primary"},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("missing %s in:\n%s", want, html)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(html, notWant) {
+					t.Errorf("unexpected %s", notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package tablerenderer
+
+import "strings"
+
+// joinClasses joins the non-empty classes with spaces
+func joinClasses(classes ...string) string {
+	var parts []string
+	for _, class := range classes {
+		if class = strings.TrimSpace(class); class != "" {
+			parts = append(parts, class)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// columnClass returns the classes of the data cells of a column: its
+// CellClass and responsive class
+func columnClass(column *Column) string {
+	if column == nil {
+		return ""
+	}
+	return joinClasses(column.CellClass, priorityClass(column))
+}
+
+// columnClasses returns the data cell classes of each header's column
+func columnClasses(headers []string, columns []Column) []string {
+	classes := make([]string, len(headers))
+	for j, column := range columnsFor(headers, columns) {
+		classes[j] = columnClass(column)
+	}
+	return classes
+}
+
+// headerClasses returns the class attribute value of each header cell:
+// frozen for the first frozen columns, the column's HeaderClass and its
+// responsive class
+func headerClasses(headers []string, columns []Column, frozen int) []string {
+	classes := make([]string, len(headers))
+	for j, column := range columnsFor(headers, columns) {
+		var frozenClass, headerClass string
+		if j < frozen {
+			frozenClass = "frozen"
+		}
+		if column != nil {
+			headerClass = column.HeaderClass
+		}
+		classes[j] = joinClasses(frozenClass, headerClass, priorityClass(column))
+	}
+	return classes
+}
+
+// addCellClass adds class to the classes of cell j of row
+func addCellClass(row *tableRow, j int, class string) {
+	class = strings.TrimSpace(class)
+	if class == "" {
+		return
+	}
+	if row.Classes == nil {
+		row.Classes = make([]string, len(row.Cells))
+	}
+	if j >= len(row.Classes) {
+		return
+	}
+	row.Classes[j] = joinClasses(row.Classes[j], class)
+}
+
+// markCellClasses adds the frozen class to the first frozen cells, the
+// column classes and the classes returned by CellClassFunc to the cells of
+// each row
+func markCellClasses(rendered []tableRow, headers []string, rows [][]interface{}, options TableOptions) {
+	classes := columnClasses(headers, options.Columns)
+	frozen := frozenDataColumns(options)
+	for i := range rendered {
+		markRowClasses(&rendered[i], RowView{Index: i, Headers: headers, Values: rows[i]}, classes, frozen, options.CellClassFunc)
+	}
+}
+
+// markRowClasses adds the classes of markCellClasses to the cells of a
+// single row; classes are the column classes from columnClasses
+func markRowClasses(row *tableRow, view RowView, classes []string, frozen int, cellClass func(row RowView, header string, value interface{}) string) {
+	for j := 0; j < frozen; j++ {
+		addCellClass(row, j, "frozen")
+	}
+	for j, class := range classes {
+		addCellClass(row, j, class)
+	}
+	if cellClass == nil {
+		return
+	}
+	for j, header := range view.Headers {
+		if j < len(view.Values) {
+			addCellClass(row, j, cellClass(view, header, view.Values[j]))
+		}
+	}
+}
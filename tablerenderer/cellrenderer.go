@@ -0,0 +1,33 @@
+package tablerenderer
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// CellRenderer is implemented by domain types that render their own cells,
+// e.g. a Money type or a status enum shown as a badge. The returned markup
+// is trusted like HTML and takes precedence over column formatting.
+type CellRenderer interface {
+	RenderCell() HTML
+}
+
+// domainValue resolves values that describe their own display: a
+// fmt.Stringer becomes its text and a driver.Valuer its driver value, nil
+// when it fails. Times keep their type for the time formatting.
+func domainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time, *time.Time:
+		return value
+	case fmt.Stringer:
+		return v.String()
+	case driver.Valuer:
+		resolved, err := v.Value()
+		if err != nil {
+			return nil
+		}
+		return resolved
+	}
+	return value
+}
@@ -0,0 +1,98 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+)
+
+// ClientSide sorts, searches and pages the rendered rows in the browser,
+// for small tables where server round trips are not worth it. Render every
+// row with server pagination, sorting and search disabled.
+type ClientSide struct {
+	PageSize int `json:"page_size,omitempty"` // Rows per page; negative for a single page (default: 10)
+}
+
+// defaultClientPageSize is the client-side page size when none is set
+const defaultClientPageSize = 10
+
+// ToolbarItemClientSearch is the name of the client-side search box
+const ToolbarItemClientSearch = "client_search"
+
+// clientSideScript sorts by the clicked header, filters by the search box
+// and pages the rows, using the data-sort values the server renders on the
+// cells. Empty values sort last in both directions. Group rows are hidden
+// once the order or the visible rows change.
+const clientSideScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var t=c.querySelector('table.data-table');if(!t||!t.tBodies[0])return;
+var body=t.tBodies[0],nav=c.querySelector('.client-pagination'),size=nav?+nav.getAttribute('data-page-size'):0;
+var rows=[].slice.call(body.querySelectorAll('tr:not(.group-header):not(.group-subtotal):not(.empty-state-row)'));
+var groups=body.querySelectorAll('tr.group-header,tr.group-subtotal');
+var input=c.querySelector('.client-search input'),empty=c.querySelector('.client-empty');
+var col=-1,desc=false,page=0;
+function val(tr){var td=tr.querySelectorAll('td[data-sort]')[col];return td?td.getAttribute('data-sort'):'';}
+function cmp(a,b){var x=val(a),y=val(b);if(x===''||y==='')return (x==='')-(y==='');
+var r=isFinite(x)&&isFinite(y)?x-y:x.localeCompare(y);return desc?-r:r;}
+function draw(){
+var q=input?input.value.trim().toLowerCase():'';
+var list=rows.filter(function(tr){return !q||tr.textContent.toLowerCase().indexOf(q)>=0;});
+if(col>=0){list.sort(cmp);list.forEach(function(tr){body.appendChild(tr);});}
+var pages=size>0?Math.max(1,Math.ceil(list.length/size)):1;if(page>=pages)page=pages-1;
+var shown=size>0?list.slice(page*size,(page+1)*size):list;
+rows.forEach(function(tr){tr.hidden=shown.indexOf(tr)<0;});
+groups.forEach(function(g){g.hidden=!!q||col>=0||pages>1;});
+if(empty)empty.hidden=list.length>0;
+if(nav){nav.hidden=pages<2;nav.querySelector('.client-page-info').textContent=(page+1)+' / '+pages;
+nav.querySelector('.client-prev').disabled=page<1;nav.querySelector('.client-next').disabled=page>=pages-1;}
+}
+t.querySelectorAll('thead th[data-column]').forEach(function(th){th.addEventListener('click',function(e){
+e.preventDefault();var i=+th.getAttribute('data-column');if(col===i){desc=!desc;}else{col=i;desc=false;}
+t.querySelectorAll('thead th[data-column]').forEach(function(h){h.setAttribute('aria-sort',h===th?(desc?'descending':'ascending'):'none');});
+page=0;draw();});});
+if(input)input.addEventListener('input',function(){page=0;draw();});
+if(nav){nav.querySelector('.client-prev').addEventListener('click',function(){page--;draw();});
+nav.querySelector('.client-next').addEventListener('click',function(){page++;draw();});}
+draw();
+})();`
+
+// generateClientSideHTML generates the client-side search box, the pager
+// and the message shown when the search hides every row
+func (r *Renderer) generateClientSideHTML(clientSide *ClientSide) (search string, pager string, emptyMessage string) {
+	label := template.HTMLEscapeString(r.translate(LabelSearchPlaceholder, 1))
+	search = fmt.Sprintf(`<div class="client-search"><input type="search" placeholder="%s" aria-label="%s"></div>`, label, label)
+
+	pageSize := clientSide.PageSize
+	if pageSize == 0 {
+		pageSize = defaultClientPageSize
+	}
+	if pageSize > 0 {
+		pager = fmt.Sprintf(`<nav class="client-pagination" data-page-size="%d" hidden><button type="button" class="page-link client-prev">%s</button><span class="client-page-info"></span><button type="button" class="page-link client-next">%s</button></nav>`,
+			pageSize, template.HTMLEscapeString(r.translate(LabelPrevious, 1)), template.HTMLEscapeString(r.translate(LabelNext, 1)))
+	}
+
+	emptyMessage = fmt.Sprintf(`<div class="no-results client-empty" hidden>%s</div>`,
+		template.HTMLEscapeString(r.translate(LabelNoRecords, 0)))
+	return search, pager, emptyMessage
+}
+
+// clientSortValues returns the data-sort value of each cell: numbers and
+// times as numbers, so the script compares them numerically, other values
+// as their export text and empty values as ""
+func clientSortValues(row []interface{}) []string {
+	values := make([]string, len(row))
+	for i, value := range row {
+		value = domainValue(value)
+		if isEmptyValue(value) {
+			continue
+		}
+		if t, ok := timeValue(value); ok {
+			values[i] = strconv.FormatInt(t.UnixMilli(), 10)
+		} else if n, ok := numericValue(value); ok {
+			values[i] = strconv.FormatFloat(n, 'g', -1, 64)
+		} else {
+			values[i] = exportValue(value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,163 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Column holds per-column configuration, matched to a header by name
+type Column struct {
+	Header    string `json:"header"`              // Header this configuration applies to
+	Label     string `json:"label,omitempty"`     // Text shown in the header cell (default: the header, humanized for struct fields)
+	Anonymize string `json:"anonymize,omitempty"` // Export-only anonymization: "hash", "redact" or "month"
+
+	Format   *NumberFormat   `json:"format,omitempty"`   // Numeric formatting for int and float cells
+	Currency *CurrencyFormat `json:"currency,omitempty"` // Currency formatting for int and float cells
+
+	// Precision rounds float cells to this many decimals with Rounding in
+	// HTML, footer statistics, group subtotals and exports alike, and
+	// overrides the decimals of Format and Currency. Negative values count
+	// as 0.
+	Precision *int   `json:"precision,omitempty"`
+	Rounding  string `json:"rounding,omitempty"` // RoundHalfUp or RoundHalfEven (default: RoundHalfUp)
+
+	TimeLayout  string       `json:"time_layout,omitempty"` // Layout for time.Time cells (default: Renderer.TimeLayout)
+	Bool        *BoolFormat  `json:"bool,omitempty"`        // Text for bool cells (default: Renderer.BoolFormat)
+	Placeholder string       `json:"placeholder,omitempty"` // Text for nil, empty and zero-time cells (default: Renderer.Placeholder)
+	MaxLength   int          `json:"max_length,omitempty"`  // Truncate longer values with an ellipsis; the full value goes in the title
+	MaxWidth    string       `json:"max_width,omitempty"`   // CSS max width of cells, e.g. "200px", cutting overflow with an ellipsis
+	Link        *LinkFormat  `json:"link,omitempty"`        // Render cells as links built from row values
+	Badge       *BadgeFormat `json:"badge,omitempty"`       // Map values to colored badges, e.g. status columns
+	DrillDown   *DrillDown   `json:"drill_down,omitempty"`  // Link cells to another registered table filtered by the value
+	Weight      float64      `json:"weight,omitempty"`      // Share of the spare width in text and email layouts relative to other columns (default: 1)
+	SortOrder   string       `json:"sort_order,omitempty"`  // Fixed direction of the header sort link, "asc" or "desc", e.g. "desc" for dates (default: toggles)
+	Editor      *CellEditor  `json:"editor,omitempty"`      // Input of the column in inline edit mode (default: by value type)
+	Priority    int          `json:"priority,omitempty"`    // Responsive priority, PriorityEssential to PriorityLowest; higher ones hide first on narrow screens (default: always shown)
+
+	// CellClass and HeaderClass are CSS classes added to the column's data
+	// and header cells, e.g. "text-end" to align numbers to the end
+	CellClass   string `json:"cell_class,omitempty"`
+	HeaderClass string `json:"header_class,omitempty"`
+
+	// HeaderTemplate is an html/template snippet rendered as the header cell
+	// content, inside the sort link when sorting is enabled. It receives a
+	// HeaderContext, e.g. `{{.Header}} <small>(kg)</small>`.
+	HeaderTemplate string `json:"header_template,omitempty"`
+
+	// styleClasses renders badge colors and MaxWidth as classes from
+	// CSPStyles instead of style attributes; set in CSP mode
+	styleClasses bool
+}
+
+// HeaderContext is the data passed to Column.HeaderTemplate
+type HeaderContext struct {
+	Header    string // Header name
+	Label     string // Text shown for the header, see Column.Label
+	Sorted    bool   // Whether the table is currently sorted by this column
+	SortOrder string // Current sort order when Sorted: "asc" or "desc"
+}
+
+// columnsFor returns the column configuration for each header, with nil
+// entries for headers that have no configuration
+func columnsFor(headers []string, columns []Column) []*Column {
+	result := make([]*Column, len(headers))
+	if len(columns) == 0 {
+		return result
+	}
+
+	byHeader := make(map[string]*Column, len(columns))
+	for i := range columns {
+		byHeader[columns[i].Header] = &columns[i]
+	}
+	for i, header := range headers {
+		result[i] = byHeader[header]
+	}
+	return result
+}
+
+// selectColumns keeps the visible columns of the rows, in the order given.
+// Unknown headers are ignored and the source column is kept for
+// extractSource. Without visible columns all are kept.
+func selectColumns(headers []string, rows [][]interface{}, visible []string, source *SourceFormat) ([]string, [][]interface{}) {
+	if len(visible) == 0 {
+		return headers, rows
+	}
+	index := make(map[string]int, len(headers))
+	for i, header := range headers {
+		index[header] = i
+	}
+
+	var keep []int
+	seen := make(map[string]bool, len(visible))
+	for _, header := range visible {
+		if i, ok := index[header]; ok && !seen[header] {
+			keep = append(keep, i)
+			seen[header] = true
+		}
+	}
+	if source != nil && source.Header != "" && !seen[source.Header] {
+		if i, ok := index[source.Header]; ok {
+			keep = append(keep, i)
+		}
+	}
+	if len(keep) == 0 {
+		return headers, rows
+	}
+
+	selected := make([]string, len(keep))
+	for j, i := range keep {
+		selected[j] = headers[i]
+	}
+	projected := make([][]interface{}, len(rows))
+	for r, row := range rows {
+		projected[r] = make([]interface{}, len(keep))
+		for j, i := range keep {
+			if i < len(row) {
+				projected[r][j] = row[i]
+			}
+		}
+	}
+	return selected, projected
+}
+
+// setColumnsParam adds the visible columns to the parameters preserved in
+// links
+func setColumnsParam(params map[string]string, visible []string) {
+	if len(visible) > 0 {
+		params["columns"] = strings.Join(visible, ",")
+	}
+}
+
+// renderHeaderContents returns the content of each header cell, executing
+// the column header templates and escaping plain header names
+func (r *Renderer) renderHeaderContents(headers []string, columns []Column, sorting *Sorting) ([]template.HTML, error) {
+	configs := columnsFor(headers, columns)
+	contents := make([]template.HTML, len(headers))
+
+	for i, header := range headers {
+		column := configs[i]
+		if column == nil || column.HeaderTemplate == "" {
+			contents[i] = template.HTML(template.HTMLEscapeString(headerLabel(column, header)))
+			continue
+		}
+
+		tmpl, err := template.New("header").Parse(column.HeaderTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse header template for column %q: %w", header, err)
+		}
+
+		context := HeaderContext{Header: header, Label: headerLabel(column, header)}
+		if sorting != nil && sorting.Enabled && sorting.SortBy == header {
+			context.Sorted = true
+			context.SortOrder = sorting.SortOrder
+		}
+
+		var content strings.Builder
+		if err := tmpl.Execute(&content, context); err != nil {
+			return nil, fmt.Errorf("failed to execute header template for column %q: %w", header, err)
+		}
+		contents[i] = template.HTML(content.String())
+	}
+	return contents, nil
+}
@@ -0,0 +1,178 @@
+package tablerenderer
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressibleTypes lists the media types CompressHandler compresses
+var compressibleTypes = map[string]bool{
+	"text/html":            true,
+	"text/plain":           true,
+	"text/csv":             true,
+	"application/json":     true,
+	"application/x-ndjson": true,
+	"application/jsonl":    true,
+}
+
+// encoder is a pooled compressing writer, a *gzip.Writer or *brotli.Writer
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Content codings CompressHandler applies, most preferred first
+var encoders = []struct {
+	coding string
+	pool   *sync.Pool
+}{
+	{"br", &sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, brotli.DefaultCompression) }}},
+	{"gzip", &sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}},
+}
+
+// CompressHandler wraps a table handler and compresses HTML, JSON, CSV and
+// JSONL responses with Brotli or gzip for clients that accept them, taking
+// the coding with the highest Accept-Encoding weight and Brotli on a tie.
+// Compression is streaming: flushing the response flushes the compressed
+// stream, so large exports are sent incrementally. Range requests and
+// responses that already carry a Content-Encoding are passed through
+// untouched.
+func CompressHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		coding := negotiateEncoding(req.Header.Get("Accept-Encoding"))
+		if coding < 0 || req.Header.Get("Range") != "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, coding: coding}
+		defer cw.Close()
+		next.ServeHTTP(cw, req)
+	})
+}
+
+// negotiateEncoding returns the index in encoders of the coding an
+// Accept-Encoding header prefers, or -1 when it accepts none of them
+func negotiateEncoding(acceptEncoding string) int {
+	weights := make([]float64, len(encoders))
+	for i := range weights {
+		weights[i] = -1
+	}
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if coding == "*" {
+			wildcard = weight
+			continue
+		}
+		for i, encoder := range encoders {
+			if coding == encoder.coding {
+				weights[i] = weight
+			}
+		}
+	}
+
+	best, bestWeight := -1, 0.0
+	for i, weight := range weights {
+		// Codings the header does not name get the weight of "*"
+		if weight < 0 {
+			weight = wildcard
+		}
+		// An explicit q=0 rejects the coding
+		if weight > bestWeight {
+			best, bestWeight = i, weight
+		}
+	}
+	return best
+}
+
+// compressWriter decides on the first write whether to compress the response
+type compressWriter struct {
+	http.ResponseWriter
+	coding      int     // Index in encoders of the negotiated coding
+	enc         encoder // Set while the response is compressed
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+
+	header := cw.Header()
+	if status != http.StatusNoContent && status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" && isCompressible(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", encoders[cw.coding].coding)
+		header.Del("Content-Length")
+		enc := encoders[cw.coding].pool.Get().(encoder)
+		enc.Reset(cw.ResponseWriter)
+		cw.enc = enc
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		if cw.Header().Get("Content-Type") == "" {
+			cw.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher so streamed responses reach the client
+func (cw *compressWriter) Flush() {
+	if cw.enc != nil {
+		cw.enc.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close finishes the compressed stream
+func (cw *compressWriter) Close() {
+	if cw.enc == nil {
+		return
+	}
+	cw.enc.Close()
+	encoders[cw.coding].pool.Put(cw.enc)
+	cw.enc = nil
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// isCompressible reports whether a Content-Type is worth compressing
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return compressibleTypes[mediaType]
+}
@@ -0,0 +1,85 @@
+package tablerenderer
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestCompressHandler(t *testing.T) {
+	body := strings.Repeat("<tr><td>row</td></tr>\n", 200)
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		rangeHeader    string
+		contentType    string
+		encoded        bool // The handler sets its own Content-Encoding
+		wantEncoding   string
+	}{
+		{name: "gzip", acceptEncoding: "gzip", contentType: "text/html; charset=utf-8", wantEncoding: "gzip"},
+		{name: "no Accept-Encoding", contentType: "text/html"},
+		{name: "gzip rejected with q=0", acceptEncoding: "gzip;q=0, identity", contentType: "text/html"},
+		{name: "wildcard", acceptEncoding: "*", contentType: "application/json", wantEncoding: "br"},
+		{name: "brotli preferred on a tie", acceptEncoding: "gzip, deflate, br", contentType: "text/csv", wantEncoding: "br"},
+		{name: "higher weight wins", acceptEncoding: "br;q=0.5, gzip;q=0.8", contentType: "text/html", wantEncoding: "gzip"},
+		{name: "wildcard weight for unnamed codings", acceptEncoding: "gzip;q=0.4, *;q=0.6", contentType: "text/html", wantEncoding: "br"},
+		{name: "brotli rejected", acceptEncoding: "br;q=0, *", contentType: "text/html", wantEncoding: "gzip"},
+		{name: "range request", acceptEncoding: "gzip", rangeHeader: "bytes=0-99", contentType: "text/csv"},
+		{name: "not compressible", acceptEncoding: "gzip", contentType: "image/png"},
+		{name: "already encoded", acceptEncoding: "gzip", contentType: "text/html", encoded: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.encoded {
+					w.Header().Set("Content-Encoding", "identity")
+				}
+				io.WriteString(w, body)
+			}))
+			req := httptest.NewRequest("GET", "/orders", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			if got := recorder.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			gotEncoding := recorder.Header().Get("Content-Encoding")
+			if tt.encoded {
+				gotEncoding = ""
+			}
+			if gotEncoding != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", gotEncoding, tt.wantEncoding)
+			}
+			var reader io.Reader = recorder.Body
+			switch gotEncoding {
+			case "gzip":
+				gz, err := gzip.NewReader(recorder.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader() error = %v", err)
+				}
+				reader = gz
+			case "br":
+				reader = brotli.NewReader(recorder.Body)
+			}
+			decoded, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("reading the body: %v", err)
+			}
+			if string(decoded) != body {
+				t.Errorf("decoded body has %d bytes, want %d", len(decoded), len(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// copyTableScript copies the header and the rows shown of the table as
+// tab-separated values, leaving out selection and action cells, group rows
+// and rows hidden by the quick filter. Tabs and line breaks within cells
+// become spaces. Browsers without the Clipboard API copy through a hidden
+// textarea instead.
+const copyTableScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+function text(cell){var s=cell.querySelector('.sort-link > span');return (s||cell).textContent.replace(/\s+/g,' ').trim();}
+function tsv(){var t=c.querySelector('table.data-table');if(!t)return '';var lines=[];
+t.querySelectorAll('thead tr, tbody tr:not(.group-header):not(.group-subtotal):not(.empty-state-row)').forEach(function(tr){if(tr.hidden)return;
+var cells=[];tr.querySelectorAll('th,td').forEach(function(cell){if(!cell.matches('.select-cell,.actions-cell'))cells.push(text(cell));});lines.push(cells.join('\t'));});
+return lines.join('\n')+'\n';}
+function fallback(s){var ta=document.createElement('textarea');ta.value=s;ta.setAttribute('readonly','');ta.style.position='fixed';ta.style.opacity='0';document.body.appendChild(ta);ta.select();
+try{document.execCommand('copy');}finally{ta.remove();}}
+c.addEventListener('click',function(e){
+var b=e.target.closest('button[data-table-copy]');if(!b||!c.contains(b))return;
+var s=tsv(),done=function(){b.setAttribute('data-copied','true');setTimeout(function(){b.removeAttribute('data-copied');},2000);};
+if(navigator.clipboard){navigator.clipboard.writeText(s).then(done,function(){fallback(s);done();});}else{fallback(s);done();}
+});
+})();`
+
+// generateCopyButtonHTML generates the toolbar button copying the table
+func (r *Renderer) generateCopyButtonHTML() string {
+	return fmt.Sprintf(`<button type="button" class="copy-button" data-table-copy>%s</button>`,
+		template.HTMLEscapeString(r.translate(LabelCopy, 1)))
+}
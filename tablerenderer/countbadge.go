@@ -0,0 +1,79 @@
+package tablerenderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// countBadgeFormat formats counts with thousands separators
+var countBadgeFormat = &NumberFormat{Thousands: ","}
+
+// CountBadge fetches a count and renders it as a badge fragment, e.g. for a
+// navigation item such as "Open tickets (12)". Use the same query as the
+// table's total count so both stay consistent.
+func CountBadge(fetchCount func() (int, error)) (template.HTML, error) {
+	count, err := fetchCount()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch count: %w", err)
+	}
+	return countBadgeHTML(count), nil
+}
+
+// CountBadgeHandler serves the count as the CountBadge fragment, or as JSON
+// ({"count": 1234, "text": "1,234"}) when the client accepts
+// application/json, e.g. to refresh navigation badges without a page load
+func CountBadgeHandler(fetchCount func() (int, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		count, err := fetchCount()
+		if err != nil {
+			http.Error(w, "failed to fetch count", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-cache")
+		if acceptsJSON(req.Header.Get("Accept")) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(struct {
+				Count int    `json:"count"`
+				Text  string `json:"text"`
+			}{count, formatCount(count)})
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, countBadgeHTML(count))
+	})
+}
+
+// formatCount formats a count with thousands separators
+func formatCount(count int) string {
+	text, _ := formatNumber(count, countBadgeFormat)
+	return text
+}
+
+// countBadgeHTML renders a count as a badge
+func countBadgeHTML(count int) template.HTML {
+	return template.HTML(fmt.Sprintf(`<span class="badge badge-gray count-badge" data-count="%d">%s</span>`,
+		count, template.HTMLEscapeString(formatCount(count))))
+}
+
+// acceptsJSON reports whether an Accept header prefers JSON over HTML
+func acceptsJSON(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/json":
+			return true
+		case "text/html":
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// formControlsScript navigates to the URL chosen in the page size dropdown,
+// within the Turbo frame when there is one, and submits the facet form when
+// a facet changes
+const formControlsScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+c.addEventListener('change',function(e){
+var s=e.target;if(!c.contains(s))return;
+if(s.matches('select[data-table-navigate]')){var f=s.getAttribute('data-frame');if(f&&window.Turbo){Turbo.visit(s.value,{frame:f});}else{location.href=s.value;}}
+else if(s.matches('select[data-auto-submit]')&&s.form){if(s.form.requestSubmit){s.form.requestSubmit();}else{s.form.submit();}}
+});
+})();`
+
+// tableScripts are the enhancement scripts by the name their CSP mode
+// markers carry
+var tableScripts = map[string]string{
+	"actions-confirm": confirmActionScript,
+	"bulk-actions":    bulkActionsScript,
+	"client-side":     clientSideScript,
+	"column-resize":   columnResizeScript,
+	"copy-table":      copyTableScript,
+	"datatables":      dataTablesInitScript,
+	"export-copy":     exportCopyScript,
+	"form-controls":   formControlsScript,
+	"frozen-columns":  frozenColumnsScript,
+	"infinite-scroll": infiniteScrollScript,
+	"keyboard":        keyboardShortcutsScript,
+	"live-search":     liveSearchScript,
+	"load-more":       loadMoreScript,
+	"quick-filter":    quickFilterScript,
+	"row-link":        rowLinkScript,
+	"select-all":      selectAllScript,
+}
+
+// tableScriptName returns the name of an enhancement script
+func tableScriptName(js string) string {
+	for name, script := range tableScripts {
+		if script == js {
+			return name
+		}
+	}
+	return ""
+}
+
+// TableScript returns the script running the enhancements of tables
+// rendered with OmitScripts or in CSP mode without a nonce. Serve it from the page's origin,
+// e.g. with ScriptHandler, and load it once per page. It runs the scripts
+// the tables' markers name when the document loads and after Turbo and
+// htmx swap content in.
+func TableScript() string {
+	names := make([]string, 0, len(tableScripts))
+	for name := range tableScripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var js strings.Builder
+	js.WriteString("(function(){\nvar scripts={\n")
+	for _, name := range names {
+		// Each script finds its table from the marker instead of the
+		// running script element
+		body := tableScripts[name]
+		body = strings.TrimSuffix(strings.TrimPrefix(body, "(function(){"), "})();")
+		body = strings.ReplaceAll(body, "document.currentScript", "s")
+		js.WriteString("'" + name + "':function(s){" + body + "},\n")
+	}
+	js.WriteString(`};
+var init=function(){document.querySelectorAll('template[data-table-script]:not([data-ready])').forEach(function(s){s.setAttribute('data-ready','');var f=scripts[s.getAttribute('data-table-script')];if(f)f(s);});};
+if(document.readyState==='loading'){document.addEventListener('DOMContentLoaded',init);}else{init();}
+['turbo:load','turbo:frame-load','htmx:load'].forEach(function(e){document.addEventListener(e,init);});
+})();`)
+	return js.String()
+}
+
+// ScriptHandler serves TableScript as JavaScript
+func ScriptHandler() http.Handler {
+	script := TableScript()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Write([]byte(script))
+	})
+}
+
+// withStyleClasses returns a copy of columns rendering their styles as
+// classes
+func withStyleClasses(columns []Column) []Column {
+	classed := make([]Column, len(columns))
+	for i, column := range columns {
+		column.styleClasses = true
+		classed[i] = column
+	}
+	return classed
+}
+
+// cssLengthClass turns a CSS length into a class name suffix, e.g. "12.5%"
+// into "12_5pct"
+var cssLengthClass = strings.NewReplacer(".", "_", "%", "pct")
+
+// badgeColorClass returns the class of a custom hex badge color in CSP mode,
+// e.g. "badge-6f42c1"
+func badgeColorClass(color string) string {
+	return "badge-" + strings.ToLower(strings.TrimPrefix(color, "#"))
+}
+
+// maxWidthClass returns the class of a column MaxWidth in CSP mode
+func maxWidthClass(width string) string {
+	return "max-w-" + cssLengthClass.Replace(width)
+}
+
+// maxHeightClass returns the class of TableOptions.MaxHeight in CSP mode
+func maxHeightClass(height string) string {
+	return "max-h-" + cssLengthClass.Replace(height)
+}
+
+// styleClass returns the class of TableOptions.Style in CSP mode, named
+// after a hash of the style
+func styleClass(style string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(style))
+	return fmt.Sprintf("style-%08x", hash.Sum32())
+}
+
+// CSPStyles returns the CSS rules of the classes that replace style
+// attributes in CSP mode: custom badge colors, column MaxWidth, MaxHeight
+// and Style. With a Nonce they are added to the table's <style> block;
+// without one, include them in the page's stylesheet, e.g. next to
+// Renderer.Assets().CSS.
+func CSPStyles(options TableOptions) string {
+	rules := make(map[string]bool)
+	badges := func(format *BadgeFormat) {
+		if format == nil {
+			return
+		}
+		all := make([]Badge, 0, len(format.Badges)+1)
+		for _, badge := range format.Badges {
+			all = append(all, badge)
+		}
+		if format.Default != nil {
+			all = append(all, *format.Default)
+		}
+		for _, badge := range all {
+			if !badgeColors[badge.Color] && hexColor.MatchString(badge.Color) {
+				rules[fmt.Sprintf(".badge.%s { background-color: %s; color: #fff; }", badgeColorClass(badge.Color), badge.Color)] = true
+			}
+		}
+	}
+
+	for _, column := range options.Columns {
+		badges(column.Badge)
+		if cssLength.MatchString(column.MaxWidth) {
+			rules[fmt.Sprintf(".cell-truncated.%s { max-width: %s; }", maxWidthClass(column.MaxWidth), column.MaxWidth)] = true
+		}
+	}
+	if options.Source != nil {
+		badges(options.Source.Badges)
+	}
+	if cssLength.MatchString(options.MaxHeight) {
+		rules[fmt.Sprintf(".table-scroll.%s { max-height: %s; }", maxHeightClass(options.MaxHeight), options.MaxHeight)] = true
+	}
+	// Style is trusted CSS, but must not close the <style> element
+	if options.Style != "" && !strings.Contains(options.Style, "<") {
+		rules[fmt.Sprintf(".%s { %s }", styleClass(options.Style), options.Style)] = true
+	}
+
+	sorted := make([]string, 0, len(rules))
+	for rule := range rules {
+		sorted = append(sorted, rule)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\n")
+}
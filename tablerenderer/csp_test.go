@@ -0,0 +1,114 @@
+package tablerenderer
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCSPMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		nonce   string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "with a nonce",
+			nonce:   "n0nce",
+			want:    []string{`<style nonce="n0nce">`, `<script nonce="n0nce">`, `<select data-table-navigate>`},
+			notWant: []string{"<script>", "onchange=", "data-table-script"},
+		},
+		{
+			name: "without a nonce",
+			want: []string{
+				`<template data-table-script="select-all"></template>`,
+				`<template data-table-script="form-controls"></template>`,
+			},
+			notWant: []string{"<script", "<style", "onchange="},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewRenderer().RenderHTML(DatabasePaginatedData{
+				Headers:    []string{"ID", "Name"},
+				Rows:       [][]interface{}{{1, "Ada"}, {2, "Bob"}},
+				TotalCount: 2,
+				Options: TableOptions{
+					CSP:        true,
+					Nonce:      tt.nonce,
+					Selection:  &Selection{Enabled: true},
+					Pagination: &Pagination{Enabled: true, CurrentPage: 1, PageSize: 10, TotalCount: 2, ShowPageSizer: true},
+				},
+			})
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("missing %s in:\n%s", want, html)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(html, notWant) {
+					t.Errorf("unexpected %s", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestScriptHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	ScriptHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/table.js", nil))
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/javascript") {
+		t.Errorf("Content-Type = %q, want text/javascript", got)
+	}
+	script := recorder.Body.String()
+	for name := range tableScripts {
+		if !strings.Contains(script, "'"+name+"':function(s){") {
+			t.Errorf("TableScript() does not define %q", name)
+		}
+	}
+	if strings.Contains(script, "document.currentScript") {
+		t.Error("TableScript() uses document.currentScript instead of the marker")
+	}
+}
+
+func TestCSPStyleClasses(t *testing.T) {
+	options := TableOptions{
+		CSP:       true,
+		Nonce:     "n0nce",
+		MaxHeight: "300px",
+		Style:     "font-size: 12px",
+		Columns: []Column{
+			{Header: "Name", MaxWidth: "12.5%"},
+			{Header: "Status", Badge: &BadgeFormat{Badges: map[string]Badge{"vip": {Color: "#6F42C1"}}}},
+		},
+	}
+	html, err := NewRenderer().RenderHTML(DatabasePaginatedData{
+		Headers: []string{"Name", "Status"},
+		Rows:    [][]interface{}{{"Ada Lovelace", "vip"}},
+		Options: options,
+	})
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if strings.Contains(html, `style="`) {
+		t.Errorf("CSP mode rendered a style attribute:\n%s", html)
+	}
+	wantRules := []string{
+		".badge.badge-6f42c1 { background-color: #6F42C1; color: #fff; }",
+		".cell-truncated.max-w-12_5pct { max-width: 12.5%; }",
+		"." + styleClass(options.Style) + " { font-size: 12px }",
+		".table-scroll.max-h-300px { max-height: 300px; }",
+	}
+	if got := CSPStyles(options); got != strings.Join(wantRules, "\n") {
+		t.Errorf("CSPStyles() = %q, want %q", got, wantRules)
+	}
+	for _, want := range append(wantRules, `class="badge badge-custom badge-6f42c1"`, "max-w-12_5pct", "max-h-300px", styleClass(options.Style)) {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %s in:\n%s", want, html)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package tablerenderer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// FromCSV reads CSV, e.g. an uploaded file, into table data. With hasHeader
+// the first record names the columns, otherwise they are named "Column 1",
+// "Column 2" and so on. Records may have differing lengths; short ones are
+// padded with empty cells. All values are strings.
+func FromCSV(r io.Reader, hasHeader bool) (TableData, error) {
+	// Skip the byte order mark Excel writes at the start of UTF-8 files
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return TableData{}, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	var headers []string
+	if hasHeader && len(records) > 0 {
+		headers = records[0]
+		records = records[1:]
+	}
+	width := len(headers)
+	for _, record := range records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+	for i := len(headers); i < width; i++ {
+		headers = append(headers, fmt.Sprintf("Column %d", i+1))
+	}
+
+	rows := make([][]interface{}, len(records))
+	for i, record := range records {
+		row := make([]interface{}, width)
+		for j := range row {
+			row[j] = ""
+			if j < len(record) {
+				row[j] = record[j]
+			}
+		}
+		rows[i] = row
+	}
+	return TableData{Headers: headers, Rows: rows}, nil
+}
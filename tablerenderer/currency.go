@@ -0,0 +1,115 @@
+package tablerenderer
+
+import (
+	"reflect"
+	"strings"
+)
+
+// CurrencyFormat holds currency formatting for a column
+type CurrencyFormat struct {
+	Code                string `json:"code,omitempty"`                 // ISO 4217 code for the default symbol and precision, e.g. "EUR"
+	Symbol              string `json:"symbol,omitempty"`               // Currency symbol (default: from Code)
+	Decimals            int    `json:"decimals,omitempty"`             // Digits after the decimal point (default: from Code, or 2); negative for none
+	Locale              string `json:"locale,omitempty"`               // Locale for separators and symbol placement, e.g. "de" (default: "en")
+	NegativeParentheses bool   `json:"negative_parentheses,omitempty"` // Render negative amounts as (1,234.00) instead of -1,234.00
+}
+
+// currencyInfo holds the default symbol and minor units of a currency
+type currencyInfo struct {
+	symbol   string
+	decimals int
+}
+
+// currencies lists the defaults for common ISO 4217 currencies
+var currencies = map[string]currencyInfo{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"INR": {"₹", 2},
+	"CHF": {"CHF", 2},
+	"CAD": {"$", 2},
+	"AUD": {"$", 2},
+}
+
+// currencyLocale holds the separators and symbol placement of a locale
+type currencyLocale struct {
+	thousands   string
+	decimal     string
+	symbolAfter bool
+}
+
+// currencyLocales lists the supported currency locales by language tag
+var currencyLocales = map[string]currencyLocale{
+	"en": {thousands: ",", decimal: "."},
+	"de": {thousands: ".", decimal: ",", symbolAfter: true},
+	"es": {thousands: ".", decimal: ",", symbolAfter: true},
+	"fr": {thousands: " ", decimal: ",", symbolAfter: true},
+}
+
+// formatCurrency formats a numeric value as a currency amount, reporting
+// false for values that are not numeric
+func formatCurrency(value interface{}, format *CurrencyFormat) (string, bool) {
+	var amount float64
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		amount = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		amount = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		amount = v.Float()
+	default:
+		return "", false
+	}
+
+	info, known := currencies[strings.ToUpper(format.Code)]
+	symbol := format.Symbol
+	if symbol == "" {
+		symbol = info.symbol
+		if !known {
+			symbol = strings.ToUpper(format.Code)
+		}
+	}
+	decimals := format.Decimals
+	switch {
+	case decimals < 0:
+		decimals = 0
+	case decimals == 0 && known:
+		decimals = info.decimals
+	case decimals == 0:
+		decimals = 2
+	}
+
+	tag := strings.ToLower(format.Locale)
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	locale, ok := currencyLocales[tag]
+	if !ok {
+		locale = currencyLocales["en"]
+	}
+
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+	text, _ := formatNumber(amount, &NumberFormat{Decimals: decimals, Thousands: locale.thousands, Decimal: locale.decimal})
+
+	if symbol != "" {
+		if locale.symbolAfter {
+			text = text + " " + symbol
+		} else {
+			text = symbol + text
+		}
+	}
+
+	if negative {
+		if format.NegativeParentheses {
+			return "(" + text + ")", true
+		}
+		return "-" + text, true
+	}
+	return text, true
+}
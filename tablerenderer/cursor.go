@@ -0,0 +1,201 @@
+package tablerenderer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeCursor returns an opaque URL-safe cursor holding the sort values of
+// a row, e.g. its creation time and ID. Times keep their fractional
+// seconds, so rows created within the same second stay apart.
+func EncodeCursor(values ...interface{}) string {
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = cursorValue(value)
+	}
+	encoded, _ := json.Marshal(texts)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// cursorValue returns the text of a cursor value: times in RFC 3339 with
+// nanoseconds, other values as exported
+func cursorValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v != nil {
+			return v.Format(time.RFC3339Nano)
+		}
+	}
+	return exportValue(value)
+}
+
+// DecodeCursor returns the values of a cursor made by EncodeCursor
+func DecodeCursor(cursor string) ([]string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var values []string
+	if err := json.Unmarshal(decoded, &values); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return values, nil
+}
+
+// Keyset is the order cursor pagination walks a table in: the sorted
+// columns, ending with a unique one such as the primary key so every row
+// has a distinct position. Unlike OFFSET, seeking to a cursor stays fast
+// deep into large tables when the columns are indexed.
+//
+// A source fetches a page with Where and OrderBy and a limit of one more
+// than the page size, then passes the rows to CursorPage.
+type Keyset struct {
+	Columns []string // Trusted column names, e.g. {"created_at", "id"}; inserted into SQL as is
+	Desc    bool     // Walk the columns in descending order
+}
+
+// Where returns the condition selecting the rows after the cursor, or
+// before it when before is set, with ? placeholders and their arguments,
+// e.g. "(created_at > ?) OR (created_at = ? AND id > ?)". An empty cursor
+// selects every row and returns an empty condition.
+func (k Keyset) Where(cursor string, before bool) (string, []interface{}, error) {
+	if cursor == "" {
+		return "", nil, nil
+	}
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(values) != len(k.Columns) {
+		return "", nil, fmt.Errorf("invalid cursor: %d values for %d columns", len(values), len(k.Columns))
+	}
+
+	op := " > ?"
+	if k.Desc != before {
+		op = " < ?"
+	}
+	// Rows past the cursor on the first column, or tied on the leading
+	// columns and past it on the next; portable where row values are not
+	var conditions []string
+	var args []interface{}
+	for i, column := range k.Columns {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, k.Columns[j]+" = ?")
+			args = append(args, values[j])
+		}
+		parts = append(parts, column+op)
+		args = append(args, values[i])
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(conditions, " OR "), args, nil
+}
+
+// OrderBy returns the ORDER BY expression of the keyset, reversed when
+// fetching the rows before a cursor
+func (k Keyset) OrderBy(before bool) string {
+	direction := " ASC"
+	if k.Desc != before {
+		direction = " DESC"
+	}
+	columns := make([]string, len(k.Columns))
+	for i, column := range k.Columns {
+		columns[i] = column + direction
+	}
+	return strings.Join(columns, ", ")
+}
+
+// CursorPage trims rows fetched with Keyset.Where and a limit of the page
+// size plus one to the page, restores the order of pages fetched before a
+// cursor, and returns the pagination with the cursors of the neighbouring
+// pages set. cursor returns the cursor of a row, e.g. with EncodeCursor.
+func CursorPage(rows [][]interface{}, pagination Pagination, cursor func(row []interface{}) string) ([][]interface{}, Pagination) {
+	before := pagination.Before != ""
+	more := pagination.PageSize > 0 && len(rows) > pagination.PageSize
+	if more {
+		rows = rows[:pagination.PageSize]
+	}
+	if before {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+	}
+
+	pagination.NextCursor, pagination.PrevCursor = "", ""
+	if len(rows) > 0 {
+		// A page fetched before a cursor has that cursor's rows after it,
+		// and one fetched after a cursor has its rows before it
+		if more || before {
+			pagination.NextCursor = cursor(rows[len(rows)-1])
+		}
+		if (more && before) || pagination.After != "" {
+			pagination.PrevCursor = cursor(rows[0])
+		}
+	}
+	return rows, pagination
+}
+
+// cursorPage returns the page of rows held in memory at an offset cursor,
+// for sources without a keyset such as SliceSource
+func cursorPage(rows [][]interface{}, pagination Pagination) ([][]interface{}, Pagination) {
+	pageSize := CalculateDatabaseLimit(pagination.PageSize)
+	offset := func(cursor string) int {
+		values, err := DecodeCursor(cursor)
+		if err != nil || len(values) != 1 {
+			return 0
+		}
+		n, _ := strconv.Atoi(values[0])
+		return min(max(n, 0), len(rows))
+	}
+
+	start := 0
+	switch {
+	case pagination.After != "":
+		start = offset(pagination.After)
+	case pagination.Before != "":
+		start = max(offset(pagination.Before)-pageSize, 0)
+	}
+	end := min(start+pageSize, len(rows))
+
+	pagination.NextCursor, pagination.PrevCursor = "", ""
+	if end < len(rows) {
+		pagination.NextCursor = EncodeCursor(end)
+	}
+	if start > 0 {
+		pagination.PrevCursor = EncodeCursor(start)
+	}
+	return rows[start:end], pagination
+}
+
+// cursorURL returns the URL of the page after or before a cursor, keeping
+// the other parameters but not the page number
+func cursorURL(pagination *Pagination, currentQueryParams map[string]string, param, cursor string) string {
+	params := queryValues(currentQueryParams)
+	params.Del("page")
+	if pagination.QueryParam != "" {
+		params.Del(pagination.QueryParam)
+	}
+	params.Del("after")
+	params.Del("before")
+	params.Set(param, cursor)
+	return linkURL(pagination.BaseURL, params)
+}
+
+// generateCursorPaginationHTML generates the Previous and Next controls of
+// cursor pagination
+func (r *Renderer) generateCursorPaginationHTML(pagination *Pagination, currentQueryParams map[string]string, frame string) string {
+	var previousURL, nextURL string
+	if pagination.PrevCursor != "" {
+		previousURL = cursorURL(pagination, currentQueryParams, "before", pagination.PrevCursor)
+	}
+	if pagination.NextCursor != "" {
+		nextURL = cursorURL(pagination, currentQueryParams, "after", pagination.NextCursor)
+	}
+	return r.generatePrevNextHTML("pagination-cursor", previousURL, nextURL, frame)
+}
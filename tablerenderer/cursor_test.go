@@ -0,0 +1,158 @@
+package tablerenderer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeysetWhere(t *testing.T) {
+	cursor := EncodeCursor("2024-01-02", 42)
+	tests := []struct {
+		name      string
+		keyset    Keyset
+		cursor    string
+		before    bool
+		wantSQL   string
+		wantArgs  []interface{}
+		wantError bool
+	}{
+		{
+			name:    "no cursor",
+			keyset:  Keyset{Columns: []string{"created_at", "id"}},
+			wantSQL: "",
+		},
+		{
+			name:     "after ascending",
+			keyset:   Keyset{Columns: []string{"created_at", "id"}},
+			cursor:   cursor,
+			wantSQL:  "(created_at > ?) OR (created_at = ? AND id > ?)",
+			wantArgs: []interface{}{"2024-01-02", "2024-01-02", "42"},
+		},
+		{
+			name:     "before ascending",
+			keyset:   Keyset{Columns: []string{"created_at", "id"}},
+			cursor:   cursor,
+			before:   true,
+			wantSQL:  "(created_at < ?) OR (created_at = ? AND id < ?)",
+			wantArgs: []interface{}{"2024-01-02", "2024-01-02", "42"},
+		},
+		{
+			name:     "after descending",
+			keyset:   Keyset{Columns: []string{"created_at", "id"}, Desc: true},
+			cursor:   cursor,
+			wantSQL:  "(created_at < ?) OR (created_at = ? AND id < ?)",
+			wantArgs: []interface{}{"2024-01-02", "2024-01-02", "42"},
+		},
+		{
+			name:      "value count mismatch",
+			keyset:    Keyset{Columns: []string{"id"}},
+			cursor:    cursor,
+			wantError: true,
+		},
+		{
+			name:      "malformed cursor",
+			keyset:    Keyset{Columns: []string{"id"}},
+			cursor:    "not a cursor",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.keyset.Where(tt.cursor, tt.before)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Where() = %q, want an error", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Where() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("Where() = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Where() args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestEncodeCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"fractional seconds", created, "2024-01-02T03:04:05.123456789+01:00"},
+		{"time pointer", &created, "2024-01-02T03:04:05.123456789+01:00"},
+		{"whole seconds", created.Truncate(time.Second), "2024-01-02T03:04:05+01:00"},
+		{"nil time pointer", (*time.Time)(nil), ""},
+		{"integer", 42, "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := DecodeCursor(EncodeCursor(tt.value))
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if len(values) != 1 || values[0] != tt.want {
+				t.Fatalf("DecodeCursor(EncodeCursor()) = %q, want %q", values, tt.want)
+			}
+			if when, ok := tt.value.(time.Time); ok {
+				parsed, err := time.Parse(time.RFC3339Nano, values[0])
+				if err != nil || !parsed.Equal(when) {
+					t.Errorf("parsed cursor time = %v, %v, want %v", parsed, err, when)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	rows := [][]interface{}{{1}, {2}, {3}}
+	cursor := func(row []interface{}) string { return EncodeCursor(row[0]) }
+	tests := []struct {
+		name       string
+		pagination Pagination
+		wantRows   [][]interface{}
+		wantNext   string
+		wantPrev   string
+	}{
+		{
+			name:       "first page with more",
+			pagination: Pagination{PageSize: 2},
+			wantRows:   [][]interface{}{{1}, {2}},
+			wantNext:   EncodeCursor(2),
+		},
+		{
+			name:       "last page after a cursor",
+			pagination: Pagination{PageSize: 5, After: EncodeCursor(0)},
+			wantRows:   [][]interface{}{{1}, {2}, {3}},
+			wantPrev:   EncodeCursor(1),
+		},
+		{
+			name:       "page before a cursor is reversed",
+			pagination: Pagination{PageSize: 2, Before: EncodeCursor(4)},
+			wantRows:   [][]interface{}{{2}, {1}},
+			wantNext:   EncodeCursor(1),
+			wantPrev:   EncodeCursor(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pagination := CursorPage(rows, tt.pagination, cursor)
+			if !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("CursorPage() rows = %v, want %v", got, tt.wantRows)
+			}
+			if pagination.NextCursor != tt.wantNext {
+				t.Errorf("NextCursor = %q, want %q", pagination.NextCursor, tt.wantNext)
+			}
+			if pagination.PrevCursor != tt.wantPrev {
+				t.Errorf("PrevCursor = %q, want %q", pagination.PrevCursor, tt.wantPrev)
+			}
+		})
+	}
+}
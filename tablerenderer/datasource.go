@@ -0,0 +1,150 @@
+package tablerenderer
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// DataSource loads a table for the page, sort order and search term in
+// options. Sources return the requested page with TotalCount set, or every
+// matching row when options.Pagination is nil, as for exports. The returned
+// Options are used for rendering, so sources pass on the options they get,
+// adding column configuration as needed.
+type DataSource interface {
+	Load(ctx context.Context, options TableOptions) (DatabasePaginatedData, error)
+}
+
+// DataSourceFunc adapts a function to a DataSource
+type DataSourceFunc func(ctx context.Context, options TableOptions) (DatabasePaginatedData, error)
+
+// Load implements DataSource
+func (f DataSourceFunc) Load(ctx context.Context, options TableOptions) (DatabasePaginatedData, error) {
+	return f(ctx, options)
+}
+
+// SliceSource is a DataSource over rows held in memory, e.g. fixtures in
+// tests. It filters, searches, sorts and pages the rows the way a database
+// would. Cursor pagination uses the row offset as the cursor. Filters are
+// applied as given; the renderer's handlers drop them unless
+// FeatureAdvancedFilters is enabled.
+type SliceSource struct {
+	Headers []string
+	Rows    [][]interface{}
+}
+
+// Load implements DataSource
+func (s SliceSource) Load(ctx context.Context, options TableOptions) (DatabasePaginatedData, error) {
+	rows := s.search(options.Search)
+	if len(options.Filters) > 0 {
+		filtered := rows[:0]
+		for _, row := range rows {
+			if matchFilters(s.Headers, row, options.Filters) {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+	s.sort(rows, options.Sorting)
+
+	data := DatabasePaginatedData{
+		Headers:    s.Headers,
+		TotalCount: len(rows),
+		Options:    options,
+	}
+	if options.Pagination != nil && options.Pagination.Enabled && options.Pagination.Cursor {
+		var pagination Pagination
+		rows, pagination = cursorPage(rows, *options.Pagination)
+		pagination.TotalCount = data.TotalCount
+		data.Options.Pagination = &pagination
+	} else if options.Pagination != nil && options.Pagination.Enabled {
+		pageSize := CalculateDatabaseLimit(options.Pagination.PageSize)
+		offset := min(CalculateDatabaseOffset(options.Pagination.CurrentPage, pageSize), len(rows))
+		rows = rows[offset:min(offset+pageSize, len(rows))]
+
+		pagination := *options.Pagination
+		pagination.TotalCount = data.TotalCount
+		pagination.HasNext = offset+pageSize < data.TotalCount
+		data.Options.Pagination = &pagination
+	}
+	data.Rows = rows
+	return data, nil
+}
+
+// search returns the rows where any searched column contains the term
+func (s SliceSource) search(search *Search) [][]interface{} {
+	rows := append([][]interface{}(nil), s.Rows...)
+	if search == nil || !search.Enabled {
+		return rows
+	}
+	term := strings.TrimSpace(search.SearchTerm)
+	if len([]rune(term)) < max(search.MinLength, 1) {
+		return rows
+	}
+	if !search.CaseSensitive {
+		term = strings.ToLower(term)
+	}
+
+	columns := make(map[string]bool, len(search.SearchColumns))
+	for _, column := range search.SearchColumns {
+		columns[column] = true
+	}
+
+	matches := rows[:0]
+	for _, row := range rows {
+		for i, value := range row {
+			if i >= len(s.Headers) || (len(columns) > 0 && !columns[s.Headers[i]]) {
+				continue
+			}
+			text := exportValue(value)
+			if !search.CaseSensitive {
+				text = strings.ToLower(text)
+			}
+			if strings.Contains(text, term) {
+				matches = append(matches, row)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// sort orders the rows by the sorted column, keeping empty values last
+// unless Sorting.NullsPosition says otherwise
+func (s SliceSource) sort(rows [][]interface{}, sorting *Sorting) {
+	if sorting == nil || !sorting.Enabled || sorting.SortBy == "" {
+		return
+	}
+	column := -1
+	for i, header := range s.Headers {
+		if header == sorting.SortBy {
+			column = i
+		}
+	}
+	if column < 0 {
+		return
+	}
+
+	desc := strings.EqualFold(sorting.SortOrder, "desc")
+	nullsFirst := sorting.NullsPosition == NullsFirst
+	value := func(row []interface{}) interface{} {
+		if column < len(row) {
+			return row[column]
+		}
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := value(rows[i]), value(rows[j])
+		if aEmpty, bEmpty := isEmptyValue(a), isEmptyValue(b); aEmpty || bEmpty {
+			return aEmpty != bEmpty && aEmpty == nullsFirst
+		}
+		order, ok := compareValues(a, b)
+		if !ok {
+			order = strings.Compare(exportValue(a), exportValue(b))
+		}
+		if desc {
+			return order > 0
+		}
+		return order < 0
+	})
+}
@@ -0,0 +1,268 @@
+package tablerenderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DataTables renders the table for DataTables.js: the pagination, sorting
+// and search controls are left to DataTables, and an initialization payload
+// built from the current options is embedded as JSON
+// (script.datatables-config). With AjaxURL set, DataTables runs in
+// server-side mode against an endpoint such as DataTablesHandler.
+type DataTables struct {
+	AjaxURL  string                 `json:"ajax_url,omitempty"` // JSON endpoint for server-side processing; rows are processed in the browser without it
+	Settings map[string]interface{} `json:"settings,omitempty"` // Extra DataTables settings merged into the payload, e.g. {"stateSave": true}
+	NoInit   bool                   `json:"no_init,omitempty"`  // Only embed the payload; the page initializes DataTables itself
+}
+
+// dataTablesInitScript initializes DataTables from the embedded payload,
+// with the DataTables 2 constructor or the jQuery plugin, whichever is loaded
+const dataTablesInitScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var t=c.querySelector('table.data-table'),p=c.querySelector('script.datatables-config');if(!t||!p)return;
+var cfg=JSON.parse(p.textContent);
+if(window.DataTable){new DataTable(t,cfg);}else if(window.jQuery&&jQuery.fn.DataTable){jQuery(t).DataTable(cfg);}
+})();`
+
+// dataTablesColumn is a column of the DataTables payload
+type dataTablesColumn struct {
+	Data      int    `json:"data"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Orderable bool   `json:"orderable"`
+}
+
+// dataTablesPayload returns the DataTables settings matching the current
+// options: columns, page length and start, order and search term
+func dataTablesPayload(headers []string, options TableOptions, totalCount int, pageRows int) map[string]interface{} {
+	dt := options.DataTables
+	configs := columnsFor(headers, options.Columns)
+	columns := make([]dataTablesColumn, len(headers))
+	for i, header := range headers {
+		columns[i] = dataTablesColumn{
+			Data:      i,
+			Name:      header,
+			Title:     headerLabel(configs[i], header),
+			Orderable: options.Sorting != nil && options.Sorting.Enabled,
+		}
+	}
+
+	payload := map[string]interface{}{
+		"columns":   columns,
+		"ordering":  options.Sorting != nil && options.Sorting.Enabled,
+		"searching": options.Search != nil && options.Search.Enabled,
+		"paging":    options.Pagination != nil && options.Pagination.Enabled,
+		"order":     [][]interface{}{},
+	}
+	if pagination := options.Pagination; pagination != nil && pagination.Enabled {
+		pageSize := CalculateDatabaseLimit(pagination.PageSize)
+		payload["pageLength"] = pageSize
+		payload["displayStart"] = CalculateDatabaseOffset(pagination.CurrentPage, pageSize)
+		if len(pagination.PageSizeOptions) > 0 {
+			payload["lengthMenu"] = pagination.PageSizeOptions
+		}
+	}
+	if sorting := options.Sorting; sorting != nil && sorting.Enabled {
+		for i, header := range headers {
+			if header == sorting.SortBy {
+				order := "asc"
+				if sorting.SortOrder == "desc" {
+					order = "desc"
+				}
+				payload["order"] = [][]interface{}{{i, order}}
+			}
+		}
+	}
+	if search := options.Search; search != nil && search.Enabled {
+		payload["search"] = map[string]string{"search": search.SearchTerm}
+	}
+	if dt.AjaxURL != "" {
+		payload["serverSide"] = true
+		payload["processing"] = true
+		payload["ajax"] = map[string]string{"url": dt.AjaxURL}
+		// The rendered rows are the first draw, so DataTables does not
+		// request them again
+		if pageRows > 0 {
+			payload["deferLoading"] = totalCount
+		}
+	}
+	for key, value := range dt.Settings {
+		payload[key] = value
+	}
+	return payload
+}
+
+// generateDataTablesHTML embeds the DataTables payload and, unless NoInit is
+// set and scripts are allowed, the script initializing DataTables
+func generateDataTablesHTML(headers []string, options TableOptions, totalCount int, pageRows int) (template.HTML, error) {
+	// json.Marshal escapes <, > and &, so the data cannot close the element
+	encoded, err := json.Marshal(dataTablesPayload(headers, options, totalCount, pageRows))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode DataTables settings: %w", err)
+	}
+	html := template.HTML(`<script type="application/json" class="datatables-config">` + string(encoded) + `</script>`)
+	if !options.DataTables.NoInit && scriptsAllowed(options) {
+		html += scriptTag(options, dataTablesInitScript)
+	}
+	return html, nil
+}
+
+// withoutServerControls returns options without the pagination, sorting and
+// search controls, which DataTables renders itself
+func withoutServerControls(options TableOptions) TableOptions {
+	options.Pagination = nil
+	options.Sorting = nil
+	options.Search = nil
+	return options
+}
+
+// DataTablesRequest is a server-side processing request sent by DataTables
+type DataTablesRequest struct {
+	Draw      int    // Counter DataTables matches responses by
+	Start     int    // Offset of the first row
+	Length    int    // Rows per page; -1 for all rows
+	Search    string // Global search term
+	SortBy    string // Header of the first ordered column
+	SortOrder string // "asc" or "desc"
+}
+
+// ParseDataTablesRequest reads a DataTables server-side request from its
+// query parameters or form values. Ordered columns are mapped to headers by
+// index.
+func ParseDataTablesRequest(values url.Values, headers []string) DataTablesRequest {
+	request := DataTablesRequest{Length: 10, SortOrder: "asc"}
+	request.Draw, _ = strconv.Atoi(values.Get("draw"))
+	if start, err := strconv.Atoi(values.Get("start")); err == nil && start > 0 {
+		request.Start = start
+	}
+	if length, err := strconv.Atoi(values.Get("length")); err == nil && (length > 0 || length == -1) {
+		request.Length = length
+	}
+	request.Search = values.Get("search[value]")
+	if column, err := strconv.Atoi(values.Get("order[0][column]")); err == nil && column >= 0 && column < len(headers) {
+		request.SortBy = headers[column]
+		if values.Get("order[0][dir]") == "desc" {
+			request.SortOrder = "desc"
+		}
+	}
+	return request
+}
+
+// Options returns the table options selecting the requested rows, for a
+// DataSource or a query built from them
+func (req DataTablesRequest) Options() TableOptions {
+	var options TableOptions
+	req.Apply(&options)
+	return options
+}
+
+// Apply sets the requested page, sort order and search term on options,
+// keeping the rest of the table's configuration. Pagination is removed when
+// DataTables asks for all rows.
+func (req DataTablesRequest) Apply(options *TableOptions) {
+	var sorting Sorting
+	if options.Sorting != nil {
+		sorting = *options.Sorting
+	}
+	sorting.Enabled = true
+	sorting.SortBy = req.SortBy
+	sorting.SortOrder = req.SortOrder
+	options.Sorting = &sorting
+
+	var search Search
+	if options.Search != nil {
+		search = *options.Search
+	}
+	search.Enabled = true
+	search.SearchTerm = req.Search
+	if search.MinLength == 0 {
+		search.MinLength = 1
+	}
+	options.Search = &search
+
+	if req.Length <= 0 {
+		options.Pagination = nil
+		return
+	}
+	var pagination Pagination
+	if options.Pagination != nil {
+		pagination = *options.Pagination
+	}
+	pagination.Enabled = true
+	pagination.PageSize = req.Length
+	pagination.CurrentPage = req.Start/req.Length + 1
+	options.Pagination = &pagination
+}
+
+// DataTablesResponse is the server-side processing response DataTables
+// expects. Data holds the formatted cells of each row as HTML.
+type DataTablesResponse struct {
+	Draw            int        `json:"draw"`
+	RecordsTotal    int        `json:"recordsTotal"`
+	RecordsFiltered int        `json:"recordsFiltered"`
+	Data            [][]string `json:"data"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// DataTablesResponse formats the rows of data for a server-side request.
+// RecordsTotal and RecordsFiltered are data.TotalCount; set RecordsTotal
+// to the unfiltered count when it differs.
+func (r *Renderer) DataTablesResponse(data DatabasePaginatedData, draw int) (DataTablesResponse, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return DataTablesResponse{}, err
+	}
+
+	response := DataTablesResponse{
+		Draw:            draw,
+		RecordsTotal:    data.TotalCount,
+		RecordsFiltered: data.TotalCount,
+		Data:            [][]string{},
+	}
+	for _, row := range r.renderRows(headers, rows, data.Options.Columns) {
+		cells := make([]string, len(row.Cells))
+		for i, cell := range row.Cells {
+			cells[i] = cellHTML(cell)
+		}
+		response.Data = append(response.Data, cells)
+	}
+	return response, nil
+}
+
+// DataTablesHandler serves DataTables server-side requests for a table with
+// the given headers and options, loading the requested rows from source.
+// The page, sort order and search term of each request are applied to
+// options, so columns, features and formatting carry over from the table.
+func (r *Renderer) DataTablesHandler(headers []string, source DataSource, options TableOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		request := ParseDataTablesRequest(req.Form, headers)
+		opts := options
+		request.Apply(&opts)
+		r.dropDisabledFeatures(&opts)
+
+		w.Header().Set("Content-Type", "application/json")
+		response := DataTablesResponse{Draw: request.Draw, Data: [][]string{}}
+		data, err := source.Load(req.Context(), opts)
+		if err == nil {
+			var page DataTablesResponse
+			if page, err = r.DataTablesResponse(data, request.Draw); err == nil {
+				response = page
+			}
+		}
+		if err != nil {
+			// DataTables shows the error field to the user and matches the
+			// response to its request by Draw
+			response.Error = "failed to load table"
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,158 @@
+package tablerenderer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseDataTablesRequest(t *testing.T) {
+	headers := []string{"ID", "Name"}
+	tests := []struct {
+		name  string
+		query string
+		want  DataTablesRequest
+	}{
+		{"defaults", "", DataTablesRequest{Length: 10, SortOrder: "asc"}},
+		{
+			"full request",
+			"draw=4&start=20&length=25&search[value]=ada&order[0][column]=1&order[0][dir]=desc",
+			DataTablesRequest{Draw: 4, Start: 20, Length: 25, Search: "ada", SortBy: "Name", SortOrder: "desc"},
+		},
+		{"all rows", "length=-1", DataTablesRequest{Length: -1, SortOrder: "asc"}},
+		{"invalid values", "start=-5&length=0&order[0][column]=7", DataTablesRequest{Length: 10, SortOrder: "asc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			if got := ParseDataTablesRequest(values, headers); got != tt.want {
+				t.Errorf("ParseDataTablesRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataTablesRequestOptions(t *testing.T) {
+	options := DataTablesRequest{Start: 20, Length: 10, Search: "ada", SortBy: "Name", SortOrder: "desc"}.Options()
+	if p := options.Pagination; p == nil || p.CurrentPage != 3 || p.PageSize != 10 {
+		t.Errorf("Pagination = %+v, want page 3 of 10 rows", p)
+	}
+	if s := options.Sorting; s == nil || s.SortBy != "Name" || s.SortOrder != "desc" {
+		t.Errorf("Sorting = %+v, want Name desc", s)
+	}
+	if s := options.Search; s == nil || s.SearchTerm != "ada" {
+		t.Errorf("Search = %+v, want ada", s)
+	}
+	if options := (DataTablesRequest{Length: -1}).Options(); options.Pagination != nil {
+		t.Errorf("Pagination = %+v for all rows, want nil", options.Pagination)
+	}
+}
+
+func TestDataTablesPayload(t *testing.T) {
+	options := TableOptions{
+		Pagination: &Pagination{Enabled: true, CurrentPage: 2, PageSize: 25},
+		Sorting:    &Sorting{Enabled: true, SortBy: "Name", SortOrder: "desc"},
+		DataTables: &DataTables{AjaxURL: "/users.json", Settings: map[string]interface{}{"stateSave": true}},
+	}
+	payload := dataTablesPayload([]string{"ID", "Name"}, options, 120, 25)
+	want := map[string]interface{}{
+		"pageLength":   25,
+		"displayStart": 25,
+		"order":        [][]interface{}{{1, "desc"}},
+		"serverSide":   true,
+		"ajax":         map[string]string{"url": "/users.json"},
+		"deferLoading": 120,
+		"stateSave":    true,
+		"searching":    false,
+	}
+	for key, value := range want {
+		if !reflect.DeepEqual(payload[key], value) {
+			t.Errorf("payload[%q] = %#v, want %#v", key, payload[key], value)
+		}
+	}
+}
+
+func TestDataTablesHandler(t *testing.T) {
+	headers := []string{"ID", "Name", "Score"}
+	rows := SliceSource{Headers: headers}
+	for i := 1; i <= 25; i++ {
+		rows.Rows = append(rows.Rows, []interface{}{i, fmt.Sprintf("user %d", i), float64(i) / 4})
+	}
+	precision := 1
+	options := TableOptions{
+		Columns: []Column{{Header: "Score", Precision: &precision}},
+		Search:  &Search{Enabled: true, MinLength: 2},
+	}
+	failing := DataSourceFunc(func(ctx context.Context, options TableOptions) (DatabasePaginatedData, error) {
+		return DatabasePaginatedData{}, errors.New("database down")
+	})
+	unrenderable := DataSourceFunc(func(ctx context.Context, options TableOptions) (DatabasePaginatedData, error) {
+		return DatabasePaginatedData{Data: 42}, nil
+	})
+
+	tests := []struct {
+		name      string
+		source    DataSource
+		query     string
+		want      DataTablesResponse
+		wantFirst []string
+	}{
+		{
+			name:      "page sorted by ID",
+			source:    rows,
+			query:     "draw=3&start=10&length=10&order[0][column]=0&order[0][dir]=desc",
+			want:      DataTablesResponse{Draw: 3, RecordsTotal: 25, RecordsFiltered: 25},
+			wantFirst: []string{"15", "user 15", "3.8"},
+		},
+		{
+			name:      "search with the table's minimum length",
+			source:    rows,
+			query:     "draw=4&search[value]=user%202",
+			want:      DataTablesResponse{Draw: 4, RecordsTotal: 7, RecordsFiltered: 7},
+			wantFirst: []string{"2", "user 2", "0.5"},
+		},
+		{
+			name:   "load error keeps the draw",
+			source: failing,
+			query:  "draw=5",
+			want:   DataTablesResponse{Draw: 5, Error: "failed to load table"},
+		},
+		{
+			name:   "render error keeps the draw",
+			source: unrenderable,
+			query:  "draw=6",
+			want:   DataTablesResponse{Draw: 6, Error: "failed to load table"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewRenderer().DataTablesHandler(headers, tt.source, options).ServeHTTP(recorder,
+				httptest.NewRequest("GET", "/users.json?"+tt.query, nil))
+
+			var response DataTablesResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decoding the response: %v", err)
+			}
+			data := response.Data
+			response.Data = nil
+			if !reflect.DeepEqual(response, tt.want) {
+				t.Errorf("response = %+v, want %+v", response, tt.want)
+			}
+			if data == nil {
+				t.Error("Data = null, want an array")
+			}
+			if tt.wantFirst != nil && (len(data) == 0 || !reflect.DeepEqual(data[0], tt.wantFirst)) {
+				t.Errorf("Data = %q, want a first row of %q", data, tt.wantFirst)
+			}
+		})
+	}
+	if options.Pagination != nil || options.Search.SearchTerm != "" {
+		t.Errorf("DataTablesHandler() changed the table options to %+v", options)
+	}
+}
@@ -0,0 +1,150 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// detailStyles is the CSS of detail tables
+const detailStyles = `<style>
+.detail-table {
+	width: 100%;
+	border-collapse: collapse;
+	margin: 0;
+	background: white;
+	font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
+	font-size: 0.875rem;
+}
+.detail-table th {
+	width: 1%;
+	padding: 0.75rem;
+	background: #f8f9fa;
+	border-bottom: 1px solid #dee2e6;
+	color: #495057;
+	font-weight: 600;
+	text-align: start;
+	vertical-align: top;
+	white-space: nowrap;
+}
+.detail-table td {
+	padding: 0.75rem;
+	border-bottom: 1px solid #dee2e6;
+	color: #212529;
+}
+.detail-table td.cell-missing {
+	background-color: #fffbea;
+}
+.detail-table td.cell-invalid {
+	background-color: #fdf0f0;
+	box-shadow: inset 3px 0 0 #dc3545;
+}
+.detail-table.table-striped tbody tr:nth-child(even) td {
+	background-color: #f8f9fa;
+}
+.detail-table.table-bordered th, .detail-table.table-bordered td {
+	border: 1px solid #dee2e6;
+}
+</style>`
+
+// detailTemplate lays out the fields of a record as label/value rows
+var detailTemplate = template.Must(template.New("detail").Parse(`<div class="detail-container"{{if .Direction}} dir="{{.Direction}}"{{end}}>
+{{- if not .OmitStyles}}{{.Styles}}{{end}}
+<table class="{{.CSSClasses}}"{{if .ID}} id="{{.ID}}"{{end}}{{if .Style}} style="{{.Style}}"{{end}}>
+<tbody>
+{{- range .Fields}}
+<tr>
+<th scope="row">{{.Header}}</th>
+{{- $classes := .Classes}}
+{{- range $i, $cell := .Cells}}
+<td{{with index $classes $i}} class="{{.}}"{{end}}>{{$cell}}</td>
+{{- end}}
+</tr>
+{{- end}}
+</tbody>
+</table>
+</div>`))
+
+// RenderDetail renders a single struct, or a pointer to one, as a two-column
+// label/value table for detail pages. Labels come from the json tags like
+// table headers do, and the column configuration, header templates, plugins
+// and Source of options apply to the values as in RenderHTML. Pagination,
+// sorting, search and the other table controls are ignored.
+func (r *Renderer) RenderDetail(record interface{}, options TableOptions) (string, error) {
+	value := reflect.ValueOf(record)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", fmt.Errorf("record must not be nil")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return "", fmt.Errorf("record must be a struct")
+	}
+
+	// Treat the record as a one-row struct slice so it goes through the same
+	// header extraction and preparation as tables
+	records := reflect.Append(reflect.MakeSlice(reflect.SliceOf(value.Type()), 0, 1), value)
+	headers, rows, err := r.prepareTable(nil, nil, records.Interface(), &options)
+	if err != nil {
+		return "", err
+	}
+	headers, rows, sources := extractSource(headers, rows, options.Source)
+
+	headerContents, err := r.renderHeaderContents(headers, options.Columns, nil)
+	if err != nil {
+		return "", err
+	}
+	rendered := r.renderRows(headers, rows, options.Columns)
+	if sources != nil {
+		applySource(rendered, sources, options.Source, options.CSP)
+	}
+	if options.HighlightMissing {
+		flagDataQuality(rendered, headers, rows, options.Columns)
+	}
+
+	cssClasses := []string{"detail-table"}
+	if options.CSSClass != "" {
+		cssClasses = append(cssClasses, options.CSSClass)
+	}
+	// CSP mode sets Style by a class from CSPStyles
+	style, styles := template.CSS(options.Style), detailStyles
+	if options.CSP {
+		if style != "" {
+			cssClasses = append(cssClasses, styleClass(options.Style))
+			style = ""
+		}
+		styles = strings.Replace(styles, "</style>", CSPStyles(options)+"\n</style>", 1)
+	}
+	if options.Striped {
+		cssClasses = append(cssClasses, "table-striped")
+	}
+	if options.Bordered {
+		cssClasses = append(cssClasses, "table-bordered")
+	}
+
+	templateData := struct {
+		Fields     []transposedRow
+		CSSClasses string
+		ID         string
+		Direction  string
+		Style      template.CSS
+		OmitStyles bool
+		Styles     template.HTML
+	}{
+		Fields:     transposeRows(headerContents, rendered),
+		CSSClasses: strings.Join(cssClasses, " "),
+		ID:         options.ID,
+		Direction:  textDirection(options.Direction),
+		Style:      style,
+		OmitStyles: options.OmitStyles || (options.CSP && options.Nonce == ""),
+		Styles:     template.HTML(strings.Replace(styles, "<style>", "<style"+nonceAttr(options.Nonce)+">", 1)),
+	}
+
+	var result strings.Builder
+	if err := detailTemplate.Execute(&result, templateData); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,63 @@
+package tablerenderer
+
+import (
+	"net/url"
+)
+
+// TableDefinition describes a table that other tables can link to
+type TableDefinition struct {
+	Name    string        `json:"name"`            // Name other tables refer to, e.g. "orders"
+	Title   string        `json:"title,omitempty"` // Human-readable title, e.g. "Orders"
+	BaseURL string        `json:"base_url"`        // URL the table is served at, e.g. "/orders"
+	Options *TableOptions `json:"-"`               // Options TableHandler serves the table with (default: paginated, sortable and searchable)
+}
+
+// DrillDown links cells to another registered table, pre-filtered by the
+// cell's value, e.g. a customer name to the orders of that customer
+type DrillDown struct {
+	Table  string `json:"table"`            // Name of the registered target table
+	Field  string `json:"field,omitempty"`  // Field of the target table to filter on (default: the column header)
+	Value  string `json:"value,omitempty"`  // Header of the row value to filter by, e.g. "CustomerID" (default: the cell value)
+	Target string `json:"target,omitempty"` // Link target, e.g. "_blank"
+}
+
+// RegisterTable makes a table definition available as a drill-down target
+func (r *Renderer) RegisterTable(definition TableDefinition) {
+	if r.tables == nil {
+		r.tables = make(map[string]TableDefinition)
+	}
+	r.tables[definition.Name] = definition
+}
+
+// FilterParam returns the query parameter that filters a table by a field,
+// e.g. "filter[customer]"
+func FilterParam(field string) string {
+	return "filter[" + field + "]"
+}
+
+// drillDownURL returns the URL of the target table filtered by the row's
+// value, reporting false when the target table is not registered
+func (r *Renderer) drillDownURL(drillDown *DrillDown, header string, row RowView, value interface{}) (string, bool) {
+	definition, ok := r.tables[drillDown.Table]
+	if !ok {
+		return "", false
+	}
+
+	target, err := url.Parse(definition.BaseURL)
+	if err != nil {
+		return "", false
+	}
+
+	field := drillDown.Field
+	if field == "" {
+		field = header
+	}
+	if drillDown.Value != "" {
+		value = row.Get(drillDown.Value)
+	}
+
+	query := target.Query()
+	query.Set(FilterParam(field), exportValue(value))
+	target.RawQuery = query.Encode()
+	return safeURL(target.String()), true
+}
@@ -0,0 +1,150 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// emailClassStyles maps the CSS classes used inside cells to the inline
+// declarations RenderEmailHTML substitutes for them
+var emailClassStyles = map[string]string{
+	"badge":          "display: inline-block; padding: 2px 6px; border-radius: 4px; font-size: 12px; font-weight: 600; line-height: 1",
+	"badge-true":     "background-color: #d4edda; color: #155724",
+	"badge-false":    "background-color: #f8d7da; color: #721c24",
+	"badge-green":    "background-color: #d4edda; color: #155724",
+	"badge-red":      "background-color: #f8d7da; color: #721c24",
+	"badge-yellow":   "background-color: #fff3cd; color: #856404",
+	"badge-blue":     "background-color: #cce5ff; color: #004085",
+	"badge-gray":     "background-color: #e2e3e5; color: #383d41",
+	"cell-truncated": "display: inline-block; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; vertical-align: bottom",
+}
+
+// classAttribute matches a class attribute and an optional style attribute
+// directly after it, as produced by the cell formatters
+var classAttribute = regexp.MustCompile(`class="([^"]*)"(\s+style="([^"]*)")?`)
+
+// emailLayoutWidth is the width in characters the email columns are laid
+// out in before converting them to percentages, about what fits a 600px
+// email at 14px
+const emailLayoutWidth = 80
+
+// emailRow is a row prepared for the email template
+type emailRow struct {
+	Shaded bool          // Alternate row background for striped tables
+	Cells  []interface{} // Formatted cell values
+}
+
+// emailTemplate lays out a table for email clients: no stylesheet, classes
+// or scripts, only inline styles and presentational attributes
+var emailTemplate = template.Must(template.New("email").Parse(`<table cellpadding="0" cellspacing="0" border="0" width="100%"{{if .Direction}} dir="{{.Direction}}"{{end}} style="border-collapse: collapse; font-family: Arial, Helvetica, sans-serif; font-size: 14px; color: #212529; background: #ffffff">
+{{- if .Rows}}
+<thead>
+<tr>
+{{- range $i, $header := .HeaderContents}}
+<th align="{{$.Align}}"{{with index $.Widths $i}} width="{{.}}%"{{end}} style="padding: 8px 12px; background: #f8f9fa; border-bottom: 2px solid #dee2e6; color: #495057; font-weight: bold; text-align: {{$.Align}}">{{$header}}</th>
+{{- end}}
+</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if .Shaded}} style="background-color: #f8f9fa"{{end}}>
+{{- range .Cells}}
+<td align="{{$.Align}}" style="padding: 8px 12px; border-bottom: 1px solid #dee2e6; text-align: {{$.Align}}">{{.}}</td>
+{{- end}}
+</tr>
+{{- end}}
+</tbody>
+{{- else}}
+<tr><td style="padding: 24px; text-align: center; color: #6c757d; font-style: italic">{{.NoRecordsText}}</td></tr>
+{{- end}}
+</table>`))
+
+// RenderEmailHTML renders the table for HTML email. The output uses inline
+// styles only and never contains scripts, pagination, sorting or search
+// controls, so it displays the same in webmail and desktop clients.
+// Column formatting, badges and links are kept.
+func (r *Renderer) RenderEmailHTML(data TableData) (string, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return "", err
+	}
+	headers, rows, sources := extractSource(headers, rows, data.Options.Source)
+
+	headerContents, err := r.renderHeaderContents(headers, data.Options.Columns, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Row links need a script, which email clients do not run, so only the
+	// cells are kept
+	rendered := r.renderRows(headers, rows, data.Options.Columns)
+	if sources != nil {
+		applySource(rendered, sources, data.Options.Source, false)
+	}
+	// Size the columns by their text so one long cell does not take up
+	// the whole width
+	texts := r.textRows(headers, rows, data.Options.Columns)
+	widths := columnPercentages(layoutColumns(headers, texts, data.Options.Columns, emailLayoutWidth))
+
+	var emailRows []emailRow
+	for i, row := range rendered {
+		for j, cell := range row.Cells {
+			if html, ok := cell.(template.HTML); ok {
+				row.Cells[j] = template.HTML(inlineEmailStyles(string(html)))
+			}
+		}
+		emailRows = append(emailRows, emailRow{Shaded: data.Options.Striped && i%2 == 1, Cells: row.Cells})
+	}
+
+	direction := textDirection(data.Options.Direction)
+	align := "left"
+	if direction == "rtl" {
+		align = "right"
+	}
+
+	templateData := struct {
+		HeaderContents []template.HTML
+		Widths         []int
+		Rows           []emailRow
+		Direction      string
+		Align          string
+		NoRecordsText  string
+	}{
+		HeaderContents: headerContents,
+		Widths:         widths,
+		Rows:           emailRows,
+		Direction:      direction,
+		Align:          align,
+		NoRecordsText:  r.translate(LabelNoRecords, 0),
+	}
+
+	var result strings.Builder
+	if err := emailTemplate.Execute(&result, templateData); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return result.String(), nil
+}
+
+// inlineEmailStyles replaces the class attributes in a trusted cell fragment
+// with the equivalent inline styles, keeping any style already set
+func inlineEmailStyles(html string) string {
+	return classAttribute.ReplaceAllStringFunc(html, func(match string) string {
+		groups := classAttribute.FindStringSubmatch(match)
+
+		var declarations []string
+		for _, class := range strings.Fields(groups[1]) {
+			if style, ok := emailClassStyles[class]; ok {
+				declarations = append(declarations, style)
+			}
+		}
+		if groups[3] != "" {
+			declarations = append(declarations, groups[3])
+		}
+		if len(declarations) == 0 {
+			return ""
+		}
+		return fmt.Sprintf(`style="%s"`, strings.Join(declarations, "; "))
+	})
+}
@@ -0,0 +1,35 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// EmptyState configures the block shown inside the table body when there
+// are no rows, in place of the plain "no records" message
+type EmptyState struct {
+	Message      string `json:"message,omitempty"`        // Text shown (default: translated "No records found")
+	Icon         string `json:"icon,omitempty"`           // Icon text shown above the message, e.g. "📭"
+	CallToAction HTML   `json:"call_to_action,omitempty"` // Trusted markup shown below the message, e.g. a link creating the first record
+}
+
+// generateEmptyStateHTML renders the content of the empty table body
+func (r *Renderer) generateEmptyStateHTML(emptyState *EmptyState) string {
+	message := emptyState.Message
+	if message == "" {
+		message = r.translate(LabelNoRecords, 0)
+	}
+
+	var html strings.Builder
+	html.WriteString(`<div class="empty-state" role="status">`)
+	if emptyState.Icon != "" {
+		html.WriteString(fmt.Sprintf(`<div class="empty-state-icon" aria-hidden="true">%s</div>`, template.HTMLEscapeString(emptyState.Icon)))
+	}
+	html.WriteString(fmt.Sprintf(`<p class="empty-state-message">%s</p>`, template.HTMLEscapeString(message)))
+	if emptyState.CallToAction != "" {
+		html.WriteString(fmt.Sprintf(`<div class="empty-state-action">%s</div>`, emptyState.CallToAction))
+	}
+	html.WriteString(`</div>`)
+	return html.String()
+}
@@ -0,0 +1,61 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+// Export encodings
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingWindows1252 = "windows-1252" // What Excel assumes for CSV files on Western European Windows systems
+)
+
+// encodingWriter returns a writer converting UTF-8 to the encoding and a
+// function flushing it. Characters the encoding lacks are written as "?".
+func encodingWriter(w io.Writer, encoding string) (io.Writer, func() error, error) {
+	switch strings.ToLower(encoding) {
+	case "", EncodingUTF8, "utf8":
+		return w, func() error { return nil }, nil
+	case EncodingWindows1252, "cp1252":
+		writer := transform.NewWriter(w, charmapEncoder{charmap.Windows1252})
+		return writer, writer.Close, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported export encoding %q", encoding)
+}
+
+// charmapEncoder encodes UTF-8 to a single-byte character set, replacing
+// unsupported characters with "?" rather than failing the export
+type charmapEncoder struct {
+	charmap *charmap.Charmap
+}
+
+// Transform implements transform.Transformer
+func (e charmapEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if nDst >= len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			// Wait for the rest of a sequence split across writes
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		b, ok := e.charmap.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		dst[nDst] = b
+		nDst++
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// Reset implements transform.Transformer
+func (charmapEncoder) Reset() {}
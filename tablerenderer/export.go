@@ -0,0 +1,316 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportOptions holds configuration for a single export call
+type ExportOptions struct {
+	Metadata *ExportMetadata `json:"metadata,omitempty"` // Optional preamble written before the header row
+	Trailer  bool            `json:"trailer,omitempty"`  // Append a trailer line with the row count and a SHA-256 checksum
+
+	// CSV settings for regional spreadsheet configurations, e.g. Excel in
+	// Germany expects Delimiter ';', DecimalComma and "windows-1252"
+	Delimiter    rune   `json:"delimiter,omitempty"`     // Field separator (default: ',')
+	DecimalComma bool   `json:"decimal_comma,omitempty"` // Write floating point numbers with a decimal comma, e.g. 3,5
+	Encoding     string `json:"encoding,omitempty"`      // "utf-8" or "windows-1252"; the trailer checksum covers the encoded bytes (default: "utf-8")
+
+	AnonymizeKey string `json:"-"` // Key for HMAC hashing of columns anonymized with "hash" (default: plain SHA-256)
+}
+
+// ExportTrailer summarizes an export so consumers can verify it is complete
+// The checksum covers every byte written before the trailer line
+type ExportTrailer struct {
+	RowCount int    `json:"row_count"`
+	SHA256   string `json:"sha256"`
+}
+
+// ExportMetadata describes the report preamble written at the top of an export
+type ExportMetadata struct {
+	Title       string            `json:"title,omitempty"`        // Report title
+	Author      string            `json:"author,omitempty"`       // Report author
+	GeneratedAt time.Time         `json:"generated_at,omitempty"` // Generation timestamp (default: time.Now())
+	Filters     map[string]string `json:"filters,omitempty"`      // Applied filters (default: derived from search and sorting options)
+	AsComments  bool              `json:"as_comments,omitempty"`  // Write the preamble as "#" comment lines instead of data rows
+}
+
+// ExportCSV writes the full table data as CSV
+// Struct slices in data.Data are converted the same way as for HTML rendering
+func (r *Renderer) ExportCSV(w io.Writer, data TableData, opts ExportOptions) error {
+	_, err := r.exportCSV(w, data, opts)
+	return err
+}
+
+// exportCSV writes the table data as CSV and returns the number of rows
+func (r *Renderer) exportCSV(w io.Writer, data TableData, opts ExportOptions) (int, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	out, digest := exportWriter(w, opts)
+	out, flush, err := encodingWriter(out, opts.Encoding)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.Metadata != nil {
+		if err := r.writeCSVMetadata(out, opts.Metadata, data.Options, opts.Delimiter); err != nil {
+			return 0, fmt.Errorf("failed to write export metadata: %w", err)
+		}
+	}
+
+	writer := csv.NewWriter(out)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+	if err := writer.Write(headers); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	anonymizers := exportAnonymizers(headers, data.Options.Columns, opts)
+	configs := columnsFor(headers, data.Options.Columns)
+	record := make([]string, len(headers))
+	for _, row := range rows {
+		row = anonymizeRow(row, anonymizers)
+		record = record[:0]
+		for i, value := range row {
+			var column *Column
+			if i < len(configs) {
+				column = configs[i]
+			}
+			record = append(record, csvValue(column, value, opts))
+		}
+		if err := writer.Write(record); err != nil {
+			return 0, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to flush CSV: %w", err)
+	}
+	if err := flush(); err != nil {
+		return 0, fmt.Errorf("failed to encode CSV: %w", err)
+	}
+
+	if digest != nil {
+		trailer := newExportTrailer(len(rows), digest)
+		if _, err := fmt.Fprintf(w, "# row_count=%d sha256=%s\n", trailer.RowCount, trailer.SHA256); err != nil {
+			return 0, fmt.Errorf("failed to write export trailer: %w", err)
+		}
+	}
+	return len(rows), nil
+}
+
+// ExportJSONL writes the full table data as JSON Lines, one object per row
+// Object keys follow the header order
+func (r *Renderer) ExportJSONL(w io.Writer, data TableData, opts ExportOptions) error {
+	_, err := r.exportJSONL(w, data, opts)
+	return err
+}
+
+// exportJSONL writes the table data as JSON Lines and returns the number of rows
+func (r *Renderer) exportJSONL(w io.Writer, data TableData, opts ExportOptions) (int, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	out, digest := exportWriter(w, opts)
+
+	keys := make([][]byte, len(headers))
+	for i, header := range headers {
+		keys[i], _ = json.Marshal(header)
+	}
+
+	anonymizers := exportAnonymizers(headers, data.Options.Columns, opts)
+	configs := columnsFor(headers, data.Options.Columns)
+	var line bytes.Buffer
+	for _, row := range rows {
+		row = anonymizeRow(row, anonymizers)
+		line.Reset()
+		line.WriteByte('{')
+		for i, value := range row {
+			if i >= len(keys) {
+				break
+			}
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			line.Write(keys[i])
+			line.WriteByte(':')
+			value, _ = roundValue(configs[i], value)
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				encoded, _ = json.Marshal(exportValue(value))
+			}
+			line.Write(encoded)
+		}
+		line.WriteString("}\n")
+		if _, err := out.Write(line.Bytes()); err != nil {
+			return 0, fmt.Errorf("failed to write JSONL row: %w", err)
+		}
+	}
+
+	if digest != nil {
+		trailer, err := json.Marshal(map[string]ExportTrailer{"_trailer": newExportTrailer(len(rows), digest)})
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode export trailer: %w", err)
+		}
+		if _, err := w.Write(append(trailer, '\n')); err != nil {
+			return 0, fmt.Errorf("failed to write export trailer: %w", err)
+		}
+	}
+	return len(rows), nil
+}
+
+// csvValue converts a value to its CSV field, rounded to the column's
+// precision and with a decimal comma for floating point numbers when requested
+func csvValue(column *Column, value interface{}, opts ExportOptions) string {
+	if rounded, ok := roundValue(column, value); ok {
+		precision, _ := columnPrecision(column)
+		text := strconv.FormatFloat(rounded.(float64), 'f', precision, 64)
+		if opts.DecimalComma {
+			text = strings.Replace(text, ".", ",", 1)
+		}
+		return text
+	}
+	if opts.DecimalComma {
+		switch v := value.(type) {
+		case float64:
+			return strings.Replace(strconv.FormatFloat(v, 'f', -1, 64), ".", ",", 1)
+		case float32:
+			return strings.Replace(strconv.FormatFloat(float64(v), 'f', -1, 32), ".", ",", 1)
+		}
+	}
+	return exportValue(value)
+}
+
+// exportWriter returns the writer export content should go to and, when a
+// trailer is requested, the digest accumulating everything written to it
+func exportWriter(w io.Writer, opts ExportOptions) (io.Writer, hash.Hash) {
+	if !opts.Trailer {
+		return w, nil
+	}
+	digest := sha256.New()
+	return io.MultiWriter(w, digest), digest
+}
+
+// newExportTrailer builds the trailer from the row count and accumulated digest
+func newExportTrailer(rowCount int, digest hash.Hash) ExportTrailer {
+	return ExportTrailer{
+		RowCount: rowCount,
+		SHA256:   hex.EncodeToString(digest.Sum(nil)),
+	}
+}
+
+// writeCSVMetadata writes the metadata preamble followed by a blank separator line
+// Data row preambles use the export's delimiter
+func (r *Renderer) writeCSVMetadata(w io.Writer, metadata *ExportMetadata, options TableOptions, delimiter rune) error {
+	lines := metadataLines(metadata, options)
+
+	if metadata.AsComments {
+		for _, line := range lines {
+			if _, err := fmt.Fprintf(w, "# %s: %s\n", line[0], line[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	if delimiter != 0 {
+		writer.Comma = delimiter
+	}
+	for _, line := range lines {
+		if err := writer.Write(line); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// metadataLines returns the preamble as label/value pairs in a stable order
+func metadataLines(metadata *ExportMetadata, options TableOptions) [][]string {
+	var lines [][]string
+
+	if metadata.Title != "" {
+		lines = append(lines, []string{"Title", metadata.Title})
+	}
+	if metadata.Author != "" {
+		lines = append(lines, []string{"Author", metadata.Author})
+	}
+
+	generatedAt := metadata.GeneratedAt
+	if generatedAt.IsZero() {
+		generatedAt = time.Now()
+	}
+	lines = append(lines, []string{"Generated At", generatedAt.Format(time.RFC3339)})
+
+	filters := metadata.Filters
+	if filters == nil {
+		filters = appliedFilters(options)
+	}
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, []string{"Filter " + key, filters[key]})
+	}
+
+	return lines
+}
+
+// appliedFilters derives the active filters from the search and sorting options
+func appliedFilters(options TableOptions) map[string]string {
+	filters := make(map[string]string)
+	if options.Search != nil && options.Search.Enabled && options.Search.SearchTerm != "" {
+		filters["search"] = options.Search.SearchTerm
+	}
+	if options.Sorting != nil && options.Sorting.Enabled && options.Sorting.SortBy != "" {
+		filters["sort_by"] = options.Sorting.SortBy
+		if options.Sorting.SortOrder != "" {
+			filters["sort_order"] = options.Sorting.SortOrder
+		}
+	}
+	for _, filter := range options.Filters {
+		filters[filter.Param()] = filter.Value()
+	}
+	return filters
+}
+
+// exportValue converts a cell value to its plain text export representation
+func exportValue(value interface{}) string {
+	value = domainValue(value)
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
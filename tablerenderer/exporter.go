@@ -0,0 +1,229 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Export job statuses
+const (
+	ExportSucceeded = "succeeded"
+	ExportFailed    = "failed"
+)
+
+// ExportJob describes an export to run in the background
+type ExportJob struct {
+	ID      string        `json:"id"`               // Job identifier, also used in the artifact key
+	Format  string        `json:"format,omitempty"` // "csv", "jsonl" or "xlsx" (default: "csv")
+	Data    TableData     `json:"-"`                // Data to export
+	Options ExportOptions `json:"options"`          // Export options
+}
+
+// ExportResult reports the outcome of an export job
+type ExportResult struct {
+	JobID      string    `json:"job_id"`
+	Status     string    `json:"status"`          // "succeeded" or "failed"
+	Error      string    `json:"error,omitempty"` // Failure reason
+	RowCount   int       `json:"row_count"`       // Number of exported rows
+	Key        string    `json:"key,omitempty"`   // Blob key of the artifact
+	URL        string    `json:"url,omitempty"`   // Download URL of the artifact
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Webhook is an endpoint notified with the ExportResult when a job finishes
+// The JSON body is signed with HMAC-SHA256 in the X-Table-Signature header
+// ("sha256=<hex>") when a secret is set
+type Webhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"-"`
+}
+
+// Exporter runs export jobs in the background, stores the artifacts in a
+// BlobStore and notifies callbacks and webhooks when each job finishes
+type Exporter struct {
+	Renderer    *Renderer                        // Renderer used for exports (default: NewRenderer())
+	Store       BlobStore                        // Destination of the artifacts
+	KeyPrefix   string                           // Prefix for artifact keys, e.g. "exports/"
+	ArtifactURL func(key string) (string, error) // Builds the download URL of an artifact, e.g. with SignURL
+
+	Callbacks      []func(result ExportResult)      // Called when a job finishes
+	Webhooks       []Webhook                        // Notified when a job finishes
+	OnWebhookError func(webhook Webhook, err error) // Called when a webhook could not be delivered
+	Client         *http.Client                     // HTTP client for webhooks (default: 10s timeout)
+
+	wg sync.WaitGroup
+}
+
+// OnComplete registers a callback called when a job finishes
+func (e *Exporter) OnComplete(callback func(result ExportResult)) {
+	e.Callbacks = append(e.Callbacks, callback)
+}
+
+// AddWebhook registers a webhook notified when a job finishes
+func (e *Exporter) AddWebhook(url string, secret string) {
+	e.Webhooks = append(e.Webhooks, Webhook{URL: url, Secret: secret})
+}
+
+// Start runs a job in the background
+func (e *Exporter) Start(ctx context.Context, job ExportJob) {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.Run(ctx, job)
+	}()
+}
+
+// Wait blocks until all started jobs and their notifications have finished
+func (e *Exporter) Wait() {
+	e.wg.Wait()
+}
+
+// Run exports a job synchronously, stores the artifact, notifies callbacks
+// and webhooks, and returns the result
+func (e *Exporter) Run(ctx context.Context, job ExportJob) ExportResult {
+	result := ExportResult{JobID: job.ID, StartedAt: time.Now()}
+
+	rowCount, key, err := e.export(ctx, job)
+	result.RowCount = rowCount
+	result.FinishedAt = time.Now()
+	if err == nil && e.ArtifactURL != nil {
+		result.URL, err = e.ArtifactURL(key)
+	}
+	if err != nil {
+		result.Status = ExportFailed
+		result.Error = err.Error()
+	} else {
+		result.Status = ExportSucceeded
+		result.Key = key
+	}
+
+	e.notify(ctx, result)
+	return result
+}
+
+// export streams the job's export into the blob store
+func (e *Exporter) export(ctx context.Context, job ExportJob) (int, string, error) {
+	if e.Store == nil {
+		return 0, "", fmt.Errorf("exporter has no blob store")
+	}
+
+	renderer := e.Renderer
+	if renderer == nil {
+		renderer = NewRenderer()
+	}
+
+	var write func(w io.Writer) (int, error)
+	var extension, contentType string
+	switch job.Format {
+	case "", "csv":
+		extension, contentType = "csv", "text/csv; charset=utf-8"
+		write = func(w io.Writer) (int, error) { return renderer.exportCSV(w, job.Data, job.Options) }
+	case "jsonl":
+		extension, contentType = "jsonl", "application/x-ndjson"
+		write = func(w io.Writer) (int, error) { return renderer.exportJSONL(w, job.Data, job.Options) }
+	case "xlsx":
+		extension, contentType = "xlsx", xlsxContentType
+		write = func(w io.Writer) (int, error) { return renderer.exportXLSX(w, job.Data, job.Options) }
+	default:
+		return 0, "", fmt.Errorf("unsupported export format %q", job.Format)
+	}
+
+	key := fmt.Sprintf("%s%s.%s", e.KeyPrefix, job.ID, extension)
+
+	// Stream the export into the store instead of buffering it
+	reader, writer := io.Pipe()
+	rowCounts := make(chan int, 1)
+	go func() {
+		rowCount, err := write(writer)
+		writer.CloseWithError(err)
+		rowCounts <- rowCount
+	}()
+
+	err := e.Store.Put(ctx, key, reader, contentType)
+	// Unblock the writer if the store stopped reading early
+	reader.CloseWithError(err)
+	rowCount := <-rowCounts
+	if err != nil {
+		return rowCount, "", fmt.Errorf("failed to store export: %w", err)
+	}
+	return rowCount, key, nil
+}
+
+// notify calls the callbacks and delivers the webhooks for a result
+func (e *Exporter) notify(ctx context.Context, result ExportResult) {
+	for _, callback := range e.Callbacks {
+		callback(result)
+	}
+	if len(e.Webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	for _, webhook := range e.Webhooks {
+		if err := e.deliverWebhook(ctx, webhook, body); err != nil && e.OnWebhookError != nil {
+			e.OnWebhookError(webhook, err)
+		}
+	}
+}
+
+// deliverWebhook posts a result to a webhook, retrying failed deliveries
+// with exponential backoff
+func (e *Exporter) deliverWebhook(ctx context.Context, webhook Webhook, body []byte) error {
+	client := e.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	const attempts = 3
+	backoff := time.Second
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if webhook.Secret != "" {
+			mac := hmac.New(sha256.New, []byte(webhook.Secret))
+			mac.Write(body)
+			req.Header.Set("X-Table-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook responded with %s", resp.Status)
+		if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("failed to deliver webhook after %d attempts: %w", attempts, lastErr)
+}
@@ -0,0 +1,85 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+)
+
+// ExportCopy is the export format of the Copy button, which copies the rows
+// to the clipboard as tab-separated values instead of downloading a file
+const ExportCopy = "copy"
+
+// exportFormatNames are the button texts of the common export formats;
+// other formats show in upper case
+var exportFormatNames = map[string]string{
+	"csv":   "CSV",
+	"tsv":   "TSV",
+	"jsonl": "JSON",
+	"xlsx":  "Excel",
+	"pdf":   "PDF",
+}
+
+// exportCopyScript fetches the TSV export of a Copy button and writes it to
+// the clipboard, marking the button with data-copied for two seconds.
+// Browsers without the Clipboard API follow the link instead.
+const exportCopyScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+c.addEventListener('click',function(e){
+var a=e.target.closest('a[data-export-copy]');if(!a||!c.contains(a)||!navigator.clipboard)return;
+e.preventDefault();
+fetch(a.href,{credentials:'same-origin'}).then(function(r){if(!r.ok)throw new Error(r.status);return r.text();}).then(function(text){return navigator.clipboard.writeText(text);}).then(function(){
+a.setAttribute('data-copied','true');setTimeout(function(){a.removeAttribute('data-copied');},2000);
+}).catch(function(){location.href=a.href;});
+});
+})();`
+
+// generateExportToolbarHTML generates the export buttons of the toolbar, one
+// link per format carrying the current sort, search and filters. The Copy
+// button needs a script and is skipped when scripts are not allowed.
+func (r *Renderer) generateExportToolbarHTML(options TableOptions, allowScripts bool) string {
+	export := options.ExportLinks
+	if export == nil || !export.Toolbar {
+		return ""
+	}
+	urls := exportURLs(options)
+
+	var buttons strings.Builder
+	shortcut := ` data-table-export`
+	for _, format := range export.formats() {
+		url := urls[format]
+		if url == "" || (format == ExportCopy && !allowScripts) {
+			continue
+		}
+		name, ok := exportFormatNames[format]
+		if !ok {
+			name = strings.ToUpper(format)
+		}
+		var attrs string
+		switch format {
+		case ExportCopy:
+			attrs = ` data-export-copy`
+			name = r.translate(LabelCopy, 1)
+		case ExportPrint:
+			attrs = ` target="_blank"`
+			name = r.translate(LabelPrint, 1)
+		default:
+			// The export keyboard shortcut clicks the first download button
+			attrs, shortcut = shortcut, ""
+		}
+		buttons.WriteString(fmt.Sprintf(`<a class="export-button export-%s" href="%s"%s>%s</a>`,
+			template.HTMLEscapeString(format), template.HTMLEscapeString(url), attrs, template.HTMLEscapeString(name)))
+	}
+	if buttons.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`<div class="export-toolbar" role="group" aria-label="%s">%s</div>`,
+		template.HTMLEscapeString(r.translate(LabelExport, 1)), buttons.String())
+}
+
+// hasExportCopy reports whether the export toolbar offers the Copy button
+func hasExportCopy(options TableOptions) bool {
+	export := options.ExportLinks
+	return export != nil && export.Toolbar && slices.Contains(export.formats(), ExportCopy)
+}
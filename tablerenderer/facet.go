@@ -0,0 +1,256 @@
+package tablerenderer
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// Facet is a dropdown filter on a field, listing its distinct values, e.g.
+// the statuses of orders. Choosing a value sets the filter[field]
+// parameter, which is kept by pagination, sorting and search links.
+type Facet struct {
+	Field      string       `json:"field"`                 // Header the facet filters
+	Label      string       `json:"label,omitempty"`       // Dropdown label (default: the column label)
+	Values     []FacetValue `json:"values,omitempty"`      // Values to choose from; loaded from a FacetSource when empty
+	ShowCounts bool         `json:"show_counts,omitempty"` // Show the number of rows of each value, e.g. "Open (12)"
+}
+
+// FacetValue is a value a facet can filter by
+type FacetValue struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"` // Option text (default: Value)
+	Count int    `json:"count,omitempty"` // Rows with the value, shown with Facet.ShowCounts
+}
+
+// FacetSource is implemented by data sources that can list the values of
+// a facet, e.g. with SELECT status, COUNT(*) ... GROUP BY status. The
+// values are counted over the rows matching the search and the filters on
+// other fields. TableHandler loads facets without values from it.
+type FacetSource interface {
+	FacetValues(ctx context.Context, options TableOptions, field string) ([]FacetValue, error)
+}
+
+// DistinctFacetValues returns the distinct values of the field in rows
+// with their counts, in ascending order. Empty values are left out.
+func DistinctFacetValues(headers []string, rows [][]interface{}, field string) []FacetValue {
+	column := -1
+	for i, header := range headers {
+		if header == field {
+			column = i
+		}
+	}
+	if column < 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	var samples []interface{}
+	for _, row := range rows {
+		if column >= len(row) || isEmptyValue(row[column]) {
+			continue
+		}
+		text := exportValue(row[column])
+		if counts[text] == 0 {
+			samples = append(samples, row[column])
+		}
+		counts[text]++
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		if order, ok := compareValues(domainValue(samples[i]), domainValue(samples[j])); ok {
+			return order < 0
+		}
+		return exportValue(samples[i]) < exportValue(samples[j])
+	})
+	values := make([]FacetValue, len(samples))
+	for i, sample := range samples {
+		text := exportValue(sample)
+		values[i] = FacetValue{Value: text, Count: counts[text]}
+	}
+	return values
+}
+
+// FacetValues implements FacetSource
+func (s SliceSource) FacetValues(ctx context.Context, options TableOptions, field string) ([]FacetValue, error) {
+	// Count over the rows other filters leave, so each value's count is
+	// the number of rows choosing it would show
+	var others []Filter
+	for _, filter := range options.Filters {
+		if filter.Field != field {
+			others = append(others, filter)
+		}
+	}
+	options.Filters = others
+	options.Sorting = nil
+	options.Pagination = nil
+
+	data, err := s.Load(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return DistinctFacetValues(s.Headers, data.Rows, field), nil
+}
+
+// loadFacets fills in the values of facets that have none from source
+func (r *Renderer) loadFacets(ctx context.Context, source DataSource, options *TableOptions) error {
+	facetSource, ok := source.(FacetSource)
+	if !ok || !r.Features.Has(FeatureAdvancedFilters) || len(options.Facets) == 0 {
+		return nil
+	}
+	facets := make([]Facet, len(options.Facets))
+	for i, facet := range options.Facets {
+		if len(facet.Values) == 0 {
+			values, err := facetSource.FacetValues(ctx, *options, facet.Field)
+			if err != nil {
+				return fmt.Errorf("failed to load facet %q: %w", facet.Field, err)
+			}
+			facet.Values = values
+		}
+		facets[i] = facet
+	}
+	options.Facets = facets
+	return nil
+}
+
+// selectedFacetValue returns the value the facet's field is filtered by
+func selectedFacetValue(filters []Filter, field string) (string, bool) {
+	for _, filter := range filters {
+		if filter.Field == field && filter.operator() == FilterEquals && len(filter.Values) == 1 {
+			return filter.Values[0], true
+		}
+	}
+	return "", false
+}
+
+// facetLinkParams returns the parameters the facet form preserves: the
+// sort order, page size, search term and filters on other fields. The page
+// is reset.
+func (r *Renderer) facetLinkParams(options TableOptions, baseURL string, pageSize int) map[string]string {
+	params := r.parseQueryParams(baseURL)
+	if options.Sorting != nil && options.Sorting.Enabled && options.Sorting.SortBy != "" {
+		params["sort_by"] = options.Sorting.SortBy
+		params["sort_order"] = options.Sorting.SortOrder
+	}
+	setNullsParam(params, options.Sorting)
+	setFilterParams(params, options.Filters)
+	setColumnsParam(params, options.VisibleColumns)
+	setWidthsParam(params, options)
+	if options.Pagination != nil && options.Pagination.Enabled && pageSize > 0 {
+		params["page_size"] = pageSizeValue(options.Pagination, pageSize)
+	}
+	if options.Search != nil && options.Search.Enabled && options.Search.SearchTerm != "" {
+		searchParam := options.Search.QueryParam
+		if searchParam == "" {
+			searchParam = "search"
+		}
+		params[searchParam] = options.Search.SearchTerm
+	}
+
+	delete(params, "page")
+	if options.Pagination != nil && options.Pagination.QueryParam != "" {
+		delete(params, options.Pagination.QueryParam)
+	}
+	for _, facet := range options.Facets {
+		delete(params, FilterParam(facet.Field))
+	}
+	return params
+}
+
+// facetBaseURL returns the URL the facet form submits to: the base URL of
+// the search, pagination or sorting links
+func facetBaseURL(options TableOptions) string {
+	switch {
+	case options.Search != nil && options.Search.BaseURL != "":
+		return options.Search.BaseURL
+	case options.Pagination != nil && options.Pagination.BaseURL != "":
+		return options.Pagination.BaseURL
+	case options.Sorting != nil && options.Sorting.BaseURL != "":
+		return options.Sorting.BaseURL
+	}
+	return ""
+}
+
+// generateFacetsHTML generates the form with a dropdown per facet. With
+// scripts a choice submits the form; without, an Apply button does.
+func (r *Renderer) generateFacetsHTML(headers []string, options TableOptions, pageSize int, allowScripts bool) string {
+	if len(options.Facets) == 0 {
+		return ""
+	}
+	baseURL := facetBaseURL(options)
+	params := r.facetLinkParams(options, baseURL, pageSize)
+	configs := columnsFor(headers, options.Columns)
+
+	var html strings.Builder
+	action := ""
+	if baseURL != "" {
+		action = linkURL(baseURL, nil)
+	}
+	html.WriteString(fmt.Sprintf(`<form method="GET" action="%s" class="facet-filters"%s>`,
+		template.HTMLEscapeString(action), turboFrameAttr(turboFrameID(options))))
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+			template.HTMLEscapeString(key), template.HTMLEscapeString(params[key])))
+	}
+
+	// formControlsScript submits the form when a dropdown changes
+	autoSubmit := ""
+	if allowScripts {
+		autoSubmit = ` data-auto-submit`
+	}
+	for _, facet := range options.Facets {
+		label := facet.Label
+		if label == "" {
+			label = facet.Field
+			for i, header := range headers {
+				if header == facet.Field {
+					label = headerLabel(configs[i], header)
+				}
+			}
+		}
+
+		html.WriteString(fmt.Sprintf(`<label class="facet"><span>%s</span><select name="%s"%s>`,
+			template.HTMLEscapeString(label), template.HTMLEscapeString(FilterParam(facet.Field)), autoSubmit))
+		html.WriteString(fmt.Sprintf(`<option value="">%s</option>`, template.HTMLEscapeString(r.translate(LabelFacetAll, 1))))
+
+		selected, filtered := selectedFacetValue(options.Filters, facet.Field)
+		listed := false
+		for _, value := range facet.Values {
+			text := value.Label
+			if text == "" {
+				text = value.Value
+			}
+			if facet.ShowCounts {
+				text = fmt.Sprintf("%s (%d)", text, value.Count)
+			}
+			attr := ""
+			if filtered && value.Value == selected {
+				attr = " selected"
+				listed = true
+			}
+			html.WriteString(fmt.Sprintf(`<option value="%s"%s>%s</option>`,
+				template.HTMLEscapeString(value.Value), attr, template.HTMLEscapeString(text)))
+		}
+		// Keep a chosen value that is no longer listed, e.g. with no rows left
+		if filtered && !listed {
+			html.WriteString(fmt.Sprintf(`<option value="%s" selected>%s</option>`,
+				template.HTMLEscapeString(selected), template.HTMLEscapeString(selected)))
+		}
+		html.WriteString(`</select></label>`)
+	}
+
+	if !allowScripts {
+		html.WriteString(fmt.Sprintf(`<button type="submit" class="facet-apply">%s</button>`,
+			template.HTMLEscapeString(r.translate(LabelApplyFilters, 1))))
+	}
+	html.WriteString(`</form>`)
+	return html.String()
+}
@@ -0,0 +1,76 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Features is a set of experimental subsystems enabled on a renderer. They
+// are off by default so they can be rolled out per environment, e.g. from
+// an environment variable with ParseFeatures. Options of a disabled
+// subsystem are ignored when loading and rendering.
+type Features uint
+
+// Experimental subsystems
+const (
+	FeatureLiveUpdates     Features = 1 << iota // Tables refreshing themselves without a page load
+	FeatureInlineEdit                           // Editing cells in place
+	FeatureAdvancedFilters                      // Filter operators and faceted filters
+
+	FeatureAll = FeatureLiveUpdates | FeatureInlineEdit | FeatureAdvancedFilters
+)
+
+// featureNames maps the names accepted by ParseFeatures to features
+var featureNames = map[string]Features{
+	"live_updates":     FeatureLiveUpdates,
+	"inline_edit":      FeatureInlineEdit,
+	"advanced_filters": FeatureAdvancedFilters,
+	"all":              FeatureAll,
+}
+
+// Has reports whether all features of f are enabled
+func (features Features) Has(f Features) bool {
+	return features&f == f
+}
+
+// dropDisabledFeatures clears the options of subsystems the renderer does
+// not enable, so they are ignored when rendering
+func (r *Renderer) dropDisabledFeatures(options *TableOptions) {
+	if !r.Features.Has(FeatureLiveUpdates) && options.Search != nil && options.Search.Live {
+		search := *options.Search
+		search.Live = false
+		options.Search = &search
+	}
+	if !r.Features.Has(FeatureLiveUpdates) && options.Pagination != nil && options.Pagination.InfiniteScroll {
+		pagination := *options.Pagination
+		// Keep the Load more link infinite scroll builds on
+		pagination.LoadMore = true
+		pagination.InfiniteScroll = false
+		options.Pagination = &pagination
+	}
+	if !r.Features.Has(FeatureInlineEdit) {
+		options.InlineEdit = nil
+	}
+	if !r.Features.Has(FeatureAdvancedFilters) {
+		options.Filters = nil
+		options.Facets = nil
+	}
+}
+
+// ParseFeatures parses a comma-separated list of feature names, e.g.
+// "live_updates,inline_edit" or "all"
+func ParseFeatures(list string) (Features, error) {
+	var features Features
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		feature, ok := featureNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown feature %q", name)
+		}
+		features |= feature
+	}
+	return features, nil
+}
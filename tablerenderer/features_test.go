@@ -0,0 +1,67 @@
+package tablerenderer
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDisabledFiltersIgnored(t *testing.T) {
+	headers := []string{"Name", "Age"}
+	rows := [][]interface{}{{"Ada", 36}, {"Bob", 25}}
+	source := SliceSource{Headers: headers, Rows: rows}
+	filters := []Filter{{Field: "Age", Operator: FilterGreater, Values: []string{"30"}}}
+
+	entryPoints := []struct {
+		name   string
+		render func(r *Renderer) string
+	}{
+		{"TableHandler", func(r *Renderer) string {
+			recorder := httptest.NewRecorder()
+			r.TableHandler(TableDefinition{Name: "people", BaseURL: "/people"}, source).ServeHTTP(recorder,
+				httptest.NewRequest("GET", "/people?filter[Age][gt]=30", nil))
+			return recorder.Body.String()
+		}},
+		{"Handler", func(r *Renderer) string {
+			recorder := httptest.NewRecorder()
+			r.Handler(source, TableOptions{}).ServeHTTP(recorder, httptest.NewRequest("GET", "/people?filter[Age][gt]=30", nil))
+			return recorder.Body.String()
+		}},
+		{"DataTablesHandler", func(r *Renderer) string {
+			recorder := httptest.NewRecorder()
+			r.DataTablesHandler(headers, source, TableOptions{Filters: filters}).ServeHTTP(recorder,
+				httptest.NewRequest("GET", "/people.json?draw=1", nil))
+			return recorder.Body.String()
+		}},
+		{"RenderHTMLInMemory", func(r *Renderer) string {
+			html, err := r.RenderHTMLInMemory(TableData{Headers: headers, Rows: rows, Options: TableOptions{Filters: filters}})
+			if err != nil {
+				t.Fatalf("RenderHTMLInMemory() error = %v", err)
+			}
+			return html
+		}},
+	}
+	for _, entry := range entryPoints {
+		for _, features := range []Features{0, FeatureAdvancedFilters} {
+			t.Run(entry.name+"/"+featureLabel(features), func(t *testing.T) {
+				r := NewRenderer()
+				r.Features = features
+				out := entry.render(r)
+				if !strings.Contains(out, "Ada") {
+					t.Errorf("matching row missing from:\n%s", out)
+				}
+				if got, want := strings.Contains(out, "Bob"), features == 0; got != want {
+					t.Errorf("filtered row rendered = %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+// featureLabel names a feature set in subtest names
+func featureLabel(features Features) string {
+	if features == 0 {
+		return "disabled"
+	}
+	return "enabled"
+}
@@ -0,0 +1,228 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter operators. In query parameters the operator follows the field,
+// e.g. filter[age][gt]=30; filter[status]=open is an equals filter.
+const (
+	FilterEquals     = "eq"          // Value equals the filter value
+	FilterContains   = "contains"    // Value contains the filter value, ignoring case
+	FilterStartsWith = "starts_with" // Value starts with the filter value, ignoring case
+	FilterGreater    = "gt"          // Value is greater than the filter value
+	FilterLess       = "lt"          // Value is less than the filter value
+	FilterBetween    = "between"     // Value is within two comma-separated values, inclusive
+	FilterIn         = "in"          // Value is one of the comma-separated values
+)
+
+// Filter restricts a field with an operator, e.g. age between 18 and 30.
+// Filters are parsed from the query with ParseFilters and applied with
+// SQL, Match or a data source honouring TableOptions.Filters.
+type Filter struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"` // One of the Filter* operators (default: FilterEquals)
+	Values   []string `json:"values"`   // Two values for FilterBetween, one or more for FilterIn, one otherwise
+}
+
+// validFilterOperator reports whether op is a known operator
+func validFilterOperator(op string) bool {
+	switch op {
+	case FilterEquals, FilterContains, FilterStartsWith, FilterGreater, FilterLess, FilterBetween, FilterIn:
+		return true
+	}
+	return false
+}
+
+// ParseFilters reads the filter[field] and filter[field][op] parameters of
+// a query, sorted by field. Unknown operators and filters without the
+// values their operator needs are skipped.
+func ParseFilters(values url.Values) []Filter {
+	var filters []Filter
+	for key, list := range values {
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok || len(list) == 0 {
+			continue
+		}
+		field, op, ok := strings.Cut(rest, "]")
+		if !ok || field == "" {
+			continue
+		}
+		if op != "" {
+			if !strings.HasPrefix(op, "[") || !strings.HasSuffix(op, "]") {
+				continue
+			}
+			op = op[1 : len(op)-1]
+		}
+
+		filter := Filter{Field: field, Operator: op}
+		if filter.Operator == "" {
+			filter.Operator = FilterEquals
+		}
+		for _, value := range list {
+			if filter.Operator == FilterBetween || filter.Operator == FilterIn {
+				filter.Values = append(filter.Values, strings.Split(value, ",")...)
+			} else {
+				filter.Values = append(filter.Values, value)
+			}
+		}
+		// An empty equals filter is a cleared dropdown, not a filter for
+		// empty values
+		if filter.valid() && filter.Value() != "" {
+			filters = append(filters, filter)
+		}
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Operator < filters[j].Operator
+	})
+	return filters
+}
+
+// operator returns the operator of the filter, defaulting to FilterEquals
+func (f Filter) operator() string {
+	if f.Operator == "" {
+		return FilterEquals
+	}
+	return f.Operator
+}
+
+// valid reports whether the filter has a known operator and the number of
+// values it needs
+func (f Filter) valid() bool {
+	op := f.operator()
+	switch {
+	case f.Field == "" || !validFilterOperator(op):
+		return false
+	case op == FilterBetween:
+		return len(f.Values) == 2
+	case op == FilterIn:
+		return len(f.Values) > 0
+	}
+	return len(f.Values) == 1
+}
+
+// Param returns the query parameter of the filter, e.g. "filter[age][gt]"
+func (f Filter) Param() string {
+	if f.operator() == FilterEquals {
+		return FilterParam(f.Field)
+	}
+	return FilterParam(f.Field) + "[" + f.operator() + "]"
+}
+
+// Value returns the query parameter value of the filter
+func (f Filter) Value() string {
+	return strings.Join(f.Values, ",")
+}
+
+// setFilterParams adds the filters to the parameters preserved in links
+func setFilterParams(params map[string]string, filters []Filter) {
+	for _, filter := range filters {
+		params[filter.Param()] = filter.Value()
+	}
+}
+
+// SQL returns the condition of the filter on column with ? placeholders and
+// their arguments, e.g. "age > ?" and ["30"]. column is inserted as is, so
+// map the filter's field to a trusted column name rather than passing the
+// field from the request. Contains and StartsWith compare both sides with
+// LOWER, so they ignore case whatever the collation.
+func (f Filter) SQL(column string) (string, []interface{}, error) {
+	if !f.valid() {
+		return "", nil, fmt.Errorf("invalid %q filter on %q", f.Operator, f.Field)
+	}
+	switch f.operator() {
+	case FilterContains:
+		return "LOWER(" + column + ") LIKE LOWER(?) ESCAPE '!'", []interface{}{"%" + escapeLike(f.Values[0]) + "%"}, nil
+	case FilterStartsWith:
+		return "LOWER(" + column + ") LIKE LOWER(?) ESCAPE '!'", []interface{}{escapeLike(f.Values[0]) + "%"}, nil
+	case FilterGreater:
+		return column + " > ?", []interface{}{f.Values[0]}, nil
+	case FilterLess:
+		return column + " < ?", []interface{}{f.Values[0]}, nil
+	case FilterBetween:
+		return column + " BETWEEN ? AND ?", []interface{}{f.Values[0], f.Values[1]}, nil
+	case FilterIn:
+		args := make([]interface{}, len(f.Values))
+		for i, value := range f.Values {
+			args[i] = value
+		}
+		return column + " IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ") + ")", args, nil
+	}
+	return column + " = ?", []interface{}{f.Values[0]}, nil
+}
+
+// escapeLike escapes the LIKE wildcards of a value with "!", which unlike a
+// backslash needs no escaping in string literals on any database
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
+// Match reports whether a cell value passes the filter. Numbers and times
+// are compared as such; other values as their export text.
+func (f Filter) Match(value interface{}) bool {
+	if !f.valid() {
+		return true
+	}
+	value = domainValue(value)
+	text := exportValue(value)
+	switch f.operator() {
+	case FilterContains:
+		return strings.Contains(strings.ToLower(text), strings.ToLower(f.Values[0]))
+	case FilterStartsWith:
+		return strings.HasPrefix(strings.ToLower(text), strings.ToLower(f.Values[0]))
+	case FilterGreater:
+		return compareFilterValue(value, f.Values[0]) > 0
+	case FilterLess:
+		return !isEmptyValue(value) && compareFilterValue(value, f.Values[0]) < 0
+	case FilterBetween:
+		return !isEmptyValue(value) && compareFilterValue(value, f.Values[0]) >= 0 && compareFilterValue(value, f.Values[1]) <= 0
+	case FilterIn:
+		for _, candidate := range f.Values {
+			if compareFilterValue(value, candidate) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	return compareFilterValue(value, f.Values[0]) == 0
+}
+
+// compareFilterValue compares a cell value with a filter value parsed to
+// the cell's type, falling back to comparing the export text
+func compareFilterValue(value interface{}, filterValue string) int {
+	if _, ok := numericValue(value); ok {
+		if n, err := strconv.ParseFloat(filterValue, 64); err == nil {
+			order, _ := compareValues(value, n)
+			return order
+		}
+	}
+	if _, ok := timeValue(value); ok {
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, filterValue); err == nil {
+				order, _ := compareValues(value, t)
+				return order
+			}
+		}
+	}
+	return strings.Compare(exportValue(value), filterValue)
+}
+
+// matchFilters reports whether a row passes every filter on its headers
+func matchFilters(headers []string, row []interface{}, filters []Filter) bool {
+	for _, filter := range filters {
+		for i, header := range headers {
+			if header == filter.Field && i < len(row) && !filter.Match(row[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package tablerenderer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    Filter
+		wantSQL   string
+		wantArgs  []interface{}
+		wantError bool
+	}{
+		{
+			name:     "equals by default",
+			filter:   Filter{Field: "status", Values: []string{"open"}},
+			wantSQL:  "status = ?",
+			wantArgs: []interface{}{"open"},
+		},
+		{
+			name:     "contains escapes wildcards",
+			filter:   Filter{Field: "name", Operator: FilterContains, Values: []string{"50%_off!"}},
+			wantSQL:  "LOWER(name) LIKE LOWER(?) ESCAPE '!'",
+			wantArgs: []interface{}{"%50!%!_off!!%"},
+		},
+		{
+			name:     "starts with escapes wildcards",
+			filter:   Filter{Field: "name", Operator: FilterStartsWith, Values: []string{"a_b"}},
+			wantSQL:  "LOWER(name) LIKE LOWER(?) ESCAPE '!'",
+			wantArgs: []interface{}{"a!_b%"},
+		},
+		{
+			name:     "greater",
+			filter:   Filter{Field: "age", Operator: FilterGreater, Values: []string{"30"}},
+			wantSQL:  "age > ?",
+			wantArgs: []interface{}{"30"},
+		},
+		{
+			name:     "less",
+			filter:   Filter{Field: "age", Operator: FilterLess, Values: []string{"30"}},
+			wantSQL:  "age < ?",
+			wantArgs: []interface{}{"30"},
+		},
+		{
+			name:     "between",
+			filter:   Filter{Field: "age", Operator: FilterBetween, Values: []string{"18", "30"}},
+			wantSQL:  "age BETWEEN ? AND ?",
+			wantArgs: []interface{}{"18", "30"},
+		},
+		{
+			name:     "in",
+			filter:   Filter{Field: "status", Operator: FilterIn, Values: []string{"open", "closed", "held"}},
+			wantSQL:  "status IN (?, ?, ?)",
+			wantArgs: []interface{}{"open", "closed", "held"},
+		},
+		{
+			name:      "between with one value",
+			filter:    Filter{Field: "age", Operator: FilterBetween, Values: []string{"18"}},
+			wantError: true,
+		},
+		{
+			name:      "unknown operator",
+			filter:    Filter{Field: "age", Operator: "like", Values: []string{"1"}},
+			wantError: true,
+		},
+		{
+			name:      "no field",
+			filter:    Filter{Values: []string{"1"}},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.filter.SQL(tt.filter.Field)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("SQL() = %q, want an error", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SQL() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("SQL() = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("SQL() args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
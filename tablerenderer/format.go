@@ -0,0 +1,383 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// NumberFormat holds numeric formatting for a column
+type NumberFormat struct {
+	Decimals   int    `json:"decimals"`             // Digits after the decimal point; -1 keeps the shortest representation
+	Thousands  string `json:"thousands,omitempty"`  // Thousands separator, e.g. ","
+	Decimal    string `json:"decimal,omitempty"`    // Decimal separator (default: ".")
+	Scientific bool   `json:"scientific,omitempty"` // Use scientific notation, e.g. 1.23e+06
+}
+
+// BoolFormat holds the text rendered for boolean cells
+type BoolFormat struct {
+	True  string `json:"true"`            // Text for true values
+	False string `json:"false"`           // Text for false values
+	Badge bool   `json:"badge,omitempty"` // Render the text as a badge (class "badge badge-true" or "badge badge-false")
+}
+
+// Built-in boolean formats
+var (
+	BoolCheckmarks = &BoolFormat{True: "✓", False: "✗"}
+	BoolYesNo      = &BoolFormat{True: "Yes", False: "No"}
+	BoolBadges     = &BoolFormat{True: "Yes", False: "No", Badge: true}
+)
+
+// HTML marks a cell value as trusted markup that is rendered as-is. Every
+// other value is HTML-escaped, so only wrap content you control, e.g.
+// tablerenderer.HTML("<strong>Total</strong>").
+type HTML string
+
+// tableRow is a row prepared for the HTML template
+type tableRow struct {
+	Attributes template.HTMLAttr // Trusted attributes of the <tr> element
+	Number     int               // Position of the row across all pages, shown with RowNumbers
+	SelectID   string            // Value of the row's selection checkbox
+	Selected   bool              // Whether the row's selection checkbox is checked
+	Classes    []string          // CSS class of each cell, "" for none
+	SortValues []string          // Client-side sort value of each cell, see ClientSide
+	Actions    template.HTML     // Rendered actions column cell
+	Edit       template.HTML     // Save button of the row in inline edit mode
+	GroupStart template.HTML     // Group header row rendered before the row
+	GroupEnd   template.HTML     // Group subtotal row rendered after the row
+	Cells      []interface{}     // Formatted cell values
+}
+
+// renderRows applies per-column formatting and links to the rows rendered
+// as HTML
+func (r *Renderer) renderRows(headers []string, rows [][]interface{}, columns []Column) []tableRow {
+	configs := columnsFor(headers, columns)
+
+	rendered := make([]tableRow, len(rows))
+	for i, row := range rows {
+		rendered[i] = r.renderRow(headers, configs, i, row)
+	}
+	return rendered
+}
+
+// renderRow formats the cells of the row at index and its row link
+func (r *Renderer) renderRow(headers []string, configs []*Column, index int, row []interface{}) tableRow {
+	view := RowView{Index: index, Headers: headers, Values: row}
+	var rowHref string
+
+	cells := make([]interface{}, len(row))
+	for j, value := range row {
+		var column *Column
+		if j < len(configs) {
+			column = configs[j]
+		}
+		cells[j] = r.formatCell(column, value)
+
+		if column != nil && column.Link != nil && column.Link.URL != "" {
+			href := expandURLTemplate(column.Link.URL, view)
+			cells[j] = linkCell(column.Link, href, cells[j])
+			if column.Link.RowLink && rowHref == "" {
+				rowHref = href
+			}
+		}
+		if column != nil && column.Link == nil && column.DrillDown != nil && !isEmptyValue(value) {
+			if href, ok := r.drillDownURL(column.DrillDown, headers[j], view, value); ok {
+				cells[j] = linkCell(&LinkFormat{Target: column.DrillDown.Target}, href, cells[j])
+			}
+		}
+	}
+
+	rendered := tableRow{Cells: cells}
+	if rowHref != "" {
+		rendered.Attributes = template.HTMLAttr(fmt.Sprintf(`class="row-link" data-href="%s"`, template.HTMLEscapeString(rowHref)))
+	}
+	return rendered
+}
+
+// hasRowLinks reports whether any column makes its rows clickable
+func hasRowLinks(columns []Column) bool {
+	for _, column := range columns {
+		if column.Link != nil && column.Link.RowLink {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCell formats a single value according to its column configuration
+// and truncates the result when the column limits its length or width
+func (r *Renderer) formatCell(column *Column, value interface{}) interface{} {
+	formatted := r.formatValue(column, value)
+	if column != nil && (column.MaxLength > 0 || column.MaxWidth != "") {
+		return truncateCell(column, formatted)
+	}
+	return formatted
+}
+
+// formatValue converts a value to its display form
+func (r *Renderer) formatValue(column *Column, value interface{}) interface{} {
+	if isEmptyValue(value) {
+		return r.placeholder(column)
+	}
+	if renderer, ok := value.(CellRenderer); ok {
+		return template.HTML(renderer.RenderCell())
+	}
+	if value = domainValue(value); isEmptyValue(value) {
+		return r.placeholder(column)
+	}
+	if html, ok := value.(HTML); ok {
+		return template.HTML(html)
+	}
+	if column != nil && column.Badge != nil {
+		if badge, ok := formatBadge(value, column.Badge, column.styleClasses); ok {
+			return badge
+		}
+	}
+	if text, ok := r.formatTime(column, value); ok {
+		return text
+	}
+	if b, ok := value.(bool); ok {
+		return r.formatBool(column, b)
+	}
+	if column == nil {
+		return value
+	}
+	if precision, ok := columnPrecision(column); ok {
+		// Round once with the column's mode so later formatting only pads
+		value, _ = roundValue(column, value)
+		if column.Currency != nil {
+			currency := *column.Currency
+			currency.Decimals = precision
+			if currency.Decimals == 0 {
+				currency.Decimals = -1
+			}
+			if text, ok := formatCurrency(value, &currency); ok {
+				return text
+			}
+		}
+		if text, ok := formatNumber(value, precisionFormat(column)); ok {
+			return text
+		}
+		return value
+	}
+	if column.Currency != nil {
+		if text, ok := formatCurrency(value, column.Currency); ok {
+			return text
+		}
+	}
+	if column.Format != nil {
+		if text, ok := formatNumber(value, column.Format); ok {
+			return text
+		}
+	}
+	return value
+}
+
+// cellHTML returns a formatted value as markup, escaping untrusted values
+func cellHTML(formatted interface{}) string {
+	if html, ok := formatted.(template.HTML); ok {
+		return string(html)
+	}
+	return template.HTMLEscapeString(fmt.Sprint(formatted))
+}
+
+// cssLength matches the CSS lengths accepted for Column.MaxWidth and
+// TableOptions.MaxHeight
+var cssLength = regexp.MustCompile(`^\d+(\.\d+)?(px|em|rem|ch|vh|vw|%)$`)
+
+// truncateCell shortens a formatted value to the column's MaxLength, adding
+// an ellipsis, and constrains it to MaxWidth. The full value is kept in the
+// title attribute. Trusted HTML values are left unchanged.
+func truncateCell(column *Column, formatted interface{}) interface{} {
+	if _, ok := formatted.(template.HTML); ok {
+		return formatted
+	}
+
+	full := fmt.Sprint(formatted)
+	text := full
+	if column.MaxLength > 0 {
+		runes := []rune(full)
+		if len(runes) > column.MaxLength {
+			text = strings.TrimRightFunc(string(runes[:column.MaxLength]), unicode.IsSpace) + "…"
+		}
+	}
+
+	class, style := "cell-truncated", ""
+	if column.MaxWidth != "" && cssLength.MatchString(column.MaxWidth) {
+		if column.styleClasses {
+			class += " " + maxWidthClass(column.MaxWidth)
+		} else {
+			style = fmt.Sprintf(` style="max-width: %s"`, column.MaxWidth)
+		}
+	}
+	if text == full && class == "cell-truncated" && style == "" {
+		return formatted
+	}
+
+	return template.HTML(fmt.Sprintf(`<span class="%s"%s title="%s">%s</span>`,
+		class, style, template.HTMLEscapeString(full), template.HTMLEscapeString(text)))
+}
+
+// isEmptyValue reports whether a value has nothing to display: nil, nil
+// pointers, empty strings and zero times
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case HTML:
+		return v == ""
+	case time.Time:
+		return v.IsZero()
+	case *time.Time:
+		return v == nil || v.IsZero()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// placeholder returns the text rendered for empty values in a column
+func (r *Renderer) placeholder(column *Column) string {
+	if column != nil && column.Placeholder != "" {
+		return column.Placeholder
+	}
+	return r.Placeholder
+}
+
+// formatBool renders a boolean with the column format, falling back to the
+// renderer-wide format and then BoolCheckmarks
+func (r *Renderer) formatBool(column *Column, value bool) interface{} {
+	format := r.BoolFormat
+	if column != nil && column.Bool != nil {
+		format = column.Bool
+	}
+	if format == nil {
+		format = BoolCheckmarks
+	}
+
+	text, class := format.False, "badge-false"
+	if value {
+		text, class = format.True, "badge-true"
+	}
+	if !format.Badge {
+		return text
+	}
+	return template.HTML(fmt.Sprintf(`<span class="badge %s">%s</span>`, class, template.HTMLEscapeString(text)))
+}
+
+// formatTime formats time.Time values with the column layout, falling back
+// to the renderer-wide layout and then RFC3339
+func (r *Renderer) formatTime(column *Column, value interface{}) (string, bool) {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		t = *v
+	default:
+		return "", false
+	}
+
+	layout := r.TimeLayout
+	if column != nil && column.TimeLayout != "" {
+		layout = column.TimeLayout
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.Format(layout), true
+}
+
+// formatNumber formats integer and float values, reporting false for
+// values that are not numeric
+func formatNumber(value interface{}, format *NumberFormat) (string, bool) {
+	v := reflect.ValueOf(value)
+	var text string
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if format.Scientific {
+			text = strconv.FormatFloat(float64(v.Int()), 'e', format.Decimals, 64)
+		} else {
+			text = strconv.FormatInt(v.Int(), 10)
+			if format.Decimals > 0 {
+				text += "." + strings.Repeat("0", format.Decimals)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if format.Scientific {
+			text = strconv.FormatFloat(float64(v.Uint()), 'e', format.Decimals, 64)
+		} else {
+			text = strconv.FormatUint(v.Uint(), 10)
+			if format.Decimals > 0 {
+				text += "." + strings.Repeat("0", format.Decimals)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		verb := byte('f')
+		if format.Scientific {
+			verb = 'e'
+		}
+		text = strconv.FormatFloat(v.Float(), verb, format.Decimals, v.Type().Bits())
+	default:
+		return "", false
+	}
+
+	return localizeNumber(text, format.Thousands, format.Decimal), true
+}
+
+// localizeNumber applies thousands and decimal separators to a number
+// formatted by strconv, leaving any exponent untouched
+func localizeNumber(text string, thousands string, decimal string) string {
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+
+	exponent := ""
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		text, exponent = text[:i], text[i:]
+	}
+
+	integer, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		integer, fraction = text[:i], text[i+1:]
+	}
+
+	if thousands != "" && len(integer) > 3 {
+		var grouped strings.Builder
+		lead := len(integer) % 3
+		if lead > 0 {
+			grouped.WriteString(integer[:lead])
+		}
+		for i := lead; i < len(integer); i += 3 {
+			if grouped.Len() > 0 {
+				grouped.WriteString(thousands)
+			}
+			grouped.WriteString(integer[i : i+3])
+		}
+		integer = grouped.String()
+	}
+
+	if decimal == "" {
+		decimal = "."
+	}
+	if fraction != "" {
+		integer += decimal + fraction
+	}
+	return sign + integer + exponent
+}
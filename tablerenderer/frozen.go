@@ -0,0 +1,28 @@
+package tablerenderer
+
+// frozenColumnsScript offsets each frozen column by the widths of the
+// frozen columns before it, measured on the header row
+const frozenColumnsScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var t=c.querySelector('.data-table');if(!t)return;
+var place=function(){
+var off=[],x=0;
+t.querySelectorAll('thead th.frozen').forEach(function(th,i){off[i]=x;x+=th.getBoundingClientRect().width;});
+t.querySelectorAll('tr').forEach(function(tr){tr.querySelectorAll('.frozen').forEach(function(cell,i){cell.style.insetInlineStart=(off[i]||0)+'px';});});
+};
+place();window.addEventListener('resize',place);
+})();`
+
+// frozenDataColumns returns how many data columns are frozen
+// Without scripts the offsets cannot be measured, so only the first column
+// is frozen and the selection and row number columns scroll away
+func frozenDataColumns(options TableOptions) int {
+	frozen := options.FrozenColumns
+	if frozen > 1 && !scriptsAllowed(options) {
+		frozen = 1
+	}
+	if frozen < 0 {
+		frozen = 0
+	}
+	return frozen
+}
@@ -0,0 +1,47 @@
+package tablerenderer
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// frozenCell matches header and data cells carrying the frozen class
+var frozenCell = regexp.MustCompile(`<t[hd] class="[^"]*\bfrozen\b[^"]*"`)
+
+func TestFrozenColumns(t *testing.T) {
+	tests := []struct {
+		name       string
+		frozen     int
+		policy     JSPolicy
+		wantCells  int
+		wantScript bool
+	}{
+		{"none", 0, JSInline, 0, false},
+		{"two columns", 2, JSInline, 6, true},
+		{"more than the table", 5, JSInline, 9, true},
+		{"first column only without scripts", 2, JSNone, 3, false},
+		{"negative", -1, JSInline, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewRenderer().RenderHTML(DatabasePaginatedData{
+				Headers: []string{"ID", "Name", "Email"},
+				Rows:    [][]interface{}{{1, "Ada", "ada@example.com"}, {2, "Bob", "bob@example.com"}},
+				Options: TableOptions{FrozenColumns: tt.frozen, JSPolicy: tt.policy},
+			})
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			if got := len(frozenCell.FindAllString(html, -1)); got != tt.wantCells {
+				t.Errorf("frozen cells = %d, want %d", got, tt.wantCells)
+			}
+			if got := strings.Contains(html, `class="table-scroll"`); got != (tt.wantCells > 0) {
+				t.Errorf("scroll wrapper rendered = %v, want %v", got, tt.wantCells > 0)
+			}
+			if got := strings.Contains(html, "insetInlineStart"); got != tt.wantScript {
+				t.Errorf("offset script rendered = %v, want %v", got, tt.wantScript)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Time bucket sizes for GroupBy and BucketStart
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week" // Weeks start on Monday
+	BucketMonth = "month"
+)
+
+// GroupBy splits the rows into sections by the value of a column, each with
+// a header row and optional subtotals. Rows must already be sorted by the
+// grouping column, e.g. by the ORDER BY of the page query, since only
+// consecutive rows are grouped.
+type GroupBy struct {
+	Header    string   `json:"header"`              // Header whose value groups the rows
+	Bucket    string   `json:"bucket,omitempty"`    // Group time values by "day", "week" or "month" instead of their exact value
+	Relative  bool     `json:"relative,omitempty"`  // Label recent buckets "Today", "Yesterday", "This week", "Last week", "This month" and "Last month"
+	Subtotals []string `json:"subtotals,omitempty"` // Numeric headers summed per group
+
+	Location *time.Location   `json:"-"` // Time zone buckets are cut in (default: time.Local)
+	Now      func() time.Time `json:"-"` // Current time for relative labels (default: time.Now)
+}
+
+// BucketStart returns the start of the day, week or month t falls in, in
+// t's location. Other bucket sizes return t unchanged.
+func BucketStart(t time.Time, bucket string) time.Time {
+	year, month, day := t.Date()
+	switch bucket {
+	case BucketDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case BucketWeek:
+		// Go weekdays start on Sunday, ISO weeks on Monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case BucketMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+// rowGroup is a run of consecutive rows with the same group value
+type rowGroup struct {
+	Label string // Group header content as HTML
+	Start int    // Index of the first row
+	End   int    // Index after the last row
+}
+
+// groupRows splits the rows into runs of the same group value or time bucket
+func (r *Renderer) groupRows(headers []string, rows [][]interface{}, columns []Column, groupBy *GroupBy) []rowGroup {
+	index := -1
+	for i, header := range headers {
+		if header == groupBy.Header {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+	column := columnsFor(headers, columns)[index]
+
+	location := groupBy.Location
+	if location == nil {
+		location = time.Local
+	}
+	now := time.Now
+	if groupBy.Now != nil {
+		now = groupBy.Now
+	}
+	today := now().In(location)
+
+	var groups []rowGroup
+	var lastKey string
+	for i, row := range rows {
+		var value interface{}
+		if index < len(row) {
+			value = row[index]
+		}
+
+		key := exportValue(value)
+		var label string
+		if t, ok := timeValue(value); ok && groupBy.Bucket != "" && !t.IsZero() {
+			start := BucketStart(t.In(location), groupBy.Bucket)
+			key = start.Format(time.RFC3339)
+			label = template.HTMLEscapeString(r.bucketLabel(start, groupBy, today))
+		} else {
+			label = cellHTML(r.formatCell(column, value))
+		}
+
+		if len(groups) > 0 && key == lastKey {
+			groups[len(groups)-1].End = i + 1
+			continue
+		}
+		groups = append(groups, rowGroup{Label: label, Start: i, End: i + 1})
+		lastKey = key
+	}
+	return groups
+}
+
+// bucketLabel names the bucket starting at start, relative to today when
+// the group asks for it
+func (r *Renderer) bucketLabel(start time.Time, groupBy *GroupBy, today time.Time) string {
+	current := BucketStart(today, groupBy.Bucket)
+	switch groupBy.Bucket {
+	case BucketDay:
+		if groupBy.Relative {
+			switch {
+			case start.Equal(current):
+				return r.translate(LabelToday, 1)
+			case start.Equal(current.AddDate(0, 0, -1)):
+				return r.translate(LabelYesterday, 1)
+			}
+		}
+		return start.Format("2006-01-02")
+	case BucketWeek:
+		if groupBy.Relative {
+			switch {
+			case start.Equal(current):
+				return r.translate(LabelThisWeek, 1)
+			case start.Equal(current.AddDate(0, 0, -7)):
+				return r.translate(LabelLastWeek, 1)
+			}
+		}
+		return r.translate(LabelWeekOf, 1, start.Format("2006-01-02"))
+	case BucketMonth:
+		if groupBy.Relative {
+			switch {
+			case start.Equal(current):
+				return r.translate(LabelThisMonth, 1)
+			case start.Equal(current.AddDate(0, -1, 0)):
+				return r.translate(LabelLastMonth, 1)
+			}
+		}
+		return start.Format("2006-01")
+	}
+	return start.Format("2006-01-02")
+}
+
+// applyGroups adds the group header and subtotal rows around the rendered
+// rows; leading and trailing count the columns before and after the data
+// columns, such as row numbers and actions
+func (r *Renderer) applyGroups(rendered []tableRow, headers []string, rows [][]interface{}, columns []Column, groupBy *GroupBy, leading int, trailing int) {
+	subtotals := make(map[string]bool, len(groupBy.Subtotals))
+	for _, header := range groupBy.Subtotals {
+		subtotals[header] = true
+	}
+	configs := columnsFor(headers, columns)
+	colspan := leading + len(headers) + trailing
+
+	for _, group := range r.groupRows(headers, rows, columns, groupBy) {
+		rendered[group.Start].GroupStart = template.HTML(fmt.Sprintf(
+			`<tr class="group-header"><th colspan="%d" scope="rowgroup">%s <span class="badge badge-gray">%d</span></th></tr>`,
+			colspan, group.Label, group.End-group.Start))
+
+		if len(subtotals) == 0 {
+			continue
+		}
+		var html strings.Builder
+		html.WriteString(`<tr class="group-subtotal">`)
+		html.WriteString(strings.Repeat(`<td></td>`, leading))
+		for j, header := range headers {
+			td := `<td>`
+			if class := columnClass(configs[j]); class != "" {
+				td = fmt.Sprintf(`<td class="%s">`, class)
+			}
+			switch {
+			case subtotals[header]:
+				html.WriteString(fmt.Sprintf(`%s%s</td>`, td, cellHTML(r.formatValue(configs[j], sumColumn(rows[group.Start:group.End], j, configs[j])))))
+			case j == 0:
+				html.WriteString(fmt.Sprintf(`%s%s</td>`, td, template.HTMLEscapeString(r.translate(LabelSubtotal, 1))))
+			default:
+				html.WriteString(td + `</td>`)
+			}
+		}
+		html.WriteString(strings.Repeat(`<td></td>`, trailing))
+		html.WriteString(`</tr>`)
+		rendered[group.End-1].GroupEnd = template.HTML(html.String())
+	}
+}
+
+// sumColumn adds up the numeric values of a column, keeping integer sums as
+// int64 so they are formatted without decimals. Values are rounded to the
+// column's precision first, so the subtotal matches the displayed cells.
+func sumColumn(rows [][]interface{}, index int, column *Column) interface{} {
+	var sum float64
+	integers := true
+	for _, row := range rows {
+		if index >= len(row) {
+			continue
+		}
+		value, _ := roundValue(column, row[index])
+		number, ok := numericValue(value)
+		if !ok {
+			continue
+		}
+		sum += number
+		if kind := reflect.ValueOf(row[index]).Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+			integers = false
+		}
+	}
+	if integers {
+		return int64(sum)
+	}
+	return sum
+}
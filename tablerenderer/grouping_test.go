@@ -0,0 +1,75 @@
+package tablerenderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSumColumn(t *testing.T) {
+	precision := func(n int) *int { return &n }
+	tests := []struct {
+		name   string
+		rows   [][]interface{}
+		column *Column
+		want   interface{}
+	}{
+		{"integers", [][]interface{}{{1}, {2}, {int64(3)}}, nil, int64(6)},
+		{"floats", [][]interface{}{{1}, {2.5}}, nil, 3.5},
+		{"values rounded before summing", [][]interface{}{{0.005}, {0.005}}, &Column{Precision: precision(2)}, 0.02},
+		{"half even rounding", [][]interface{}{{0.125}, {0.125}}, &Column{Precision: precision(2), Rounding: RoundHalfEven}, 0.24},
+		{"text and missing cells skipped", [][]interface{}{{"n/a"}, {}, {nil}, {4}}, nil, int64(4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sumColumn(tt.rows, 0, tt.column); got != tt.want {
+				t.Errorf("sumColumn() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupSubtotalsMatchCells(t *testing.T) {
+	precision := 2
+	html, err := NewRenderer().RenderHTML(DatabasePaginatedData{
+		Headers: []string{"Team", "Amount"},
+		Rows:    [][]interface{}{{"a", 0.005}, {"a", 0.005}},
+		Options: TableOptions{
+			Columns: []Column{{Header: "Amount", Precision: &precision}},
+			GroupBy: &GroupBy{Header: "Team", Subtotals: []string{"Amount"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	start := strings.Index(html, `class="group-subtotal"`)
+	if start < 0 {
+		t.Fatalf("no subtotal row in:\n%s", html)
+	}
+	if subtotal := html[start:]; !strings.Contains(subtotal, "0.02") {
+		t.Errorf("subtotal of two 0.01 cells is not 0.02:\n%s", subtotal)
+	}
+}
+
+func TestBucketLabelGerman(t *testing.T) {
+	r := NewRenderer()
+	r.Translator = LocaleGerman
+	today := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	groupBy := &GroupBy{Bucket: BucketMonth, Relative: true}
+	tests := []struct {
+		name  string
+		start time.Time
+		want  string
+	}{
+		{"this month", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "Dieser Monat"},
+		{"last month", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), "Letzter Monat"},
+		{"earlier", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.bucketLabel(tt.start, groupBy, today); got != tt.want {
+				t.Errorf("bucketLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
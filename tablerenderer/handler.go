@@ -0,0 +1,161 @@
+package tablerenderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// handlerPageSize is the page size TableHandler uses without a page_size
+// parameter
+const handlerPageSize = 25
+
+// TableHandler serves the table of definition with rows from source. The
+// page, page_size, sort_by, sort_order, nulls and search parameters select
+// the rows. Responses are HTML by default, the Resolve result as JSON with
+// format=json or Accept: application/json, and an export of every matching
+// row with format=csv, format=tsv, format=jsonl or format=xlsx. format=print
+// serves the print view of every matching row. The table is configured by
+// definition.Options, or is paginated, sortable and searchable without them;
+// links default to definition.BaseURL.
+func (r *Renderer) TableHandler(definition TableDefinition, source DataSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var options TableOptions
+		if definition.Options != nil {
+			options = *definition.Options
+		} else {
+			options = CreatePaginatedDataWithSortingAndSearch(nil, 0, "", "", handlerPageSize, true, true, "").Options
+		}
+		options = requestOptions(req, options, definition.BaseURL)
+		if options.ID == "" {
+			options.ID = definition.Name
+		}
+		if options.ExportLinks == nil {
+			options.ExportLinks = &ExportLinks{BaseURL: definition.BaseURL}
+		}
+
+		r.serveTable(w, req, source, options, definition.Name)
+	})
+}
+
+// NewTableHandler serves a table with rows from provider, rendered by a
+// default Renderer. See Renderer.Handler.
+func NewTableHandler(provider DataSource, opts TableOptions) http.Handler {
+	return NewRenderer().Handler(provider, opts)
+}
+
+// Handler serves a table with rows from provider. opts configures the table
+// and which of pagination, sorting and search are enabled; the page, page
+// size, sort and search term of each request are read from its query with
+// the configured parameter names. Responses are formatted as by
+// TableHandler. Links default to the request path.
+func (r *Renderer) Handler(provider DataSource, opts TableOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.serveTable(w, req, provider, requestOptions(req, opts, req.URL.Path), opts.ID)
+	})
+}
+
+// requestOptions returns a copy of opts with the page, page size, sort and
+// search term of the request. Links without a base URL default to baseURL.
+func requestOptions(req *http.Request, opts TableOptions, baseURL string) TableOptions {
+	var defaults TableState
+	if opts.Pagination != nil {
+		defaults.PageSize = opts.Pagination.PageSize
+		if defaults.PageSize <= 0 {
+			defaults.PageSize = handlerPageSize
+		}
+	}
+	ParseStateValues(req.URL.Query(), stateParams(opts), defaults).Apply(&opts)
+
+	if opts.Pagination != nil && opts.Pagination.BaseURL == "" {
+		opts.Pagination.BaseURL = baseURL
+	}
+	if opts.Sorting != nil && opts.Sorting.BaseURL == "" {
+		opts.Sorting.BaseURL = baseURL
+	}
+	if opts.Search != nil && opts.Search.BaseURL == "" {
+		opts.Search.BaseURL = baseURL
+	}
+	return opts
+}
+
+// serveTable loads the rows selected by options and writes them in the
+// format the request asks for. name names export attachments.
+func (r *Renderer) serveTable(w http.ResponseWriter, req *http.Request, source DataSource, options TableOptions, name string) {
+	format := req.URL.Query().Get("format")
+	if format == "csv" || format == "tsv" || format == "jsonl" || format == "xlsx" || format == ExportPrint {
+		// Exports cover every matching row, not the current page
+		options.Pagination = nil
+		options.Print = format == ExportPrint
+	}
+	// Filters from the query are ignored unless their feature is enabled
+	r.dropDisabledFeatures(&options)
+
+	data, err := source.Load(req.Context(), options)
+	if err == nil {
+		err = r.loadFacets(req.Context(), source, &data.Options)
+	}
+	if err != nil {
+		http.Error(w, "failed to load table", http.StatusInternalServerError)
+		return
+	}
+	if data.Options.Pagination != nil && data.Options.Pagination.TotalCount == 0 {
+		pagination := *data.Options.Pagination
+		pagination.TotalCount = data.TotalCount
+		data.Options.Pagination = &pagination
+	}
+
+	switch {
+	case format == "csv" || format == "tsv" || format == "jsonl" || format == "xlsx":
+		r.serveExport(w, name, data, format)
+	case format == "json" || (format == "" && acceptsJSON(req.Header.Get("Accept"))):
+		resolved, err := r.Resolve(data, data.Options)
+		if err != nil {
+			http.Error(w, "failed to resolve table", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resolved)
+	default:
+		html, err := r.RenderHTML(data)
+		if err != nil {
+			http.Error(w, "failed to render table", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, html)
+	}
+}
+
+// serveExport writes data as a CSV, TSV, JSONL or XLSX attachment named after
+// the table
+func (r *Renderer) serveExport(w http.ResponseWriter, name string, data DatabasePaginatedData, format string) {
+	table := TableData{Headers: data.Headers, Rows: data.Rows, Data: data.Data, Options: data.Options}
+
+	if name == "" {
+		name = "table"
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + format}))
+
+	var err error
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = r.ExportCSV(w, table, ExportOptions{})
+	case "tsv":
+		// Tab-separated values paste into spreadsheets as cells
+		w.Header().Set("Content-Type", "text/tab-separated-values; charset=utf-8")
+		err = r.ExportCSV(w, table, ExportOptions{Delimiter: '\t'})
+	case "xlsx":
+		w.Header().Set("Content-Type", xlsxContentType)
+		err = r.ExportXLSX(w, table, ExportOptions{})
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		err = r.ExportJSONL(w, table, ExportOptions{})
+	}
+	if err != nil {
+		// Only failures before the first write still change the status
+		http.Error(w, "failed to export table", http.StatusInternalServerError)
+	}
+}
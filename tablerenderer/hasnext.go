@@ -0,0 +1,68 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// TrimPage trims rows fetched with a limit of the page size plus one to the
+// page and sets HasNext when the extra row came back, for sources paging
+// with Pagination.SkipCount instead of counting rows
+func TrimPage(rows [][]interface{}, pagination Pagination) ([][]interface{}, Pagination) {
+	pagination.HasNext = pagination.PageSize > 0 && len(rows) > pagination.PageSize
+	if pagination.HasNext {
+		rows = rows[:pagination.PageSize]
+	}
+	return rows, pagination
+}
+
+// uncountedPagination calculates the pagination of a source that reports
+// whether a next page exists instead of a total count. The totals cover the
+// pages up to the current one, plus the next when there is one.
+func uncountedPagination(currentPageDataCount int, pagination *Pagination) PaginationInfo {
+	currentPage := max(pagination.CurrentPage, 1)
+	totalPages := currentPage
+	if pagination.HasNext {
+		totalPages++
+	}
+	startRow := (currentPage-1)*pagination.PageSize + 1
+	return PaginationInfo{
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+		TotalRows:   startRow - 1 + currentPageDataCount,
+		PageSize:    pagination.PageSize,
+		StartRow:    startRow,
+		EndRow:      startRow - 1 + currentPageDataCount,
+		Uncounted:   true,
+	}
+}
+
+// generatePrevNextHTML generates Previous and Next controls linking to the
+// given URLs, disabled where a URL is empty
+func (r *Renderer) generatePrevNextHTML(class string, previousURL string, nextURL string, frame string) string {
+	if previousURL == "" && nextURL == "" {
+		return ""
+	}
+
+	previousLabel := template.HTMLEscapeString(r.translate(LabelPrevious, 1))
+	nextLabel := template.HTMLEscapeString(r.translate(LabelNext, 1))
+	frameAttr := turboFrameAttr(frame)
+
+	var html strings.Builder
+	html.WriteString(fmt.Sprintf(`<ul class="pagination %s">`, class))
+	if previousURL != "" {
+		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" rel="prev" href="%s"%s>%s</a></li>`,
+			template.HTMLEscapeString(previousURL), frameAttr, previousLabel))
+	} else {
+		html.WriteString(fmt.Sprintf(`<li class="page-item disabled"><span class="page-link">%s</span></li>`, previousLabel))
+	}
+	if nextURL != "" {
+		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" rel="next" href="%s"%s>%s</a></li>`,
+			template.HTMLEscapeString(nextURL), frameAttr, nextLabel))
+	} else {
+		html.WriteString(fmt.Sprintf(`<li class="page-item disabled"><span class="page-link">%s</span></li>`, nextLabel))
+	}
+	html.WriteString(`</ul>`)
+	return html.String()
+}
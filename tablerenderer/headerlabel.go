@@ -0,0 +1,87 @@
+package tablerenderer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// headerLabel returns the text shown for a header: the column's Label when
+// set, otherwise the header itself
+func headerLabel(column *Column, header string) string {
+	if column != nil && column.Label != "" {
+		return column.Label
+	}
+	return header
+}
+
+// headerLabels returns the text shown for each header
+func headerLabels(headers []string, columns []Column) []string {
+	configs := columnsFor(headers, columns)
+	labels := make([]string, len(headers))
+	for i, header := range headers {
+		labels[i] = headerLabel(configs[i], header)
+	}
+	return labels
+}
+
+// labelStructHeaders gives headers taken from struct fields humanized
+// labels, e.g. "Created At" rather than "CreatedAt" or "created_at", unless
+// options.RawHeaders is set
+func labelStructHeaders(headers []string, data interface{}, explicitHeaders bool, options *TableOptions) {
+	if _, isMaps := mapSlice(data); data == nil || isMaps || explicitHeaders || options.RawHeaders {
+		return
+	}
+	options.Columns = withHumanLabels(headers, options.Columns)
+}
+
+// withHumanLabels returns a copy of columns in which every header without a
+// Label gets its humanized form as label
+func withHumanLabels(headers []string, columns []Column) []Column {
+	labeled := append([]Column(nil), columns...)
+	configs := columnsFor(headers, labeled)
+	var added []Column
+	for i, header := range headers {
+		switch {
+		case configs[i] == nil:
+			added = append(added, Column{Header: header, Label: HumanizeHeader(header)})
+		case configs[i].Label == "":
+			configs[i].Label = HumanizeHeader(header)
+		}
+	}
+	return append(labeled, added...)
+}
+
+// HumanizeHeader turns a field or key name into a header label, splitting
+// camel case and underscores into capitalized words: "CreatedAt" and
+// "created_at" become "Created At", "HTTPStatus" "HTTP Status" and the
+// nested "Address.City" "Address City"
+func HumanizeHeader(name string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			word[0] = unicode.ToUpper(word[0])
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, c := range runes {
+		if c == '_' || c == '-' || c == '.' || unicode.IsSpace(c) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(c) && len(word) > 0 {
+			previous := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Break before "At" in "createdAt" and before "Status" in "HTTPStatus"
+			if !unicode.IsUpper(previous) || nextLower {
+				flush()
+			}
+		}
+		word = append(word, c)
+	}
+	flush()
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,80 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// HeadLinks are the URLs of the current table page for the <head> of a
+// listing page: its canonical URL and the previous and next pages, so
+// crawlers follow the pagination. URLs are relative when the base URLs are.
+type HeadLinks struct {
+	Canonical string `json:"canonical,omitempty"` // Current page with its sort, search and filters
+	Previous  string `json:"previous,omitempty"`  // Previous page, empty on the first page
+	Next      string `json:"next,omitempty"`      // Next page, empty on the last page
+}
+
+// HeadLinks returns the canonical, previous and next page URLs of data as
+// RenderHTML would link them
+func (r *Renderer) HeadLinks(data DatabasePaginatedData) (HeadLinks, error) {
+	options := data.Options
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &options)
+	if err != nil {
+		return HeadLinks{}, err
+	}
+	headers, rows, _ = extractSource(headers, rows, options.Source)
+	paginationInfo := r.calculatePagination(len(rows), options.Pagination)
+
+	links := r.generateLinks(headers, options, paginationInfo)
+	head := HeadLinks{
+		Canonical: r.canonicalURL(options, paginationInfo),
+		Previous:  links.Previous,
+		Next:      links.Next,
+	}
+	// Point back to the first page by its canonical URL, without the page
+	if head.Previous != "" && !options.Pagination.Cursor && paginationInfo.CurrentPage == 2 {
+		first := paginationInfo
+		first.CurrentPage = 1
+		head.Previous = r.canonicalURL(options, first)
+	}
+	return head, nil
+}
+
+// canonicalURL returns the URL of the current page: the pagination links'
+// parameters with the page number, left out on the first page, or the
+// cursor of the page
+func (r *Renderer) canonicalURL(options TableOptions, paginationInfo PaginationInfo) string {
+	pagination := options.Pagination
+	if pagination == nil || !pagination.Enabled {
+		return facetBaseURL(options)
+	}
+	queryParam := pagination.QueryParam
+	if queryParam == "" {
+		queryParam = "page"
+	}
+
+	params := queryValues(r.paginationLinkParams(options, paginationInfo))
+	params.Del(queryParam)
+	switch {
+	case pagination.Cursor && pagination.After != "":
+		params.Set("after", pagination.After)
+	case pagination.Cursor && pagination.Before != "":
+		params.Set("before", pagination.Before)
+	case !pagination.Cursor && paginationInfo.CurrentPage > 1:
+		params.Set(queryParam, strconv.Itoa(paginationInfo.CurrentPage))
+	}
+	return linkURL(pagination.BaseURL, params)
+}
+
+// HTML renders the links as <link> tags for the document head
+func (l HeadLinks) HTML() template.HTML {
+	var html strings.Builder
+	for _, link := range []struct{ rel, href string }{{"canonical", l.Canonical}, {"prev", l.Previous}, {"next", l.Next}} {
+		if link.href != "" {
+			html.WriteString(fmt.Sprintf(`<link rel="%s" href="%s">`+"\n", link.rel, template.HTMLEscapeString(link.href)))
+		}
+	}
+	return template.HTML(html.String())
+}
@@ -0,0 +1,98 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// searchHighlighter returns the pattern matching the active search term, or
+// nil when highlighting is off or no term long enough is set
+func searchHighlighter(search *Search) *regexp.Regexp {
+	if search == nil || !search.Enabled || !search.Highlight {
+		return nil
+	}
+	term := strings.TrimSpace(search.SearchTerm)
+	if term == "" || len([]rune(term)) < search.MinLength {
+		return nil
+	}
+	pattern := regexp.QuoteMeta(term)
+	if !search.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.MustCompile(pattern)
+}
+
+// highlightSearch wraps the matches of the search term in the cells of the
+// searched columns with <mark>
+func highlightSearch(rows []tableRow, headers []string, search *Search) {
+	pattern := searchHighlighter(search)
+	if pattern == nil {
+		return
+	}
+	searched := make(map[string]bool, len(search.SearchColumns))
+	for _, column := range search.SearchColumns {
+		searched[column] = true
+	}
+
+	for i := range rows {
+		for j, cell := range rows[i].Cells {
+			if len(searched) > 0 && (j >= len(headers) || !searched[headers[j]]) {
+				continue
+			}
+			rows[i].Cells[j] = highlightCell(pattern, cell)
+		}
+	}
+}
+
+// highlightCell marks the matches in a formatted cell. Plain values are
+// escaped; in trusted HTML only the text between tags is searched, so
+// markup and attributes are left intact.
+func highlightCell(pattern *regexp.Regexp, cell interface{}) interface{} {
+	markup, trusted := cell.(template.HTML)
+	if !trusted {
+		text := fmt.Sprint(cell)
+		if !pattern.MatchString(text) {
+			return cell
+		}
+		return template.HTML(markMatches(pattern, text))
+	}
+
+	var out strings.Builder
+	rest := string(markup)
+	for rest != "" {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			start = len(rest)
+		}
+		out.WriteString(markMatches(pattern, html.UnescapeString(rest[:start])))
+		rest = rest[start:]
+		if rest == "" {
+			break
+		}
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			end = len(rest) - 1
+		}
+		out.WriteString(rest[:end+1])
+		rest = rest[end+1:]
+	}
+	return template.HTML(out.String())
+}
+
+// markMatches escapes text and wraps the matches of pattern with <mark>
+func markMatches(pattern *regexp.Regexp, text string) string {
+	var out strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		out.WriteString(template.HTMLEscapeString(text[last:match[0]]))
+		out.WriteString("<mark>")
+		out.WriteString(template.HTMLEscapeString(text[match[0]:match[1]]))
+		out.WriteString("</mark>")
+		last = match[1]
+	}
+	out.WriteString(template.HTMLEscapeString(text[last:]))
+	return out.String()
+}
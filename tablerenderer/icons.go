@@ -0,0 +1,114 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// IconSet renders the icons of the table controls
+type IconSet interface {
+	// Icon returns the markup of the named icon, e.g. IconSearch. Icons are
+	// decorative; the controls carry their own accessible names.
+	Icon(name string) template.HTML
+}
+
+// Icon names
+const (
+	IconSearch   = "search"    // Search button
+	IconClear    = "clear"     // Link clearing the search
+	IconSortAsc  = "sort-asc"  // Column sorted ascending
+	IconSortDesc = "sort-desc" // Column sorted descending
+	IconSortable = "sortable"  // Sortable column that is not sorted
+	IconKeyboard = "keyboard"  // Keyboard shortcuts help button
+)
+
+// TextIcons is an IconSet of plain text glyphs by icon name
+type TextIcons map[string]string
+
+// Icon implements IconSet
+func (t TextIcons) Icon(name string) template.HTML {
+	text, ok := t[name]
+	if !ok {
+		text = IconsText[name]
+	}
+	return template.HTML(template.HTMLEscapeString(text))
+}
+
+// FontIcons is an IconSet of icon font classes by icon name, rendered as
+// <i> elements. The page must load the font's stylesheet.
+type FontIcons map[string]string
+
+// Icon implements IconSet
+func (f FontIcons) Icon(name string) template.HTML {
+	class, ok := f[name]
+	if !ok {
+		return IconsText.Icon(name)
+	}
+	return template.HTML(fmt.Sprintf(`<i class="%s" aria-hidden="true"></i>`, template.HTMLEscapeString(class)))
+}
+
+// SVGIcons is an IconSet of the contents of 16x16 inline SVG icons by icon
+// name. The icons are stroked in the current text color and sized to the
+// text, so they need no stylesheet.
+type SVGIcons map[string]template.HTML
+
+// Icon implements IconSet
+func (s SVGIcons) Icon(name string) template.HTML {
+	content, ok := s[name]
+	if !ok {
+		return IconsText.Icon(name)
+	}
+	return `<svg class="icon" viewBox="0 0 16 16" width="1em" height="1em" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" aria-hidden="true" focusable="false">` +
+		content + `</svg>`
+}
+
+// Built-in icon sets
+var (
+	// IconsText uses Unicode glyphs and needs no assets (default)
+	IconsText = TextIcons{
+		IconSearch:   "🔍",
+		IconClear:    "×",
+		IconSortAsc:  "▲",
+		IconSortDesc: "▼",
+		IconSortable: "⬍",
+		IconKeyboard: "⌨",
+	}
+
+	// IconsFontAwesome uses Font Awesome 6 classes
+	IconsFontAwesome = FontIcons{
+		IconSearch:   "fa-solid fa-magnifying-glass",
+		IconClear:    "fa-solid fa-xmark",
+		IconSortAsc:  "fa-solid fa-sort-up",
+		IconSortDesc: "fa-solid fa-sort-down",
+		IconSortable: "fa-solid fa-sort",
+		IconKeyboard: "fa-regular fa-keyboard",
+	}
+
+	// IconsBootstrap uses Bootstrap Icons classes
+	IconsBootstrap = FontIcons{
+		IconSearch:   "bi bi-search",
+		IconClear:    "bi bi-x-lg",
+		IconSortAsc:  "bi bi-caret-up-fill",
+		IconSortDesc: "bi bi-caret-down-fill",
+		IconSortable: "bi bi-chevron-expand",
+		IconKeyboard: "bi bi-keyboard",
+	}
+
+	// IconsSVG uses inline SVG icons
+	IconsSVG = SVGIcons{
+		IconSearch:   `<circle cx="7" cy="7" r="4.5"/><path d="M10.5 10.5 14 14"/>`,
+		IconClear:    `<path d="M4 4l8 8M12 4l-8 8"/>`,
+		IconSortAsc:  `<path d="M4 10l4-4 4 4"/>`,
+		IconSortDesc: `<path d="M4 6l4 4 4-4"/>`,
+		IconSortable: `<path d="M5 6l3-3 3 3M5 10l3 3 3-3"/>`,
+		IconKeyboard: `<rect x="1.5" y="4" width="13" height="8" rx="1.5"/><path d="M4.5 7h.01M8 7h.01M11.5 7h.01M5 9.5h6"/>`,
+	}
+)
+
+// icon renders the named icon of the renderer's icon set
+func (r *Renderer) icon(name string) template.HTML {
+	if r.Icons == nil {
+		return IconsText.Icon(name)
+	}
+	return r.Icons.Icon(name)
+}
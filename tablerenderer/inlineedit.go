@@ -0,0 +1,227 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InlineEdit holds configuration for editing rows in place, e.g. for quick
+// admin screens. Cells of the editable columns render as inputs, chosen by
+// Column.Editor or the type of the value, belonging to a form per row with
+// a Save button, or to one form saving every row. Forms render before the
+// table and inputs join them through their form attribute, so no scripts
+// are needed. Checkbox editors submit "true" followed by a hidden "false",
+// so handlers read the first value of a field, as http.Request.FormValue
+// does.
+type InlineEdit struct {
+	Enabled  bool              `json:"enabled"`
+	SaveURL  string            `json:"save_url"`            // URL template with {Header} placeholders filled from the row, e.g. "/users/{ID}"; used as is with Table
+	Method   string            `json:"method,omitempty"`    // HTTP method; other than POST adds a "_method" field (default: "POST")
+	Columns  []string          `json:"columns,omitempty"`   // Headers of the editable columns (default: all)
+	Table    bool              `json:"table,omitempty"`     // Save every row with one form, inputs named "Header[ID]", instead of a form per row
+	IDColumn string            `json:"id_column,omitempty"` // Header identifying rows in input names with Table (default: the row number)
+	Hidden   map[string]string `json:"hidden,omitempty"`    // Hidden fields added to the forms, e.g. a CSRF token
+}
+
+// CellEditor configures the input of a column in inline edit mode
+type CellEditor struct {
+	Type     string   `json:"type,omitempty"`     // "text", "number", "date", "datetime-local", "checkbox", "select", "textarea" or another input type (default: from the value)
+	Options  []string `json:"options,omitempty"`  // Choices of a "select" editor
+	Required bool     `json:"required,omitempty"` // Whether the input must be filled
+}
+
+// Input value layouts of date and time editors
+const (
+	editorDateLayout     = "2006-01-02"
+	editorDateTimeLayout = "2006-01-02T15:04"
+)
+
+// editable reports whether the column of header is edited inline
+func (e *InlineEdit) editable(header string) bool {
+	return len(e.Columns) == 0 || slices.Contains(e.Columns, header)
+}
+
+// inlineEditFormID returns the ID of the edit form of the row with number,
+// or of the table's form with Table
+func inlineEditFormID(edit *InlineEdit, tableID string, number int) string {
+	if tableID == "" {
+		tableID = "table"
+	}
+	if edit.Table {
+		return tableID + "-edit"
+	}
+	return tableID + "-edit-" + strconv.Itoa(number)
+}
+
+// inlineEditRowID returns the ID of a row in the input names of Table mode
+func inlineEditRowID(edit *InlineEdit, row RowView, number int) string {
+	if edit.IDColumn != "" {
+		return exportValue(row.Get(edit.IDColumn))
+	}
+	return strconv.Itoa(number)
+}
+
+// editorType returns the input type of a column, from its editor or else
+// from the type of value
+func editorType(column *Column, value interface{}) string {
+	if column != nil && column.Editor != nil && column.Editor.Type != "" {
+		return column.Editor.Type
+	}
+	switch domainValue(value).(type) {
+	case bool:
+		return "checkbox"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return "number"
+	case time.Time, *time.Time:
+		return "datetime-local"
+	}
+	return "text"
+}
+
+// editorValue returns value in the format of an input of the given type
+func editorValue(inputType string, value interface{}) string {
+	value = domainValue(value)
+	if t, ok := value.(*time.Time); ok && t != nil {
+		value = *t
+	}
+	if t, ok := value.(time.Time); ok && !t.IsZero() {
+		switch inputType {
+		case "date":
+			return t.Format(editorDateLayout)
+		case "datetime-local":
+			return t.Format(editorDateTimeLayout)
+		}
+	}
+	return exportValue(value)
+}
+
+// generateEditorHTML renders the input of a cell, named name and joined to
+// the form with formID
+func generateEditorHTML(column *Column, header string, value interface{}, name string, formID string) template.HTML {
+	inputType := editorType(column, value)
+	current := editorValue(inputType, value)
+	var editor CellEditor
+	if column != nil && column.Editor != nil {
+		editor = *column.Editor
+	}
+
+	attrs := fmt.Sprintf(`name="%s" form="%s" aria-label="%s"`,
+		template.HTMLEscapeString(name), template.HTMLEscapeString(formID), template.HTMLEscapeString(header))
+	if editor.Required {
+		attrs += " required"
+	}
+
+	switch inputType {
+	case "checkbox":
+		// Unchecked boxes submit nothing, so a hidden field after the box
+		// sends false; checked boxes submit "true" first
+		checked := ""
+		if strings.EqualFold(current, "true") {
+			checked = " checked"
+		}
+		return template.HTML(fmt.Sprintf(`<input type="checkbox" class="cell-editor" value="true" %s%s><input type="hidden" name="%s" value="false" form="%s">`,
+			attrs, checked, template.HTMLEscapeString(name), template.HTMLEscapeString(formID)))
+	case "select":
+		var html strings.Builder
+		html.WriteString(fmt.Sprintf(`<select class="cell-editor" %s>`, attrs))
+		options := editor.Options
+		if !slices.Contains(options, current) {
+			// Keep a value outside the choices rather than change it on save
+			options = append([]string{current}, options...)
+		}
+		for _, option := range options {
+			selected := ""
+			if option == current {
+				selected = " selected"
+			}
+			html.WriteString(fmt.Sprintf(`<option value="%s"%s>%s</option>`,
+				template.HTMLEscapeString(option), selected, template.HTMLEscapeString(option)))
+		}
+		html.WriteString(`</select>`)
+		return template.HTML(html.String())
+	case "textarea":
+		return template.HTML(fmt.Sprintf(`<textarea class="cell-editor" rows="2" %s>%s</textarea>`, attrs, template.HTMLEscapeString(current)))
+	case "number":
+		attrs += ` step="any"`
+	}
+	return template.HTML(fmt.Sprintf(`<input type="%s" class="cell-editor" value="%s" %s>`,
+		template.HTMLEscapeString(inputType), template.HTMLEscapeString(current), attrs))
+}
+
+// applyInlineEdit replaces the cells of the editable columns with inputs
+// and adds the Save button of each row outside Table mode
+func (r *Renderer) applyInlineEdit(rendered []tableRow, headers []string, rows [][]interface{}, options TableOptions) {
+	edit := options.InlineEdit
+	configs := columnsFor(headers, options.Columns)
+	saveLabel := template.HTMLEscapeString(r.translate(LabelSave, 1))
+	for i := range rendered {
+		view := RowView{Index: i, Headers: headers, Values: rows[i]}
+		formID := inlineEditFormID(edit, options.ID, rendered[i].Number)
+		rowID := inlineEditRowID(edit, view, rendered[i].Number)
+		for j, header := range headers {
+			if j >= len(rows[i]) || !edit.editable(header) {
+				continue
+			}
+			name := header
+			if edit.Table {
+				name = header + "[" + rowID + "]"
+			}
+			rendered[i].Cells[j] = generateEditorHTML(configs[j], header, rows[i][j], name, formID)
+		}
+		if !edit.Table {
+			rendered[i].Edit = template.HTML(fmt.Sprintf(`<button type="submit" class="edit-save" form="%s">%s</button>`,
+				template.HTMLEscapeString(formID), saveLabel))
+		}
+	}
+}
+
+// generateInlineEditFormsHTML renders the forms the editors belong to: one
+// per row, or one for the table with its Save button
+func (r *Renderer) generateInlineEditFormsHTML(rendered []tableRow, headers []string, rows [][]interface{}, options TableOptions) string {
+	edit := options.InlineEdit
+	method := strings.ToUpper(edit.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	names := make([]string, 0, len(edit.Hidden))
+	for name := range edit.Hidden {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var html strings.Builder
+	form := func(formID, action, class, content string) {
+		html.WriteString(fmt.Sprintf(`<form id="%s" method="POST" action="%s" class="%s">`,
+			template.HTMLEscapeString(formID), template.HTMLEscapeString(action), class))
+		// Forms only submit GET and POST, so other methods are sent as POST
+		// with a method override field
+		if method != http.MethodPost {
+			html.WriteString(fmt.Sprintf(`<input type="hidden" name="_method" value="%s">`, template.HTMLEscapeString(method)))
+		}
+		for _, name := range names {
+			html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+				template.HTMLEscapeString(name), template.HTMLEscapeString(edit.Hidden[name])))
+		}
+		html.WriteString(content)
+		html.WriteString(`</form>`)
+	}
+
+	if edit.Table {
+		if len(rendered) > 0 {
+			form(inlineEditFormID(edit, options.ID, 0), safeURL(edit.SaveURL), "inline-edit-form inline-edit-table", fmt.Sprintf(`<button type="submit" class="edit-save">%s</button>`,
+				template.HTMLEscapeString(r.translate(LabelSave, 1))))
+		}
+		return html.String()
+	}
+	for i := range rendered {
+		view := RowView{Index: i, Headers: headers, Values: rows[i]}
+		form(inlineEditFormID(edit, options.ID, rendered[i].Number), expandURLTemplate(edit.SaveURL, view), "inline-edit-form", "")
+	}
+	return html.String()
+}
@@ -0,0 +1,111 @@
+package tablerenderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInlineEdit(t *testing.T) {
+	headers := []string{"ID", "Name", "Active"}
+	rows := [][]interface{}{{1, "Ada", true}, {2, "Bob", false}}
+	tests := []struct {
+		name    string
+		edit    InlineEdit
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "form per row",
+			edit: InlineEdit{Enabled: true, SaveURL: "/users/{ID}", Method: "patch", Columns: []string{"Name", "Active"}, Hidden: map[string]string{"csrf": "token"}},
+			want: []string{
+				`<form id="users-edit-2" method="POST" action="/users/2" class="inline-edit-form"><input type="hidden" name="_method" value="PATCH"><input type="hidden" name="csrf" value="token"></form>`,
+				`<input type="text" class="cell-editor" value="Ada" name="Name" form="users-edit-1" aria-label="Name">`,
+				`<input type="checkbox" class="cell-editor" value="true" name="Active" form="users-edit-1" aria-label="Active" checked>`,
+				`<button type="submit" class="edit-save" form="users-edit-2">`,
+			},
+			notWant: []string{`name="ID"`},
+		},
+		{
+			name: "one form for the table",
+			edit: InlineEdit{Enabled: true, SaveURL: "/users", Table: true, IDColumn: "ID"},
+			want: []string{
+				`<form id="users-edit" method="POST" action="/users" class="inline-edit-form inline-edit-table"><button type="submit" class="edit-save">`,
+				`<input type="number" class="cell-editor" value="2" name="ID[2]" form="users-edit" aria-label="ID" step="any">`,
+				`<input type="text" class="cell-editor" value="Bob" name="Name[2]" form="users-edit" aria-label="Name">`,
+			},
+			notWant: []string{`_method`, `form="users-edit-1"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit := tt.edit
+			r := NewRenderer()
+			r.Features = FeatureInlineEdit
+			html, err := r.RenderHTML(DatabasePaginatedData{
+				Headers: headers,
+				Rows:    rows,
+				Options: TableOptions{ID: "users", InlineEdit: &edit},
+			})
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("missing %s in:\n%s", want, html)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(html, notWant) {
+					t.Errorf("unexpected %s", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateEditorHTML(t *testing.T) {
+	when := time.Date(2024, 3, 9, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		column *Column
+		value  interface{}
+		want   string
+	}{
+		{
+			name:   "date",
+			column: &Column{Editor: &CellEditor{Type: "date", Required: true}},
+			value:  when,
+			want:   `<input type="date" class="cell-editor" value="2024-03-09" name="f" form="x" aria-label="F" required>`,
+		},
+		{
+			name:  "datetime from the value",
+			value: when,
+			want:  `<input type="datetime-local" class="cell-editor" value="2024-03-09T14:30" name="f" form="x" aria-label="F">`,
+		},
+		{
+			name:   "select keeps a value outside the options",
+			column: &Column{Editor: &CellEditor{Type: "select", Options: []string{"open", "closed"}}},
+			value:  "draft",
+			want:   `<select class="cell-editor" name="f" form="x" aria-label="F"><option value="draft" selected>draft</option><option value="open">open</option><option value="closed">closed</option></select>`,
+		},
+		{
+			name:   "textarea escapes the value",
+			column: &Column{Editor: &CellEditor{Type: "textarea"}},
+			value:  "<b>",
+			want:   `<textarea class="cell-editor" rows="2" name="f" form="x" aria-label="F">&lt;b&gt;</textarea>`,
+		},
+		{
+			name:  "unchecked box submits the hidden false after it",
+			value: false,
+			want:  `<input type="checkbox" class="cell-editor" value="true" name="f" form="x" aria-label="F"><input type="hidden" name="f" value="false" form="x">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(generateEditorHTML(tt.column, "F", tt.value, "f", "x")); got != tt.want {
+				t.Errorf("generateEditorHTML() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package tablerenderer
+
+import "context"
+
+// RenderHTMLInMemory renders data holding every row, searching, sorting and
+// paging it in memory by the options instead of expecting the current page
+// from the database. It suits small tables that do not warrant LIMIT and
+// OFFSET queries. Plugin OnState hooks run once, before the rows are paged.
+func (r *Renderer) RenderHTMLInMemory(data TableData) (string, error) {
+	options := data.Options
+	r.applyStatePlugins(&options)
+	// Options of disabled features must not select rows either
+	r.dropDisabledFeatures(&options)
+
+	headers, rows, err := resolveHeadersAndRows(data.Headers, data.Rows, data.Data, options.Columns)
+	if err != nil {
+		return "", err
+	}
+	labelStructHeaders(headers, data.Data, len(data.Headers) > 0, &options)
+
+	// Filter before paging so the pages and counts match the visible rows
+	rows = filterRows(headers, rows, options.RowFilter)
+	options.RowFilter = nil
+
+	page, err := SliceSource{Headers: headers, Rows: rows}.Load(context.Background(), options)
+	if err != nil {
+		return "", err
+	}
+	return r.RenderHTML(page)
+}
@@ -0,0 +1,45 @@
+package tablerenderer
+
+import (
+	"strings"
+	"testing"
+)
+
+// countingPlugin counts its OnState calls and narrows the search to "ada"
+type countingPlugin struct {
+	PluginBase
+	calls int
+}
+
+// OnState implements Plugin
+func (p *countingPlugin) OnState(options *TableOptions) {
+	p.calls++
+	options.Search = &Search{Enabled: true, SearchTerm: "ada"}
+}
+
+func TestRenderHTMLInMemoryStatePluginsOnce(t *testing.T) {
+	plugin := &countingPlugin{}
+	r := NewRenderer()
+	r.Use(plugin)
+
+	html, err := r.RenderHTMLInMemory(TableData{
+		Headers: []string{"Name"},
+		Rows:    [][]interface{}{{"Ada"}, {"Bob"}},
+	})
+	if err != nil {
+		t.Fatalf("RenderHTMLInMemory() error = %v", err)
+	}
+	if plugin.calls != 1 {
+		t.Errorf("OnState calls = %d, want 1", plugin.calls)
+	}
+	if !strings.Contains(html, "Ada") || strings.Contains(html, "Bob") {
+		t.Errorf("rows not searched by the plugin's term:\n%s", html)
+	}
+
+	if _, err := r.RenderHTML(DatabasePaginatedData{Headers: []string{"Name"}, Rows: [][]interface{}{{"Ada"}}}); err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if plugin.calls != 2 {
+		t.Errorf("OnState calls after another render = %d, want 2", plugin.calls)
+	}
+}
@@ -0,0 +1,107 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// FromJSON reads a JSON array of objects, e.g. an API response, into table
+// data. Headers are the object keys in the order they first appear, so the
+// columns match the document. Missing keys give nil cells, integers become
+// int64, other numbers float64, and nested objects and arrays are kept as
+// compact JSON text.
+func FromJSON(data []byte) (TableData, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return TableData{}, fmt.Errorf("failed to decode JSON array: %w", err)
+	}
+
+	var headers []string
+	index := make(map[string]int)
+	records := make([]map[string]interface{}, len(objects))
+	for i, object := range objects {
+		keys, values, err := decodeJSONObject(object)
+		if err != nil {
+			return TableData{}, fmt.Errorf("failed to decode JSON object %d: %w", i, err)
+		}
+		for _, key := range keys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(headers)
+				headers = append(headers, key)
+			}
+		}
+		records[i] = values
+	}
+
+	rows := make([][]interface{}, len(records))
+	for i, record := range records {
+		row := make([]interface{}, len(headers))
+		for j, header := range headers {
+			row[j] = record[header]
+		}
+		rows[i] = row
+	}
+	return TableData{Headers: headers, Rows: rows}, nil
+}
+
+// decodeJSONObject decodes an object, returning its keys in document order
+// and the converted value of each key
+func decodeJSONObject(object json.RawMessage) ([]string, map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(object))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected an object, got %s", object)
+	}
+
+	var keys []string
+	values := make(map[string]interface{})
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := token.(string)
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key], err = jsonCellValue(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return keys, values, nil
+}
+
+// jsonCellValue converts a JSON value to a cell value
+func jsonCellValue(raw json.RawMessage) (interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && (raw[0] == '{' || raw[0] == '[') {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, err
+		}
+		return compact.String(), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if number, ok := value.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return i, nil
+		}
+		return number.Float64()
+	}
+	return value, nil
+}
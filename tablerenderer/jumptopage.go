@@ -0,0 +1,48 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// generateJumpToPageHTML generates the "Go to page" form of the pagination
+// bar. It submits the page parameter with the other parameters kept in
+// hidden inputs, so it works without scripts.
+func (r *Renderer) generateJumpToPageHTML(paginationInfo PaginationInfo, pagination *Pagination, currentQueryParams map[string]string, frame string) string {
+	if paginationInfo.TotalPages <= 1 {
+		return ""
+	}
+	queryParam := pagination.QueryParam
+	if queryParam == "" {
+		queryParam = "page"
+	}
+
+	params := queryValues(currentQueryParams)
+	params.Del(queryParam)
+	params.Del("page")
+
+	action := ""
+	if pagination.BaseURL != "" {
+		action = linkURL(pagination.BaseURL, nil)
+	}
+
+	var html strings.Builder
+	html.WriteString(fmt.Sprintf(`<form method="GET" action="%s" class="jump-to-page"%s>`, template.HTMLEscapeString(action), turboFrameAttr(frame)))
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+			template.HTMLEscapeString(key), template.HTMLEscapeString(params.Get(key))))
+	}
+	html.WriteString(fmt.Sprintf(`<label>%s <input type="number" name="%s" min="1" max="%d" value="%d" required></label>`,
+		template.HTMLEscapeString(r.translate(LabelGoToPage, 1)), template.HTMLEscapeString(queryParam),
+		paginationInfo.TotalPages, paginationInfo.CurrentPage))
+	html.WriteString(fmt.Sprintf(`<button type="submit">%s</button>`, template.HTMLEscapeString(r.translate(LabelGo, 1))))
+	html.WriteString(`</form>`)
+	return html.String()
+}
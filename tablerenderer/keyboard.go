@@ -0,0 +1,59 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// keyboardShortcutsScript binds the table shortcuts to the enclosing table
+// container. Arrow keys follow the reading direction of the container.
+const keyboardShortcutsScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var help=c.querySelector('.keyboard-help');
+var toggle=c.querySelector('[data-keyboard-help-toggle]');
+if(toggle&&help){toggle.addEventListener('click',function(){help.hidden=!help.hidden;});}
+document.addEventListener('keydown',function(e){
+if(e.defaultPrevented||e.ctrlKey||e.metaKey||e.altKey)return;
+var t=e.target;if(t&&(t.isContentEditable||/^(INPUT|TEXTAREA|SELECT)$/.test(t.tagName)))return;
+var rtl=c.getAttribute('dir')==='rtl',el=null;
+switch(e.key){
+case '/':el=c.querySelector('.search-control input[type=text]');if(el){e.preventDefault();el.focus();}return;
+case 'ArrowLeft':el=c.querySelector(rtl?'a[rel=next]':'a[rel=prev]');break;
+case 'ArrowRight':el=c.querySelector(rtl?'a[rel=prev]':'a[rel=next]');break;
+case 'e':el=c.querySelector('[data-table-export]');break;
+case '?':if(help){help.hidden=!help.hidden;}return;
+case 'Escape':if(help){help.hidden=true;}return;
+default:return;}
+if(el){e.preventDefault();el.click();}
+});
+})();`
+
+// generateKeyboardHelpHTML generates the help toggle and popover listing the
+// keyboard shortcuts
+func (r *Renderer) generateKeyboardHelpHTML() string {
+	shortcuts := []struct {
+		key   string
+		label string
+	}{
+		{"/", LabelShortcutSearch},
+		{"←", LabelShortcutPrevious},
+		{"→", LabelShortcutNext},
+		{"e", LabelShortcutExport},
+		{"?", LabelShortcutHelp},
+	}
+
+	title := template.HTMLEscapeString(r.translate(LabelKeyboardShortcuts, 1))
+
+	var html strings.Builder
+	html.WriteString(`<div class="keyboard-help-control">`)
+	html.WriteString(fmt.Sprintf(`<button type="button" class="keyboard-help-btn" data-keyboard-help-toggle title="%s" aria-label="%s">%s</button>`, title, title, r.icon(IconKeyboard)))
+	html.WriteString(`<div class="keyboard-help" hidden>`)
+	html.WriteString(fmt.Sprintf(`<strong>%s</strong><dl>`, title))
+	for _, shortcut := range shortcuts {
+		html.WriteString(fmt.Sprintf(`<dt><kbd>%s</kbd></dt><dd>%s</dd>`,
+			shortcut.key, template.HTMLEscapeString(r.translate(shortcut.label, 1))))
+	}
+	html.WriteString(`</dl></div></div>`)
+	return html.String()
+}
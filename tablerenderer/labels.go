@@ -0,0 +1,417 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Label keys for the user-facing strings rendered around tables
+const (
+	LabelPrevious          = "previous"           // Previous page link
+	LabelNext              = "next"               // Next page link
+	LabelFirst             = "first"              // First page link
+	LabelLast              = "last"               // Last page link
+	LabelShowingEntries    = "showing_entries"    // Pagination info; args: start, end, total
+	LabelShowingCount      = "showing_count"      // Pagination info without a total; args: rows shown
+	LabelShowingEstimate   = "showing_estimate"   // Pagination info with an estimated total; args: start, end, rounded total such as "1.2M"
+	LabelNoRecords         = "no_records"         // Empty table and pagination info message
+	LabelSearch            = "search"             // Search input label
+	LabelSearchPlaceholder = "search_placeholder" // Default search input placeholder
+	LabelSearchButton      = "search_button"      // Search button title
+	LabelClearSearch       = "clear_search"       // Clear search button title
+	LabelShow              = "show"               // Page size dropdown label
+	LabelEntriesPerPage    = "entries_per_page"   // Page size option; args: page size
+	LabelAllRows           = "all_rows"           // Page size option showing every row
+	LabelKeyboardShortcuts = "keyboard_shortcuts" // Keyboard help title
+	LabelShortcutSearch    = "shortcut_search"    // Keyboard help: focus search
+	LabelShortcutPrevious  = "shortcut_previous"  // Keyboard help: previous page
+	LabelShortcutNext      = "shortcut_next"      // Keyboard help: next page
+	LabelShortcutExport    = "shortcut_export"    // Keyboard help: open export menu
+	LabelShortcutHelp      = "shortcut_help"      // Keyboard help: toggle this help
+	LabelStatsCount        = "stats_count"        // Statistics footer: non-null values
+	LabelStatsNulls        = "stats_nulls"        // Statistics footer: null values
+	LabelStatsDistinct     = "stats_distinct"     // Statistics footer: distinct values
+	LabelStatsMin          = "stats_min"          // Statistics footer: minimum
+	LabelStatsMax          = "stats_max"          // Statistics footer: maximum
+	LabelRowNumber         = "row_number"         // Row number column header
+	LabelSelectAll         = "select_all"         // Select-all checkbox label
+	LabelSelectRow         = "select_row"         // Row selection checkbox label
+	LabelCellsMissing      = "cells_missing"      // Data quality notice; args: number of empty cells
+	LabelCellsInvalid      = "cells_invalid"      // Data quality notice; args: number of unparseable cells
+	LabelActions           = "actions"            // Actions column header
+	LabelQuickFilter       = "quick_filter"       // Quick filter placeholder
+	LabelQuickFilterEmpty  = "quick_filter_empty" // Quick filter message when no row on the page matches
+	LabelToday             = "today"              // Group header of today's day bucket
+	LabelYesterday         = "yesterday"          // Group header of yesterday's day bucket
+	LabelThisWeek          = "this_week"          // Group header of the current week bucket
+	LabelLastWeek          = "last_week"          // Group header of the previous week bucket
+	LabelWeekOf            = "week_of"            // Group header of a week bucket; args: first day of the week
+	LabelThisMonth         = "this_month"         // Group header of the current month bucket
+	LabelLastMonth         = "last_month"         // Group header of the previous month bucket
+	LabelSubtotal          = "subtotal"           // Group subtotal row label
+	LabelNullsFirst        = "nulls_first"        // Sort indicator title when empty values sort first
+	LabelNullsLast         = "nulls_last"         // Sort indicator title when empty values sort last
+	LabelFacetAll          = "facet_all"          // Facet option clearing the filter
+	LabelApplyFilters      = "apply_filters"      // Facet submit button shown without scripts
+	LabelLoadMore          = "load_more"          // Button appending the next page's rows
+	LabelGoToPage          = "go_to_page"         // Jump-to-page input label
+	LabelGo                = "go"                 // Jump-to-page submit button
+	LabelExport            = "export"             // Export toolbar label
+	LabelCopy              = "copy"               // Export button copying rows to the clipboard
+	LabelPrint             = "print"              // Export button opening the print view
+	LabelSave              = "save"               // Inline edit save button
+	LabelBulkActions       = "bulk_actions"       // Bulk actions bar label
+	LabelDeleteSelected    = "delete_selected"    // Bulk action deleting the selected rows
+	LabelExportSelected    = "export_selected"    // Bulk action exporting the selected rows
+	LabelConfirmDelete     = "confirm_delete"     // Confirmation asked before deleting the selected rows
+	LabelValidationWarning = "validation_warning" // Lenient validation banner heading; args: number of problems
+)
+
+// Translator resolves the user-facing strings rendered around tables
+type Translator interface {
+	// Translate returns the text for key, choosing the plural form for count
+	// and formatting args into it
+	Translate(key string, count int, args ...interface{}) string
+}
+
+// Locale is a Translator backed by a message table and a plural rule
+// Messages hold one format string per plural form; keys missing from a
+// locale fall back to English
+type Locale struct {
+	Tag      string              `json:"tag"`      // Language tag, e.g. "de"
+	Messages map[string][]string `json:"messages"` // Plural forms per label key, as fmt format strings
+	Plural   func(count int) int `json:"-"`        // Index of the plural form for count (default: English rule)
+}
+
+// Translate implements Translator
+func (l *Locale) Translate(key string, count int, args ...interface{}) string {
+	forms := l.Messages[key]
+	if len(forms) == 0 && l != LocaleEnglish {
+		return LocaleEnglish.Translate(key, count, args...)
+	}
+	if len(forms) == 0 {
+		return key
+	}
+
+	plural := l.Plural
+	if plural == nil {
+		plural = pluralEnglish
+	}
+	index := plural(count)
+	if index < 0 || index >= len(forms) {
+		index = len(forms) - 1
+	}
+
+	if len(args) == 0 {
+		return forms[index]
+	}
+	return fmt.Sprintf(forms[index], args...)
+}
+
+// pluralEnglish selects between singular and plural forms (also German, Spanish)
+func pluralEnglish(count int) int {
+	if count == 1 {
+		return 0
+	}
+	return 1
+}
+
+// pluralFrench treats zero as singular
+func pluralFrench(count int) int {
+	if count <= 1 {
+		return 0
+	}
+	return 1
+}
+
+// Built-in locales
+var (
+	LocaleEnglish = &Locale{
+		Tag:    "en",
+		Plural: pluralEnglish,
+		Messages: map[string][]string{
+			LabelPrevious:          {"Previous"},
+			LabelNext:              {"Next"},
+			LabelFirst:             {"First"},
+			LabelLast:              {"Last"},
+			LabelShowingEntries:    {"Showing %[1]d to %[2]d of %[3]d entry", "Showing %[1]d to %[2]d of %[3]d entries"},
+			LabelShowingCount:      {"Showing %[1]d entry", "Showing %[1]d entries"},
+			LabelShowingEstimate:   {"Showing %[1]d to %[2]d of about %[3]s entry", "Showing %[1]d to %[2]d of about %[3]s entries"},
+			LabelNoRecords:         {"No records found"},
+			LabelSearch:            {"Search:"},
+			LabelSearchPlaceholder: {"Search all columns..."},
+			LabelSearchButton:      {"Search"},
+			LabelClearSearch:       {"Clear search"},
+			LabelShow:              {"Show:"},
+			LabelEntriesPerPage:    {"%[1]d entry per page", "%[1]d entries per page"},
+			LabelAllRows:           {"All"},
+			LabelKeyboardShortcuts: {"Keyboard shortcuts"},
+			LabelShortcutSearch:    {"Focus search"},
+			LabelShortcutPrevious:  {"Previous page"},
+			LabelShortcutNext:      {"Next page"},
+			LabelShortcutExport:    {"Open export menu"},
+			LabelShortcutHelp:      {"Show or hide shortcuts"},
+			LabelStatsCount:        {"Non-null"},
+			LabelStatsNulls:        {"Nulls"},
+			LabelStatsDistinct:     {"Distinct"},
+			LabelStatsMin:          {"Min"},
+			LabelStatsMax:          {"Max"},
+			LabelRowNumber:         {"#"},
+			LabelSelectAll:         {"Select all rows"},
+			LabelSelectRow:         {"Select row"},
+			LabelCellsMissing:      {"%[1]d cell missing", "%[1]d cells missing"},
+			LabelCellsInvalid:      {"%[1]d invalid value", "%[1]d invalid values"},
+			LabelActions:           {"Actions"},
+			LabelQuickFilter:       {"Filter this page..."},
+			LabelQuickFilterEmpty:  {"No rows on this page match"},
+			LabelToday:             {"Today"},
+			LabelYesterday:         {"Yesterday"},
+			LabelThisWeek:          {"This week"},
+			LabelLastWeek:          {"Last week"},
+			LabelWeekOf:            {"Week of %[1]s"},
+			LabelThisMonth:         {"This month"},
+			LabelLastMonth:         {"Last month"},
+			LabelSubtotal:          {"Subtotal"},
+			LabelNullsFirst:        {"Empty values first"},
+			LabelNullsLast:         {"Empty values last"},
+			LabelFacetAll:          {"All"},
+			LabelApplyFilters:      {"Apply"},
+			LabelLoadMore:          {"Load more"},
+			LabelGoToPage:          {"Go to page"},
+			LabelGo:                {"Go"},
+			LabelExport:            {"Export"},
+			LabelCopy:              {"Copy"},
+			LabelPrint:             {"Print"},
+			LabelSave:              {"Save"},
+			LabelBulkActions:       {"Bulk actions"},
+			LabelDeleteSelected:    {"Delete selected"},
+			LabelExportSelected:    {"Export selected"},
+			LabelConfirmDelete:     {"Delete the selected rows?"},
+			LabelValidationWarning: {"%[1]d problem found in the table data", "%[1]d problems found in the table data"},
+		},
+	}
+
+	LocaleGerman = &Locale{
+		Tag:    "de",
+		Plural: pluralEnglish,
+		Messages: map[string][]string{
+			LabelPrevious:          {"Zurück"},
+			LabelNext:              {"Weiter"},
+			LabelFirst:             {"Erste"},
+			LabelLast:              {"Letzte"},
+			LabelShowingEntries:    {"Zeige %[1]d bis %[2]d von %[3]d Eintrag", "Zeige %[1]d bis %[2]d von %[3]d Einträgen"},
+			LabelShowingCount:      {"Zeige %[1]d Eintrag", "Zeige %[1]d Einträge"},
+			LabelShowingEstimate:   {"Zeige %[1]d bis %[2]d von etwa %[3]s Eintrag", "Zeige %[1]d bis %[2]d von etwa %[3]s Einträgen"},
+			LabelNoRecords:         {"Keine Einträge gefunden"},
+			LabelSearch:            {"Suche:"},
+			LabelSearchPlaceholder: {"Alle Spalten durchsuchen..."},
+			LabelSearchButton:      {"Suchen"},
+			LabelClearSearch:       {"Suche löschen"},
+			LabelShow:              {"Anzeigen:"},
+			LabelEntriesPerPage:    {"%[1]d Eintrag pro Seite", "%[1]d Einträge pro Seite"},
+			LabelAllRows:           {"Alle"},
+			LabelKeyboardShortcuts: {"Tastenkürzel"},
+			LabelShortcutSearch:    {"Suche fokussieren"},
+			LabelShortcutPrevious:  {"Vorherige Seite"},
+			LabelShortcutNext:      {"Nächste Seite"},
+			LabelShortcutExport:    {"Exportmenü öffnen"},
+			LabelShortcutHelp:      {"Tastenkürzel ein- oder ausblenden"},
+			LabelStatsCount:        {"Nicht leer"},
+			LabelStatsNulls:        {"Leer"},
+			LabelStatsDistinct:     {"Eindeutig"},
+			LabelStatsMin:          {"Min."},
+			LabelStatsMax:          {"Max."},
+			LabelRowNumber:         {"#"},
+			LabelSelectAll:         {"Alle Zeilen auswählen"},
+			LabelSelectRow:         {"Zeile auswählen"},
+			LabelCellsMissing:      {"%[1]d fehlender Wert", "%[1]d fehlende Werte"},
+			LabelCellsInvalid:      {"%[1]d ungültiger Wert", "%[1]d ungültige Werte"},
+			LabelActions:           {"Aktionen"},
+			LabelQuickFilter:       {"Diese Seite filtern..."},
+			LabelQuickFilterEmpty:  {"Keine Zeile auf dieser Seite passt"},
+			LabelToday:             {"Heute"},
+			LabelYesterday:         {"Gestern"},
+			LabelThisWeek:          {"Diese Woche"},
+			LabelLastWeek:          {"Letzte Woche"},
+			LabelWeekOf:            {"Woche vom %[1]s"},
+			LabelThisMonth:         {"Dieser Monat"},
+			LabelLastMonth:         {"Letzter Monat"},
+			LabelSubtotal:          {"Zwischensumme"},
+			LabelNullsFirst:        {"Leere Werte zuerst"},
+			LabelNullsLast:         {"Leere Werte zuletzt"},
+			LabelFacetAll:          {"Alle"},
+			LabelApplyFilters:      {"Anwenden"},
+			LabelLoadMore:          {"Mehr laden"},
+			LabelGoToPage:          {"Gehe zu Seite"},
+			LabelGo:                {"Los"},
+			LabelExport:            {"Exportieren"},
+			LabelCopy:              {"Kopieren"},
+			LabelPrint:             {"Drucken"},
+			LabelSave:              {"Speichern"},
+			LabelBulkActions:       {"Sammelaktionen"},
+			LabelDeleteSelected:    {"Auswahl löschen"},
+			LabelExportSelected:    {"Auswahl exportieren"},
+			LabelConfirmDelete:     {"Die ausgewählten Zeilen löschen?"},
+			LabelValidationWarning: {"%[1]d Problem in den Tabellendaten gefunden", "%[1]d Probleme in den Tabellendaten gefunden"},
+		},
+	}
+
+	LocaleFrench = &Locale{
+		Tag:    "fr",
+		Plural: pluralFrench,
+		Messages: map[string][]string{
+			LabelPrevious:          {"Précédent"},
+			LabelNext:              {"Suivant"},
+			LabelFirst:             {"Première"},
+			LabelLast:              {"Dernière"},
+			LabelShowingEntries:    {"Affichage de %[1]d à %[2]d sur %[3]d entrée", "Affichage de %[1]d à %[2]d sur %[3]d entrées"},
+			LabelShowingCount:      {"Affichage de %[1]d entrée", "Affichage de %[1]d entrées"},
+			LabelShowingEstimate:   {"Affichage de %[1]d à %[2]d sur environ %[3]s entrée", "Affichage de %[1]d à %[2]d sur environ %[3]s entrées"},
+			LabelNoRecords:         {"Aucun enregistrement trouvé"},
+			LabelSearch:            {"Rechercher :"},
+			LabelSearchPlaceholder: {"Rechercher dans toutes les colonnes..."},
+			LabelSearchButton:      {"Rechercher"},
+			LabelClearSearch:       {"Effacer la recherche"},
+			LabelShow:              {"Afficher :"},
+			LabelEntriesPerPage:    {"%[1]d entrée par page", "%[1]d entrées par page"},
+			LabelAllRows:           {"Tout"},
+			LabelKeyboardShortcuts: {"Raccourcis clavier"},
+			LabelShortcutSearch:    {"Aller à la recherche"},
+			LabelShortcutPrevious:  {"Page précédente"},
+			LabelShortcutNext:      {"Page suivante"},
+			LabelShortcutExport:    {"Ouvrir le menu d'export"},
+			LabelShortcutHelp:      {"Afficher ou masquer les raccourcis"},
+			LabelStatsCount:        {"Non nuls"},
+			LabelStatsNulls:        {"Nuls"},
+			LabelStatsDistinct:     {"Distincts"},
+			LabelStatsMin:          {"Min."},
+			LabelStatsMax:          {"Max."},
+			LabelRowNumber:         {"#"},
+			LabelSelectAll:         {"Sélectionner toutes les lignes"},
+			LabelSelectRow:         {"Sélectionner la ligne"},
+			LabelCellsMissing:      {"%[1]d valeur manquante", "%[1]d valeurs manquantes"},
+			LabelCellsInvalid:      {"%[1]d valeur invalide", "%[1]d valeurs invalides"},
+			LabelActions:           {"Actions"},
+			LabelQuickFilter:       {"Filtrer cette page..."},
+			LabelQuickFilterEmpty:  {"Aucune ligne de cette page ne correspond"},
+			LabelToday:             {"Aujourd'hui"},
+			LabelYesterday:         {"Hier"},
+			LabelThisWeek:          {"Cette semaine"},
+			LabelLastWeek:          {"La semaine dernière"},
+			LabelWeekOf:            {"Semaine du %[1]s"},
+			LabelThisMonth:         {"Ce mois-ci"},
+			LabelLastMonth:         {"Le mois dernier"},
+			LabelSubtotal:          {"Sous-total"},
+			LabelNullsFirst:        {"Valeurs vides en premier"},
+			LabelNullsLast:         {"Valeurs vides en dernier"},
+			LabelFacetAll:          {"Tous"},
+			LabelApplyFilters:      {"Appliquer"},
+			LabelLoadMore:          {"Charger plus"},
+			LabelGoToPage:          {"Aller à la page"},
+			LabelGo:                {"OK"},
+			LabelExport:            {"Exporter"},
+			LabelCopy:              {"Copier"},
+			LabelPrint:             {"Imprimer"},
+			LabelSave:              {"Enregistrer"},
+			LabelBulkActions:       {"Actions groupées"},
+			LabelDeleteSelected:    {"Supprimer la sélection"},
+			LabelExportSelected:    {"Exporter la sélection"},
+			LabelConfirmDelete:     {"Supprimer les lignes sélectionnées ?"},
+			LabelValidationWarning: {"%[1]d problème dans les données du tableau", "%[1]d problèmes dans les données du tableau"},
+		},
+	}
+
+	LocaleSpanish = &Locale{
+		Tag:    "es",
+		Plural: pluralEnglish,
+		Messages: map[string][]string{
+			LabelPrevious:          {"Anterior"},
+			LabelNext:              {"Siguiente"},
+			LabelFirst:             {"Primera"},
+			LabelLast:              {"Última"},
+			LabelShowingEntries:    {"Mostrando %[1]d a %[2]d de %[3]d registro", "Mostrando %[1]d a %[2]d de %[3]d registros"},
+			LabelShowingCount:      {"Mostrando %[1]d registro", "Mostrando %[1]d registros"},
+			LabelShowingEstimate:   {"Mostrando %[1]d a %[2]d de aproximadamente %[3]s registro", "Mostrando %[1]d a %[2]d de aproximadamente %[3]s registros"},
+			LabelNoRecords:         {"No se encontraron registros"},
+			LabelSearch:            {"Buscar:"},
+			LabelSearchPlaceholder: {"Buscar en todas las columnas..."},
+			LabelSearchButton:      {"Buscar"},
+			LabelClearSearch:       {"Limpiar búsqueda"},
+			LabelShow:              {"Mostrar:"},
+			LabelEntriesPerPage:    {"%[1]d registro por página", "%[1]d registros por página"},
+			LabelAllRows:           {"Todos"},
+			LabelKeyboardShortcuts: {"Atajos de teclado"},
+			LabelShortcutSearch:    {"Ir a la búsqueda"},
+			LabelShortcutPrevious:  {"Página anterior"},
+			LabelShortcutNext:      {"Página siguiente"},
+			LabelShortcutExport:    {"Abrir el menú de exportación"},
+			LabelShortcutHelp:      {"Mostrar u ocultar los atajos"},
+			LabelStatsCount:        {"No nulos"},
+			LabelStatsNulls:        {"Nulos"},
+			LabelStatsDistinct:     {"Distintos"},
+			LabelStatsMin:          {"Mín."},
+			LabelStatsMax:          {"Máx."},
+			LabelRowNumber:         {"#"},
+			LabelSelectAll:         {"Seleccionar todas las filas"},
+			LabelSelectRow:         {"Seleccionar fila"},
+			LabelCellsMissing:      {"%[1]d valor faltante", "%[1]d valores faltantes"},
+			LabelCellsInvalid:      {"%[1]d valor no válido", "%[1]d valores no válidos"},
+			LabelActions:           {"Acciones"},
+			LabelQuickFilter:       {"Filtrar esta página..."},
+			LabelQuickFilterEmpty:  {"Ninguna fila de esta página coincide"},
+			LabelToday:             {"Hoy"},
+			LabelYesterday:         {"Ayer"},
+			LabelThisWeek:          {"Esta semana"},
+			LabelLastWeek:          {"La semana pasada"},
+			LabelWeekOf:            {"Semana del %[1]s"},
+			LabelThisMonth:         {"Este mes"},
+			LabelLastMonth:         {"El mes pasado"},
+			LabelSubtotal:          {"Subtotal"},
+			LabelNullsFirst:        {"Valores vacíos primero"},
+			LabelNullsLast:         {"Valores vacíos al final"},
+			LabelFacetAll:          {"Todos"},
+			LabelApplyFilters:      {"Aplicar"},
+			LabelLoadMore:          {"Cargar más"},
+			LabelGoToPage:          {"Ir a la página"},
+			LabelGo:                {"Ir"},
+			LabelExport:            {"Exportar"},
+			LabelCopy:              {"Copiar"},
+			LabelPrint:             {"Imprimir"},
+			LabelSave:              {"Guardar"},
+			LabelBulkActions:       {"Acciones masivas"},
+			LabelDeleteSelected:    {"Eliminar selección"},
+			LabelExportSelected:    {"Exportar selección"},
+			LabelConfirmDelete:     {"¿Eliminar las filas seleccionadas?"},
+			LabelValidationWarning: {"%[1]d problema en los datos de la tabla", "%[1]d problemas en los datos de la tabla"},
+		},
+	}
+)
+
+// builtinLocales lists the built-in locales by language tag
+var builtinLocales = map[string]*Locale{
+	"en": LocaleEnglish,
+	"de": LocaleGerman,
+	"fr": LocaleFrench,
+	"es": LocaleSpanish,
+}
+
+// LocaleFor returns the built-in locale for a language tag such as "de" or
+// "fr-CA", falling back to English when the language is not available
+func LocaleFor(tag string) *Locale {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if locale, ok := builtinLocales[tag]; ok {
+		return locale
+	}
+	return LocaleEnglish
+}
+
+// translate resolves a label through the renderer's translator
+func (r *Renderer) translate(key string, count int, args ...interface{}) string {
+	if r.Translator == nil {
+		return LocaleEnglish.Translate(key, count, args...)
+	}
+	return r.Translator.Translate(key, count, args...)
+}
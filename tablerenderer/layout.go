@@ -0,0 +1,226 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// minColumnWidth is the narrowest a column is squeezed to by the layout,
+// unless its content is narrower
+const minColumnWidth = 4
+
+// layoutPercentile is the share of a column's cells that fit its desired
+// width; longer cells are cut so that a few outliers do not widen the column
+const layoutPercentile = 0.9
+
+// layoutColumns distributes available character cells among the columns.
+// Each column wants the width of its header or of most of its cells,
+// whichever is wider. When the wanted widths do not fit, every column keeps
+// a small minimum and the rest is shared by Column.Weight among the columns
+// that want more. An available width of 0 or less gives every column its
+// wanted width.
+func layoutColumns(headers []string, texts [][]string, columns []Column, available int) []int {
+	configs := columnsFor(headers, columns)
+	desired := make([]int, len(headers))
+	weights := make([]float64, len(headers))
+	total := 0
+	for j, header := range headers {
+		lengths := make([]int, 0, len(texts))
+		for _, row := range texts {
+			if j < len(row) {
+				lengths = append(lengths, utf8.RuneCountInString(row[j]))
+			}
+		}
+		desired[j] = percentile(lengths, layoutPercentile)
+		if length := utf8.RuneCountInString(headerLabel(configs[j], header)); length > desired[j] {
+			desired[j] = length
+		}
+		total += desired[j]
+
+		weights[j] = 1
+		if configs[j] != nil && configs[j].Weight > 0 {
+			weights[j] = configs[j].Weight
+		}
+	}
+	if available <= 0 || total <= available {
+		return desired
+	}
+
+	widths := make([]int, len(headers))
+	remaining := available
+	for j := range widths {
+		widths[j] = desired[j]
+		if widths[j] > minColumnWidth {
+			widths[j] = minColumnWidth
+		}
+		remaining -= widths[j]
+	}
+
+	// Share the remaining width by weight, handing what columns cannot use
+	// back to the others until it is used up
+	for remaining > 0 {
+		var weightSum float64
+		for j := range widths {
+			if widths[j] < desired[j] {
+				weightSum += weights[j]
+			}
+		}
+		if weightSum == 0 {
+			break
+		}
+
+		given := 0
+		for j := range widths {
+			if widths[j] >= desired[j] || given >= remaining {
+				continue
+			}
+			share := int(math.Floor(float64(remaining) * weights[j] / weightSum))
+			if share < 1 {
+				share = 1
+			}
+			if share > desired[j]-widths[j] {
+				share = desired[j] - widths[j]
+			}
+			if share > remaining-given {
+				share = remaining - given
+			}
+			widths[j] += share
+			given += share
+		}
+		remaining -= given
+	}
+	return widths
+}
+
+// percentile returns the value below which the share p of values fall
+func percentile(values []int, p float64) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// columnPercentages converts layout widths to percentages of the table width
+func columnPercentages(widths []int) []int {
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	percentages := make([]int, len(widths))
+	if total == 0 {
+		return percentages
+	}
+	for j, width := range widths {
+		percentages[j] = int(math.Round(float64(width) * 100 / float64(total)))
+	}
+	return percentages
+}
+
+// textCell returns the display text of a value for plain-text output on a
+// single line, using the column formatting where it produces text
+func (r *Renderer) textCell(column *Column, value interface{}) string {
+	formatted := r.formatValue(column, value)
+	text := fmt.Sprint(formatted)
+	if _, ok := formatted.(template.HTML); ok {
+		// Badges and other markup fall back to the raw value
+		text = exportValue(value)
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// textRows returns the plain-text cells of the rows
+func (r *Renderer) textRows(headers []string, rows [][]interface{}, columns []Column) [][]string {
+	configs := columnsFor(headers, columns)
+	texts := make([][]string, len(rows))
+	for i, row := range rows {
+		texts[i] = make([]string, len(row))
+		for j, value := range row {
+			var column *Column
+			if j < len(configs) {
+				column = configs[j]
+			}
+			texts[i][j] = r.textCell(column, value)
+		}
+	}
+	return texts
+}
+
+// RenderText renders the table as fixed-width plain text, e.g. for the text
+// part of emails or command line tools. Columns are fitted into width
+// characters (0 for no limit) by layoutColumns, cutting longer cells with an
+// ellipsis.
+func (r *Renderer) RenderText(data TableData, width int) (string, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return "", err
+	}
+	headers, rows, _ = extractSource(headers, rows, data.Options.Source)
+
+	texts := r.textRows(headers, rows, data.Options.Columns)
+
+	// Two spaces separate the columns
+	const gap = 2
+	available := 0
+	if width > 0 {
+		available = width - gap*(len(headers)-1)
+		if available < len(headers) {
+			available = len(headers)
+		}
+	}
+	widths := layoutColumns(headers, texts, data.Options.Columns, available)
+
+	var text strings.Builder
+	writeLine := func(cells []string) {
+		var line strings.Builder
+		for j, w := range widths {
+			var cell string
+			if j < len(cells) {
+				cell = fitText(cells[j], w)
+			}
+			if j > 0 {
+				line.WriteString(strings.Repeat(" ", gap))
+			}
+			line.WriteString(cell)
+			line.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(cell)))
+		}
+		text.WriteString(strings.TrimRight(line.String(), " "))
+		text.WriteString("\n")
+	}
+
+	writeLine(headerLabels(headers, data.Options.Columns))
+	rules := make([]string, len(widths))
+	for j, w := range widths {
+		rules[j] = strings.Repeat("-", w)
+	}
+	writeLine(rules)
+	if len(texts) == 0 {
+		text.WriteString(r.translate(LabelNoRecords, 0) + "\n")
+	}
+	for _, row := range texts {
+		writeLine(row)
+	}
+	return text.String(), nil
+}
+
+// fitText cuts text to width characters, ending it with an ellipsis when it
+// was cut
+func fitText(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,78 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LinkFormat renders a column's cells as links built from row values
+type LinkFormat struct {
+	URL     string `json:"url"`                // URL template with {Header} placeholders filled from the row, e.g. "/users/{ID}"
+	Target  string `json:"target,omitempty"`   // Link target, e.g. "_blank"
+	RowLink bool   `json:"row_link,omitempty"` // Also make the whole row navigate to the URL when clicked (needs scripts)
+}
+
+// urlPlaceholder matches {Header} placeholders in link URL templates
+var urlPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// rowLinkScript makes rows with a data-href attribute navigate on click,
+// leaving clicks on links and form controls inside the row alone
+const rowLinkScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+c.addEventListener('click',function(e){
+var tr=e.target.closest('tr[data-href]');if(!tr||e.target.closest('a,button,input,select,textarea,label'))return;
+window.location.href=tr.getAttribute('data-href');
+});
+})();`
+
+// expandURLTemplate fills the placeholders of a URL template from a row,
+// path-escaping values before the query string and query-escaping after it
+func expandURLTemplate(urlTemplate string, row RowView) string {
+	queryStart := strings.IndexByte(urlTemplate, '?')
+
+	var expanded strings.Builder
+	last := 0
+	for _, match := range urlPlaceholder.FindAllStringSubmatchIndex(urlTemplate, -1) {
+		expanded.WriteString(urlTemplate[last:match[0]])
+		value := exportValue(row.Get(urlTemplate[match[2]:match[3]]))
+		if queryStart >= 0 && match[0] > queryStart {
+			expanded.WriteString(url.QueryEscape(value))
+		} else {
+			expanded.WriteString(url.PathEscape(value))
+		}
+		last = match[1]
+	}
+	expanded.WriteString(urlTemplate[last:])
+	return safeURL(expanded.String())
+}
+
+// safeURL returns u when it is relative or uses a web scheme, and "#"
+// otherwise, so templates cannot produce javascript: links
+func safeURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "#"
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return u
+	}
+	return "#"
+}
+
+// linkCell wraps a formatted cell in an anchor pointing at href
+func linkCell(link *LinkFormat, href string, formatted interface{}) template.HTML {
+	content := cellHTML(formatted)
+
+	target := ""
+	if link.Target != "" {
+		target = fmt.Sprintf(` target="%s"`, template.HTMLEscapeString(link.Target))
+		if link.Target == "_blank" {
+			target += ` rel="noopener noreferrer"`
+		}
+	}
+	return template.HTML(fmt.Sprintf(`<a href="%s"%s>%s</a>`, template.HTMLEscapeString(href), target, content))
+}
@@ -0,0 +1,168 @@
+package tablerenderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// Links is the set of URLs a table links to, available from Resolve and,
+// with EmbedLinks, as JSON in the rendered table for client scripts
+type Links struct {
+	Sort     map[string]string `json:"sort,omitempty"`     // Sort URL of each header when sorting is enabled
+	Pages    map[int]string    `json:"pages,omitempty"`    // Page number links shown in the pagination controls
+	Previous string            `json:"previous,omitempty"` // Previous page, empty on the first page
+	Next     string            `json:"next,omitempty"`     // Next page, empty on the last page
+	First    string            `json:"first,omitempty"`    // First page, with Pagination.ShowFirstLast
+	Last     string            `json:"last,omitempty"`     // Last page, with Pagination.ShowFirstLast
+	Export   map[string]string `json:"export,omitempty"`   // Export URL of each format
+}
+
+// ExportLinks configures the export URLs of a table. They keep the current
+// sort order and search term so the export matches what is shown.
+type ExportLinks struct {
+	BaseURL string   `json:"base_url"`          // URL of the export handler, e.g. "/orders/export"
+	Param   string   `json:"param,omitempty"`   // Query parameter selecting the format (default: "format")
+	Formats []string `json:"formats,omitempty"` // Formats offered, e.g. "xlsx", "pdf", ExportCopy or ExportPrint (default: "csv" and "jsonl")
+
+	// Toolbar renders the formats as export buttons in the table toolbar.
+	// Endpoints sends formats to their own handlers instead of BaseURL.
+	Toolbar   bool              `json:"toolbar,omitempty"`
+	Endpoints map[string]string `json:"endpoints,omitempty"` // Export URL by format, e.g. {"pdf": "/orders/report.pdf"}
+}
+
+// generateLinks collects the sort, page and export URLs of a table
+func (r *Renderer) generateLinks(headers []string, options TableOptions, paginationInfo PaginationInfo) Links {
+	var links Links
+
+	if options.Sorting != nil && options.Sorting.Enabled {
+		sortLinks := r.generateSortLinks(headers, options.Columns, options.Sorting, r.sortLinkParams(options, paginationInfo))
+		links.Sort = make(map[string]string, len(headers))
+		for i, header := range headers {
+			links.Sort[header] = sortLinks[i]
+		}
+	}
+
+	if options.Pagination != nil && options.Pagination.Enabled && options.Pagination.Cursor {
+		params := r.paginationLinkParams(options, paginationInfo)
+		if options.Pagination.PrevCursor != "" {
+			links.Previous = cursorURL(options.Pagination, params, "before", options.Pagination.PrevCursor)
+		}
+		if options.Pagination.NextCursor != "" {
+			links.Next = cursorURL(options.Pagination, params, "after", options.Pagination.NextCursor)
+		}
+	} else if options.Pagination != nil && options.Pagination.Enabled && paginationInfo.TotalPages > 1 {
+		params := r.paginationLinkParams(options, paginationInfo)
+		if paginationInfo.CurrentPage > 1 {
+			links.Previous = pageURL(options.Pagination, params, paginationInfo.CurrentPage-1)
+		}
+		if paginationInfo.CurrentPage < paginationInfo.TotalPages {
+			links.Next = pageURL(options.Pagination, params, paginationInfo.CurrentPage+1)
+		}
+	}
+	// Without a total only the neighbouring pages are known
+	if options.Pagination != nil && options.Pagination.Enabled && paginationInfo.TotalPages > 1 && !options.Pagination.Cursor && !paginationInfo.Uncounted {
+		params := r.paginationLinkParams(options, paginationInfo)
+		start, end := pageWindow(paginationInfo, options.Pagination.PageWindow)
+		links.Pages = make(map[int]string, end-start+3)
+		for page := start; page <= end; page++ {
+			links.Pages[page] = pageURL(options.Pagination, params, page)
+		}
+		if options.Pagination.ShowEllipsis {
+			links.Pages[1] = pageURL(options.Pagination, params, 1)
+			if !paginationInfo.Approximate {
+				links.Pages[paginationInfo.TotalPages] = pageURL(options.Pagination, params, paginationInfo.TotalPages)
+			}
+		}
+		if options.Pagination.ShowFirstLast {
+			links.First = pageURL(options.Pagination, params, 1)
+			if !paginationInfo.Approximate {
+				links.Last = pageURL(options.Pagination, params, paginationInfo.TotalPages)
+			}
+		}
+	}
+
+	if options.ExportLinks != nil && (options.ExportLinks.BaseURL != "" || len(options.ExportLinks.Endpoints) > 0) {
+		links.Export = exportURLs(options)
+	}
+	return links
+}
+
+// exportURLs returns the export URL of each configured format. ExportCopy
+// requests the "tsv" format.
+func exportURLs(options TableOptions) map[string]string {
+	export := options.ExportLinks
+	param := export.Param
+	if param == "" {
+		param = "format"
+	}
+	formats := export.formats()
+
+	// Preserve the current sort order and search term
+	query := url.Values{}
+	if options.Sorting != nil && options.Sorting.Enabled && options.Sorting.SortBy != "" {
+		query.Set("sort_by", options.Sorting.SortBy)
+		if options.Sorting.SortOrder != "" {
+			query.Set("sort_order", options.Sorting.SortOrder)
+		}
+		if validNullsPosition(options.Sorting.NullsPosition) {
+			query.Set(nullsParam(options.Sorting), options.Sorting.NullsPosition)
+		}
+	}
+	if options.Search != nil && options.Search.Enabled && options.Search.SearchTerm != "" {
+		searchParam := options.Search.QueryParam
+		if searchParam == "" {
+			searchParam = "search"
+		}
+		query.Set(searchParam, options.Search.SearchTerm)
+	}
+	for _, filter := range options.Filters {
+		query.Set(filter.Param(), filter.Value())
+	}
+	if len(options.VisibleColumns) > 0 {
+		query.Set("columns", strings.Join(options.VisibleColumns, ","))
+	}
+
+	urls := make(map[string]string, len(formats))
+	for _, format := range formats {
+		baseURL := export.BaseURL
+		if endpoint := export.Endpoints[format]; endpoint != "" {
+			baseURL = endpoint
+		}
+		if baseURL == "" {
+			continue
+		}
+		if format == ExportCopy {
+			query.Set(param, "tsv")
+		} else {
+			query.Set(param, format)
+		}
+		separator := "?"
+		if strings.Contains(baseURL, "?") {
+			separator = "&"
+		}
+		urls[format] = baseURL + separator + query.Encode()
+	}
+	return urls
+}
+
+// linksScriptTag embeds the links as a JSON data block; it is not
+// executable, so it is emitted whatever the JSPolicy
+func linksScriptTag(links Links) (template.HTML, error) {
+	// json.Marshal escapes <, > and &, so the data cannot close the element
+	encoded, err := json.Marshal(links)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode links: %w", err)
+	}
+	return template.HTML(`<script type="application/json" class="table-links">` + string(encoded) + `</script>`), nil
+}
+
+// formats returns the configured export formats, or CSV and JSONL by default
+func (e *ExportLinks) formats() []string {
+	if len(e.Formats) == 0 {
+		return []string{"csv", "jsonl"}
+	}
+	return e.Formats
+}
@@ -0,0 +1,29 @@
+package tablerenderer
+
+// defaultLiveSearchDelay is the pause in typing, in milliseconds, after which
+// a live search is submitted
+const defaultLiveSearchDelay = 300
+
+// liveSearchScript submits the search form once typing pauses for the delay
+// in data-live-delay, or right away when the box is cleared. Terms shorter
+// than data-min-length are not submitted. The box is focused again with the
+// caret at the end after the page loads, so typing can go on.
+const liveSearchScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var f=c.querySelector('form.search-form[data-live-delay]');if(!f)return;
+var input=f.querySelector('input[type=text]');if(!input)return;
+var delay=+f.getAttribute('data-live-delay'),min=+f.getAttribute('data-min-length')||1,timer=0,key='table-live-search:'+location.pathname;
+try{if(sessionStorage.getItem(key)){sessionStorage.removeItem(key);input.focus();var n=input.value.length;input.setSelectionRange(n,n);}}catch(e){}
+input.addEventListener('input',function(){clearTimeout(timer);
+var q=input.value.trim();if(q&&q.length<min)return;
+timer=setTimeout(function(){try{sessionStorage.setItem(key,'1');}catch(e){}
+if(f.requestSubmit){f.requestSubmit();}else{f.submit();}},q?delay:0);});
+})();`
+
+// liveSearchDelay returns the configured live search delay
+func liveSearchDelay(search *Search) int {
+	if search.LiveDelay > 0 {
+		return search.LiveDelay
+	}
+	return defaultLiveSearchDelay
+}
@@ -0,0 +1,102 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// loadMoreScript fetches the page a Load more link points to, appends the
+// rows of its table to the tbody and swaps in its Load more link, which is
+// empty on the last page, then fires table:rows-loaded on the container.
+// Failed requests fall back to following the link.
+const loadMoreScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+c.addEventListener('click',function(e){
+var a=e.target.closest('a[data-load-more]');if(!a||!c.contains(a))return;
+e.preventDefault();if(a.getAttribute('aria-busy'))return;a.setAttribute('aria-busy','true');
+fetch(a.href,{credentials:'same-origin'}).then(function(r){if(!r.ok)throw new Error(r.status);return r.text();}).then(function(html){
+var doc=new DOMParser().parseFromString(html,'text/html'),next=doc.querySelector('.table-container')||doc;
+var body=c.querySelector('table.data-table > tbody');
+next.querySelectorAll('table.data-table > tbody > tr:not(.empty-state-row)').forEach(function(tr){body.appendChild(document.importNode(tr,true));});
+var more=next.querySelector('.load-more'),old=a.closest('.load-more');
+if(more){old.replaceWith(document.importNode(more,true));}else{old.remove();}
+c.dispatchEvent(new CustomEvent('table:rows-loaded'));
+}).catch(function(){location.href=a.href;});
+});
+})();`
+
+// infiniteScrollScript clicks the Load more link once it comes within 200px
+// of the viewport, watching each link swapped in after rows load. Browsers
+// without IntersectionObserver keep the link.
+const infiniteScrollScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c||!window.IntersectionObserver)return;
+var io=new IntersectionObserver(function(entries){entries.forEach(function(e){if(e.isIntersecting){io.unobserve(e.target);e.target.click();}});},{rootMargin:'200px'});
+function watch(){var a=c.querySelector('a[data-infinite-scroll]');if(a)io.observe(a);}
+c.addEventListener('table:rows-loaded',watch);watch();
+})();`
+
+// loadsMore reports whether the pagination appends pages with a Load more
+// link, shown as is or loaded on scroll
+func (p *Pagination) loadsMore() bool {
+	return p.LoadMore || p.InfiniteScroll
+}
+
+// nextPageURL returns the URL of the page after the current one, or "" on
+// the last page
+func nextPageURL(paginationInfo PaginationInfo, pagination *Pagination, currentQueryParams map[string]string) string {
+	if pagination.Cursor {
+		if pagination.NextCursor == "" {
+			return ""
+		}
+		return cursorURL(pagination, currentQueryParams, "after", pagination.NextCursor)
+	}
+	if paginationInfo.CurrentPage >= paginationInfo.TotalPages {
+		return ""
+	}
+	return pageURL(pagination, currentQueryParams, paginationInfo.CurrentPage+1)
+}
+
+// loadMoreID returns the ID of the Load more container, which htmx swaps
+// out of band
+func loadMoreID(options TableOptions) string {
+	if options.ID != "" {
+		return options.ID + "-load-more"
+	}
+	return "table-load-more"
+}
+
+// generateLoadMoreHTML generates the Load more link to the next page. With
+// Pagination.HTMX the link carries htmx attributes appending the rows of the
+// response to the tbody and replacing itself with the response's link; the
+// container stays, empty, on the last page so that swap clears the link.
+// With Pagination.InfiniteScroll the link also loads when scrolled to.
+func (r *Renderer) generateLoadMoreHTML(paginationInfo PaginationInfo, options TableOptions, currentQueryParams map[string]string) string {
+	pagination := options.Pagination
+	next := nextPageURL(paginationInfo, pagination, currentQueryParams)
+
+	id := ""
+	if pagination.HTMX {
+		id = fmt.Sprintf(` id="%s"`, template.HTMLEscapeString(loadMoreID(options)))
+	} else if next == "" {
+		return ""
+	}
+	if next == "" {
+		return fmt.Sprintf(`<div class="load-more"%s></div>`, id)
+	}
+
+	href := template.HTMLEscapeString(next)
+	attrs := ` data-load-more`
+	if pagination.InfiniteScroll {
+		attrs += ` data-infinite-scroll`
+	}
+	if pagination.HTMX {
+		trigger := ""
+		if pagination.InfiniteScroll {
+			trigger = ` hx-trigger="click, revealed"`
+		}
+		attrs = fmt.Sprintf(` hx-get="%s" hx-target="previous tbody" hx-select="table.data-table > tbody > tr" hx-swap="beforeend" hx-select-oob="#%s"%s`,
+			href, template.HTMLEscapeString(loadMoreID(options)), trigger)
+	}
+	return fmt.Sprintf(`<div class="load-more"%s><a class="load-more-button" rel="next" href="%s"%s%s>%s</a></div>`,
+		id, href, attrs, turboFrameAttr(turboFrameID(options)), template.HTMLEscapeString(r.translate(LabelLoadMore, 1)))
+}
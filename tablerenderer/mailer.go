@@ -0,0 +1,231 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"path"
+	"strings"
+	"time"
+)
+
+// Email is a message sent through a Mailer
+type Email struct {
+	From        string       `json:"from,omitempty"` // Sender address (default: the mailer's sender)
+	To          []string     `json:"to"`             // Recipient addresses
+	Subject     string       `json:"subject"`
+	Text        string       `json:"text,omitempty"` // Plain text body
+	HTML        string       `json:"html,omitempty"` // HTML body, e.g. from RenderEmailHTML
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a file attached to an Email
+type Attachment struct {
+	Name        string `json:"name"`                   // File name, e.g. "orders.csv"
+	ContentType string `json:"content_type,omitempty"` // Media type (default: derived from the file extension)
+	Content     []byte `json:"-"`
+}
+
+// Mailer delivers emails, e.g. scheduled reports. Implement it to send
+// through an email API instead of SMTP.
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	Addr string    // Server address including the port, e.g. "smtp.example.com:587"
+	Auth smtp.Auth // Authentication, e.g. smtp.PlainAuth (default: none)
+	From string    // Default sender address
+}
+
+// NewSMTPMailer creates a mailer for an SMTP server using PLAIN authentication
+func NewSMTPMailer(addr string, username string, password string, from string) *SMTPMailer {
+	host, _, _ := strings.Cut(addr, ":")
+	return &SMTPMailer{
+		Addr: addr,
+		Auth: smtp.PlainAuth("", username, password, host),
+		From: from,
+	}
+}
+
+// Send implements Mailer. The context is only checked before sending since
+// net/smtp does not support cancellation.
+func (m *SMTPMailer) Send(ctx context.Context, email Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if email.From == "" {
+		email.From = m.From
+	}
+
+	from, err := mail.ParseAddress(email.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	// Re-format the parsed addresses so headers cannot be injected
+	email.From = from.String()
+	recipients := make([]string, len(email.To))
+	to := make([]string, len(email.To))
+	for i, address := range email.To {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address: %w", err)
+		}
+		recipients[i] = parsed.Address
+		to[i] = parsed.String()
+	}
+	email.To = to
+
+	message, err := buildMIMEMessage(email, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.Addr, m.Auth, from.Address, recipients, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// buildMIMEMessage encodes an email as a MIME message with alternative text
+// and HTML bodies followed by the attachments
+func buildMIMEMessage(email Email, date time.Time) ([]byte, error) {
+	var message bytes.Buffer
+	writeHeader := func(name string, value string) {
+		fmt.Fprintf(&message, "%s: %s\r\n", name, value)
+	}
+	writeHeader("From", email.From)
+	writeHeader("To", strings.Join(email.To, ", "))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+
+	mixed, err := mimeBoundary()
+	if err != nil {
+		return nil, err
+	}
+	alternative, err := mimeBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	writeHeader("Content-Type", fmt.Sprintf(`multipart/mixed; boundary="%s"`, mixed))
+	message.WriteString("\r\n")
+
+	fmt.Fprintf(&message, "--%s\r\n", mixed)
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", alternative)
+	for _, body := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		if body.content == "" {
+			continue
+		}
+		fmt.Fprintf(&message, "--%s\r\n", alternative)
+		fmt.Fprintf(&message, "Content-Type: %s\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n", body.contentType)
+		qp := quotedprintable.NewWriter(&message)
+		qp.Write([]byte(body.content))
+		qp.Close()
+		message.WriteString("\r\n")
+	}
+	fmt.Fprintf(&message, "--%s--\r\n", alternative)
+
+	for _, attachment := range email.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(path.Ext(attachment.Name))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		fmt.Fprintf(&message, "--%s\r\n", mixed)
+		writeHeader("Content-Type", contentType)
+		writeHeader("Content-Transfer-Encoding", "base64")
+		writeHeader("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(attachment.Name)}))
+		message.WriteString("\r\n")
+
+		// Base64 lines must not exceed 76 characters
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 76 {
+			message.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		message.WriteString(encoded + "\r\n")
+	}
+	fmt.Fprintf(&message, "--%s--\r\n", mixed)
+
+	return message.Bytes(), nil
+}
+
+// mimeBoundary returns a random multipart boundary
+func mimeBoundary() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate MIME boundary: %w", err)
+	}
+	return "tablerenderer-" + hex.EncodeToString(random), nil
+}
+
+// ReportEmail describes a table report delivered by email
+type ReportEmail struct {
+	From        string        `json:"from,omitempty"` // Sender address (default: the mailer's sender)
+	To          []string      `json:"to"`             // Recipient addresses
+	Subject     string        `json:"subject"`
+	Intro       string        `json:"intro,omitempty"`      // Text shown above the table
+	Data        TableData     `json:"-"`                    // Table shown inline
+	AttachCSV   string        `json:"attach_csv,omitempty"` // File name of a CSV export of Data to attach, e.g. "orders.csv"
+	Export      ExportOptions `json:"export,omitempty"`     // Options for the CSV attachment
+	Attachments []Attachment  `json:"attachments,omitempty"`
+}
+
+// SendReport emails a table report with the table rendered inline by
+// RenderEmailHTML and, optionally, the full data attached as CSV
+func (r *Renderer) SendReport(ctx context.Context, mailer Mailer, report ReportEmail) error {
+	table, err := r.RenderEmailHTML(report.Data)
+	if err != nil {
+		return err
+	}
+
+	var html strings.Builder
+	html.WriteString(`<!DOCTYPE html><html><body style="margin: 0; padding: 16px; background: #ffffff">`)
+	if report.Intro != "" {
+		fmt.Fprintf(&html, `<p style="font-family: Arial, Helvetica, sans-serif; font-size: 14px; color: #212529">%s</p>`,
+			strings.ReplaceAll(template.HTMLEscapeString(report.Intro), "\n", "<br>"))
+	}
+	html.WriteString(table)
+	html.WriteString(`</body></html>`)
+
+	attachments := report.Attachments
+	if report.AttachCSV != "" {
+		var csv bytes.Buffer
+		if err := r.ExportCSV(&csv, report.Data, report.Export); err != nil {
+			return err
+		}
+		attachments = append(attachments, Attachment{
+			Name:        report.AttachCSV,
+			ContentType: "text/csv; charset=utf-8",
+			Content:     csv.Bytes(),
+		})
+	}
+
+	return mailer.Send(ctx, Email{
+		From:        report.From,
+		To:          report.To,
+		Subject:     report.Subject,
+		Text:        report.Intro,
+		HTML:        html.String(),
+		Attachments: attachments,
+	})
+}
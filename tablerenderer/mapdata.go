@@ -0,0 +1,56 @@
+package tablerenderer
+
+import (
+	"sort"
+)
+
+// mapSlice unwraps []map[string]interface{} data and pointers to it
+func mapSlice(data interface{}) ([]map[string]interface{}, bool) {
+	switch maps := data.(type) {
+	case []map[string]interface{}:
+		return maps, true
+	case *[]map[string]interface{}:
+		if maps != nil {
+			return *maps, true
+		}
+	}
+	return nil, false
+}
+
+// convertMapSliceToRows converts map rows, e.g. decoded JSON, to rows in a
+// deterministic column order: the explicit headers when given, otherwise the
+// configured columns followed by the remaining keys in sorted order. Keys a
+// map lacks become nil cells.
+func convertMapSliceToRows(maps []map[string]interface{}, headers []string, columns []Column) ([]string, [][]interface{}) {
+	if len(headers) == 0 {
+		seen := make(map[string]bool)
+		for _, column := range columns {
+			if !seen[column.Header] {
+				seen[column.Header] = true
+				headers = append(headers, column.Header)
+			}
+		}
+
+		var keys []string
+		for _, m := range maps {
+			for key := range m {
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+		sort.Strings(keys)
+		headers = append(headers, keys...)
+	}
+
+	rows := make([][]interface{}, len(maps))
+	for i, m := range maps {
+		row := make([]interface{}, len(headers))
+		for j, header := range headers {
+			row[j] = m[header]
+		}
+		rows[i] = row
+	}
+	return headers, rows
+}
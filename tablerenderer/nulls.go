@@ -0,0 +1,58 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// Null positions for Sorting.NullsPosition
+const (
+	NullsFirst = "first" // Rows with an empty sort value come first, whatever the order
+	NullsLast  = "last"  // Rows with an empty sort value come last, whatever the order
+)
+
+// nullsParam returns the query parameter carrying the nulls position
+func nullsParam(sorting *Sorting) string {
+	if sorting.NullsParam == "" {
+		return "nulls"
+	}
+	return sorting.NullsParam
+}
+
+// validNullsPosition reports whether position is NullsFirst or NullsLast
+func validNullsPosition(position string) bool {
+	return position == NullsFirst || position == NullsLast
+}
+
+// setNullsParam adds the nulls position of enabled sorting to link params
+// so following a link keeps where empty values sort
+func setNullsParam(params map[string]string, sorting *Sorting) {
+	if sorting == nil || !sorting.Enabled || !validNullsPosition(sorting.NullsPosition) {
+		return
+	}
+	params[nullsParam(sorting)] = sorting.NullsPosition
+}
+
+// ParseNullsPositionFromQuery extracts the nulls position from a URL query
+// string, returning "" when it is missing or neither "first" nor "last"
+//
+// Deprecated: use ParseState, which reads the whole table state at once
+func ParseNullsPositionFromQuery(queryString string, paramName string) string {
+	return ParseStateValues(parseQuery(queryString), StateParams{Nulls: paramName}, TableState{}).Nulls
+}
+
+// nullsIndicatorHTML renders the marker shown next to the sort icon of the
+// sorted column; themes restyle it through the nulls-first and nulls-last
+// classes
+func (r *Renderer) nullsIndicatorHTML(sorting *Sorting) template.HTML {
+	if sorting == nil || !sorting.Enabled || !validNullsPosition(sorting.NullsPosition) {
+		return ""
+	}
+	label := LabelNullsFirst
+	if sorting.NullsPosition == NullsLast {
+		label = LabelNullsLast
+	}
+	text := template.HTMLEscapeString(r.translate(label, 1))
+	return template.HTML(fmt.Sprintf(`<span class="nulls-indicator nulls-%s" title="%s" aria-label="%s">∅</span>`,
+		sorting.NullsPosition, text, text))
+}
@@ -0,0 +1,103 @@
+package tablerenderer
+
+import (
+	"html/template"
+	"strings"
+)
+
+// Plugin extends the renderer without forking it
+// Hooks run in registration order on every render; embed PluginBase to
+// implement only the hooks a plugin needs
+type Plugin interface {
+	// OnState may adjust the options of the current render before anything
+	// is computed from them. Pointer fields are shared with the caller.
+	OnState(options *TableOptions)
+	// OnColumns returns the column configuration to use for the headers
+	OnColumns(headers []string, columns []Column) []Column
+	// OnRow may modify the row values before they are formatted
+	OnRow(row *RowView)
+	// OnToolbar returns extra controls for the toolbar above the table
+	OnToolbar(options TableOptions) template.HTML
+	// OnAssets returns CSS or JS emitted once at the top of the table
+	OnAssets() template.HTML
+}
+
+// PluginBase implements every Plugin hook as a no-op
+type PluginBase struct{}
+
+// OnState implements Plugin
+func (PluginBase) OnState(options *TableOptions) {}
+
+// OnColumns implements Plugin
+func (PluginBase) OnColumns(headers []string, columns []Column) []Column { return columns }
+
+// OnRow implements Plugin
+func (PluginBase) OnRow(row *RowView) {}
+
+// OnToolbar implements Plugin
+func (PluginBase) OnToolbar(options TableOptions) template.HTML { return "" }
+
+// OnAssets implements Plugin
+func (PluginBase) OnAssets() template.HTML { return "" }
+
+// Use registers a plugin on the renderer
+func (r *Renderer) Use(p Plugin) {
+	r.plugins = append(r.plugins, p)
+}
+
+// applyStatePlugins runs the OnState hooks, once per options
+func (r *Renderer) applyStatePlugins(options *TableOptions) {
+	if options.stateApplied {
+		return
+	}
+	for _, p := range r.plugins {
+		p.OnState(options)
+	}
+	options.stateApplied = true
+}
+
+// applyColumnPlugins runs the OnColumns hooks
+func (r *Renderer) applyColumnPlugins(headers []string, columns []Column) []Column {
+	for _, p := range r.plugins {
+		columns = p.OnColumns(headers, columns)
+	}
+	return columns
+}
+
+// applyRowPlugins runs the OnRow hooks on copies of the rows, so the
+// caller's data is never modified
+func (r *Renderer) applyRowPlugins(headers []string, rows [][]interface{}) [][]interface{} {
+	if len(r.plugins) == 0 {
+		return rows
+	}
+
+	result := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		copy(values, row)
+		view := RowView{Index: i, Headers: headers, Values: values}
+		for _, p := range r.plugins {
+			p.OnRow(&view)
+		}
+		result[i] = view.Values
+	}
+	return result
+}
+
+// pluginToolbarHTML collects the OnToolbar output of all plugins
+func (r *Renderer) pluginToolbarHTML(options TableOptions) template.HTML {
+	var html strings.Builder
+	for _, p := range r.plugins {
+		html.WriteString(string(p.OnToolbar(options)))
+	}
+	return template.HTML(html.String())
+}
+
+// pluginAssetsHTML collects the OnAssets output of all plugins
+func (r *Renderer) pluginAssetsHTML() template.HTML {
+	var html strings.Builder
+	for _, p := range r.plugins {
+		html.WriteString(string(p.OnAssets()))
+	}
+	return template.HTML(html.String())
+}
@@ -0,0 +1,51 @@
+package tablerenderer
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExportPrint is the export format of the print view, served as HTML by
+// the table handlers
+const ExportPrint = "print"
+
+// RenderPrint renders the print view of a table: every row matching the
+// sort, search and filters of options, loaded from source without
+// pagination, and none of the controls. See TableOptions.Print.
+func (r *Renderer) RenderPrint(ctx context.Context, source DataSource, options TableOptions) (string, error) {
+	options.Pagination = nil
+	options.Print = true
+	data, err := source.Load(ctx, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to load table: %w", err)
+	}
+	data.Options.Pagination = nil
+	data.Options.Print = true
+	return r.RenderHTML(data)
+}
+
+// printOptions strips the options of a print view down to the table and
+// its formatting: pagination, search, sort links, selection, actions and
+// the other controls are dropped, and no scripts are emitted
+func printOptions(options *TableOptions) {
+	options.Pagination = nil
+	options.Search = nil
+	if options.Sorting != nil {
+		// Headers render without sort links; the rows keep their order
+		sorting := *options.Sorting
+		sorting.Enabled = false
+		options.Sorting = &sorting
+	}
+	options.Facets = nil
+	options.Selection = nil
+	options.Actions = nil
+	options.InlineEdit = nil
+	options.ClientSide = nil
+	options.DataTables = nil
+	options.TurboFrame = nil
+	options.QuickFilter = false
+	options.KeyboardShortcuts = false
+	options.FrozenColumns = 0
+	options.MaxHeight = ""
+	options.JSPolicy = JSNone
+}
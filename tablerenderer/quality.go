@@ -0,0 +1,77 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// CSS classes of cells flagged by TableOptions.HighlightMissing
+const (
+	cellMissingClass = "cell-missing" // Nil, empty or zero-time value
+	cellInvalidClass = "cell-invalid" // Value the column's number, currency or time format could not handle
+)
+
+// cellQualityClass returns the CSS class flagging a value as missing or
+// invalid for its column, or "" when the value is fine
+func cellQualityClass(column *Column, value interface{}) string {
+	if isEmptyValue(value) {
+		return cellMissingClass
+	}
+	if column == nil {
+		return ""
+	}
+	if column.Format != nil || column.Currency != nil {
+		number, ok := numericValue(value)
+		if !ok || math.IsNaN(number) {
+			return cellInvalidClass
+		}
+	}
+	if column.TimeLayout != "" {
+		if _, ok := timeValue(value); !ok {
+			return cellInvalidClass
+		}
+	}
+	return ""
+}
+
+// flagDataQuality sets the CSS classes of missing and invalid cells and
+// returns how many of each were found
+func flagDataQuality(rendered []tableRow, headers []string, rows [][]interface{}, columns []Column) (missing int, invalid int) {
+	configs := columnsFor(headers, columns)
+	for i, row := range rows {
+		classes := make([]string, len(row))
+		for j, value := range row {
+			var column *Column
+			if j < len(configs) {
+				column = configs[j]
+			}
+			classes[j] = cellQualityClass(column, value)
+			switch classes[j] {
+			case cellMissingClass:
+				missing++
+			case cellInvalidClass:
+				invalid++
+			}
+		}
+		rendered[i].Classes = classes
+	}
+	return missing, invalid
+}
+
+// generateDataQualityHTML renders the notice summarizing missing and
+// invalid cells, or "" when there are none
+func (r *Renderer) generateDataQualityHTML(missing int, invalid int) string {
+	var parts []string
+	if missing > 0 {
+		parts = append(parts, template.HTMLEscapeString(r.translate(LabelCellsMissing, missing, missing)))
+	}
+	if invalid > 0 {
+		parts = append(parts, template.HTMLEscapeString(r.translate(LabelCellsInvalid, invalid, invalid)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`<div class="data-quality-notice" role="status">%s</div>`, strings.Join(parts, " · "))
+}
@@ -0,0 +1,29 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// quickFilterScript hides the rows of the current page that do not contain
+// the text typed into the quick filter box, without a server round-trip
+const quickFilterScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var input=c.querySelector('.quick-filter input');if(!input)return;
+var empty=c.querySelector('.quick-filter-empty');
+input.addEventListener('input',function(){
+var q=input.value.trim().toLowerCase(),shown=0;
+c.querySelectorAll('.data-table tbody tr:not(.group-header):not(.group-subtotal)').forEach(function(tr){var hit=!q||tr.textContent.toLowerCase().indexOf(q)>=0;tr.hidden=!hit;if(hit)shown++;});
+if(empty)empty.hidden=shown>0;
+});
+})();`
+
+// generateQuickFilterHTML generates the quick filter input and the message
+// shown when it hides every row
+func (r *Renderer) generateQuickFilterHTML() (control string, emptyMessage string) {
+	label := template.HTMLEscapeString(r.translate(LabelQuickFilter, 1))
+	control = fmt.Sprintf(`<div class="quick-filter"><input type="search" placeholder="%s" aria-label="%s"></div>`, label, label)
+	emptyMessage = fmt.Sprintf(`<div class="no-results quick-filter-empty" hidden>%s</div>`,
+		template.HTMLEscapeString(r.translate(LabelQuickFilterEmpty, 1)))
+	return control, emptyMessage
+}
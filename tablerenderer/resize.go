@@ -0,0 +1,106 @@
+package tablerenderer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// minResizeWidth is the narrowest a column can be dragged, in pixels, as
+// in columnResizeScript
+const minResizeWidth = 40
+
+// columnResizeScript lets the grips of a resizable table set the width of
+// their header cell by dragging. Widths are kept as "Header:px" pairs, e.g.
+// "Name:120,Email:240": in localStorage under "table-widths:" plus the
+// table ID or page path, and in the query parameter named by the table's
+// data-widths-param attribute, which ParseState reads and the server renders
+// as data-width attributes the script applies. Saving also rewrites that parameter of the
+// table's links to the page and of its GET forms, so sorting or paging
+// keeps the dragged widths. Stored widths apply when the page has none.
+// The first drag fixes the other columns at their current widths. Styles are only set through
+// the DOM, so it runs under a CSP without 'unsafe-inline' styles.
+const columnResizeScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var t=c.querySelector('table.data-table[data-resizable]');if(!t)return;
+var key='table-widths:'+(t.getAttribute('data-resizable')||location.pathname),wp=t.getAttribute('data-widths-param')||'widths';
+var ths=[].slice.call(t.querySelectorAll('thead th[data-header]')),rtl=getComputedStyle(t).direction==='rtl';
+function parse(s){var w={};(s||'').split(',').forEach(function(p){var i=p.lastIndexOf(':'),n=parseInt(p.slice(i+1),10);if(i>0&&n>0)w[p.slice(0,i)]=n;});return w;}
+function apply(w){var any=false;ths.forEach(function(th){var n=w[th.getAttribute('data-header')];if(n>0){th.style.width=n+'px';any=true;}});if(any)t.style.tableLayout='fixed';}
+var widths={};ths.forEach(function(th){var n=parseInt(th.getAttribute('data-width'),10);if(n>0)widths[th.getAttribute('data-header')]=n;});
+if(!Object.keys(widths).length){try{widths=parse(localStorage.getItem(key));}catch(e){}}
+apply(widths);
+function save(){var w={};ths.forEach(function(th){if(th.style.width)w[th.getAttribute('data-header')]=Math.round(th.getBoundingClientRect().width);});
+var s=Object.keys(w).sort().map(function(h){return h+':'+w[h];}).join(',');
+try{localStorage.setItem(key,s);}catch(e){}
+var u=new URL(location.href);u.searchParams.set(wp,s);history.replaceState(history.state,'',u);
+relink(s);
+c.dispatchEvent(new CustomEvent('table:columns-resized',{detail:w}));}
+function relink(s){
+c.querySelectorAll('a[href],select[data-table-navigate] option[value]').forEach(function(el){var a=el.tagName==='A'?'href':'value',v=el.getAttribute(a);if(!v)return;
+var u=new URL(v,location.href);if(u.origin!==location.origin||(u.pathname!==location.pathname&&!u.searchParams.has(wp)))return;
+u.searchParams.set(wp,s);el.setAttribute(a,u.pathname+u.search+u.hash);});
+c.querySelectorAll('form').forEach(function(f){if(f.method!=='get')return;var i=f.querySelector('input[name="'+CSS.escape(wp)+'"]');
+if(!i){i=document.createElement('input');i.type='hidden';i.name=wp;f.appendChild(i);}i.value=s;});}
+t.addEventListener('pointerdown',function(e){
+var g=e.target.closest('.resize-grip');if(!g||!t.contains(g))return;
+e.preventDefault();e.stopPropagation();
+var th=g.closest('th'),x=e.clientX,start=th.getBoundingClientRect().width;
+if(t.style.tableLayout!=='fixed'){ths.forEach(function(h){h.style.width=h.getBoundingClientRect().width+'px';});t.style.tableLayout='fixed';}
+g.setPointerCapture(e.pointerId);
+function move(m){var dx=rtl?x-m.clientX:m.clientX-x;th.style.width=Math.max(40,Math.round(start+dx))+'px';}
+function up(){g.removeEventListener('pointermove',move);g.removeEventListener('pointerup',up);g.removeEventListener('pointercancel',up);save();}
+g.addEventListener('pointermove',move);g.addEventListener('pointerup',up);g.addEventListener('pointercancel',up);
+});
+t.addEventListener('click',function(e){if(e.target.closest('.resize-grip'))e.preventDefault();});
+})();`
+
+// ParseColumnWidths parses column widths in the "Header:px" form of the
+// widths parameter, e.g. "Name:120,Email:240". Malformed pairs and widths
+// below the minimum are skipped.
+func ParseColumnWidths(value string) map[string]int {
+	widths := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 {
+			continue
+		}
+		width, err := strconv.Atoi(pair[i+1:])
+		if err != nil || width < minResizeWidth {
+			continue
+		}
+		widths[pair[:i]] = width
+	}
+	if len(widths) == 0 {
+		return nil
+	}
+	return widths
+}
+
+// formatColumnWidths formats column widths as ParseColumnWidths reads them,
+// ordered by header
+func formatColumnWidths(widths map[string]int) string {
+	pairs := make([]string, 0, len(widths))
+	for header, width := range widths {
+		pairs = append(pairs, header+":"+strconv.Itoa(width))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// setWidthsParam adds the column widths of options to the parameters
+// preserved in links, under the configured widths parameter
+func setWidthsParam(params map[string]string, options TableOptions) {
+	if len(options.ColumnWidths) > 0 {
+		params[stateParams(options).withDefaults().Widths] = formatColumnWidths(options.ColumnWidths)
+	}
+}
+
+// headerWidths returns the width of each header, 0 where none is set
+func headerWidths(headers []string, widths map[string]int) []int {
+	result := make([]int, len(headers))
+	for i, header := range headers {
+		result[i] = widths[header]
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package tablerenderer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseColumnWidths(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  map[string]int
+	}{
+		{"pairs", "Name:120,Email:240", map[string]int{"Name": 120, "Email": 240}},
+		{"header with a colon", "Due: date:80", map[string]int{"Due: date": 80}},
+		{"malformed and narrow pairs skipped", "Name,Email:x,Age:10,ID:60", map[string]int{"ID": 60}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseColumnWidths(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseColumnWidths(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResizableWidthsParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		param     string
+		wantAttr  string
+		wantParam string
+	}{
+		{"default", "", `data-widths-param="widths"`, "widths=Name%3A120"},
+		{"configured", "w", `data-widths-param="w"`, "w=Name%3A120"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewRenderer().RenderHTML(DatabasePaginatedData{
+				Headers:    []string{"Name"},
+				Rows:       [][]interface{}{{"Ada"}},
+				TotalCount: 30,
+				Options: TableOptions{
+					ResizableColumns: true,
+					ColumnWidths:     map[string]int{"Name": 120},
+					WidthsParam:      tt.param,
+					Pagination:       &Pagination{Enabled: true, CurrentPage: 1, PageSize: 10, TotalCount: 30, ShowControls: true, BaseURL: "/users"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			if !strings.Contains(html, tt.wantAttr) {
+				t.Errorf("missing %s in:\n%s", tt.wantAttr, html)
+			}
+			link := pageTwoLink.FindStringSubmatch(html)
+			if link == nil || !strings.Contains(link[1], tt.wantParam) {
+				t.Errorf("page link %q does not keep %s", link, tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestParseStateWidthsParam(t *testing.T) {
+	options := TableOptions{WidthsParam: "w"}
+	state := ParseStateValues(parseQuery("w=Name:120&widths=Name:200"), stateParams(options), TableState{})
+	if want := map[string]int{"Name": 120}; !reflect.DeepEqual(state.Widths, want) {
+		t.Errorf("Widths = %v, want %v", state.Widths, want)
+	}
+}
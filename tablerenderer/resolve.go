@@ -0,0 +1,173 @@
+package tablerenderer
+
+// ResolvedTable is the logical table RenderHTML produces for the same data
+// and options: the merged columns, rows, state, links and labels, without
+// the markup around them
+type ResolvedTable struct {
+	Columns []ResolvedColumn  `json:"columns"`
+	Rows    []ResolvedRow     `json:"rows"`
+	State   ResolvedState     `json:"state"`
+	Links   Links             `json:"links"`
+	Labels  map[string]string `json:"labels"` // Translated labels shown around the table, by label key
+}
+
+// ResolvedColumn is a data column with its merged configuration
+type ResolvedColumn struct {
+	Header    string  `json:"header"`
+	Content   string  `json:"content"`              // Header cell content as HTML, after the header template
+	Config    *Column `json:"config,omitempty"`     // Configuration after plugins, nil when the column has none
+	Sorted    bool    `json:"sorted,omitempty"`     // Whether the table is sorted by this column
+	SortOrder string  `json:"sort_order,omitempty"` // Current sort order when Sorted: "asc" or "desc"
+	SortURL   string  `json:"sort_url,omitempty"`   // Link of the header cell when sorting is enabled
+	Frozen    bool    `json:"frozen,omitempty"`     // Kept visible when scrolling horizontally
+}
+
+// ResolvedRow is a row of the current page
+type ResolvedRow struct {
+	Number     int           `json:"number"`               // Position of the row across all pages
+	Values     []interface{} `json:"values"`               // Values after plugins and the row filter, before formatting
+	Cells      []string      `json:"cells"`                // Cell content as HTML
+	Classes    []string      `json:"classes,omitempty"`    // CSS class of each cell, "" for none
+	Attributes string        `json:"attributes,omitempty"` // Attributes of the <tr> element, e.g. a row link
+	Actions    string        `json:"actions,omitempty"`    // Actions cell content as HTML
+	SelectID   string        `json:"select_id,omitempty"`  // Value of the selection checkbox
+	Selected   bool          `json:"selected,omitempty"`   // Whether the selection checkbox is checked
+}
+
+// ResolvedState is the pagination, sorting and search state of a table
+type ResolvedState struct {
+	Pagination PaginationInfo `json:"pagination"`
+	SortBy     string         `json:"sort_by,omitempty"`
+	SortOrder  string         `json:"sort_order,omitempty"`
+	SearchTerm string         `json:"search_term,omitempty"`
+	Nulls      string         `json:"nulls,omitempty"` // Nulls position of the sort: "first" or "last"
+}
+
+// Resolve runs the same steps as RenderHTML for data rendered with opts,
+// which take the place of data.Options, and returns the resulting table
+// instead of HTML. Tests and debugging tools can assert on it rather than
+// matching markup.
+func (r *Renderer) Resolve(data DatabasePaginatedData, opts TableOptions) (ResolvedTable, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &opts)
+	if err != nil {
+		return ResolvedTable{}, err
+	}
+	headers, rows, sources := extractSource(headers, rows, opts.Source)
+	paginationInfo := r.calculatePagination(len(rows), opts.Pagination)
+
+	headerContents, err := r.renderHeaderContents(headers, opts.Columns, opts.Sorting)
+	if err != nil {
+		return ResolvedTable{}, err
+	}
+
+	resolved := ResolvedTable{
+		State:  ResolvedState{Pagination: paginationInfo},
+		Links:  r.generateLinks(headers, opts, paginationInfo),
+		Labels: r.resolveLabels(opts, paginationInfo),
+	}
+
+	if opts.Sorting != nil && opts.Sorting.Enabled {
+		resolved.State.SortBy = opts.Sorting.SortBy
+		resolved.State.SortOrder = opts.Sorting.SortOrder
+		if validNullsPosition(opts.Sorting.NullsPosition) {
+			resolved.State.Nulls = opts.Sorting.NullsPosition
+		}
+	}
+	if opts.Search != nil && opts.Search.Enabled {
+		resolved.State.SearchTerm = opts.Search.SearchTerm
+	}
+
+	frozen := frozenDataColumns(opts)
+	configs := columnsFor(headers, opts.Columns)
+	for i, header := range headers {
+		column := ResolvedColumn{
+			Header:  header,
+			Content: string(headerContents[i]),
+			Config:  configs[i],
+			SortURL: resolved.Links.Sort[header],
+			Frozen:  i < frozen,
+		}
+		if resolved.State.SortBy == header {
+			column.Sorted = true
+			column.SortOrder = resolved.State.SortOrder
+		}
+		resolved.Columns = append(resolved.Columns, column)
+	}
+
+	rendered, _, _ := r.decorateRows(headers, rows, sources, opts, paginationInfo.StartRow)
+	for i, row := range rendered {
+		cells := make([]string, len(row.Cells))
+		for j, cell := range row.Cells {
+			cells[j] = cellHTML(cell)
+		}
+		resolved.Rows = append(resolved.Rows, ResolvedRow{
+			Number:     row.Number,
+			Values:     rows[i],
+			Cells:      cells,
+			Classes:    row.Classes,
+			Attributes: string(row.Attributes),
+			Actions:    string(row.Actions),
+			SelectID:   row.SelectID,
+			Selected:   row.Selected,
+		})
+	}
+
+	return resolved, nil
+}
+
+// resolveLabels returns the translated labels a render with options shows
+func (r *Renderer) resolveLabels(options TableOptions, paginationInfo PaginationInfo) map[string]string {
+	labels := map[string]string{
+		LabelNoRecords: r.translate(LabelNoRecords, 0),
+	}
+	if options.Pagination != nil && options.Pagination.Enabled {
+		labels[LabelPrevious] = r.translate(LabelPrevious, 1)
+		labels[LabelNext] = r.translate(LabelNext, 1)
+		if paginationInfo.Uncounted && paginationInfo.TotalRows > 0 {
+			count := paginationInfo.EndRow - paginationInfo.StartRow + 1
+			labels[LabelShowingCount] = r.translate(LabelShowingCount, count, count)
+		} else if paginationInfo.Approximate {
+			labels[LabelShowingEstimate] = r.translate(LabelShowingEstimate, paginationInfo.TotalRows,
+				paginationInfo.StartRow, paginationInfo.EndRow, formatApproximateCount(paginationInfo.TotalRows))
+		} else if paginationInfo.TotalRows > 0 {
+			labels[LabelShowingEntries] = r.translate(LabelShowingEntries, paginationInfo.TotalRows,
+				paginationInfo.StartRow, paginationInfo.EndRow, paginationInfo.TotalRows)
+		}
+		if options.Pagination.ShowPageSizer {
+			labels[LabelShow] = r.translate(LabelShow, 1)
+		}
+	}
+	if options.Sorting != nil && options.Sorting.Enabled {
+		switch options.Sorting.NullsPosition {
+		case NullsFirst:
+			labels[LabelNullsFirst] = r.translate(LabelNullsFirst, 1)
+		case NullsLast:
+			labels[LabelNullsLast] = r.translate(LabelNullsLast, 1)
+		}
+	}
+	if options.Search != nil && options.Search.Enabled {
+		labels[LabelSearch] = r.translate(LabelSearch, 1)
+		labels[LabelSearchPlaceholder] = options.Search.Placeholder
+		if labels[LabelSearchPlaceholder] == "" {
+			labels[LabelSearchPlaceholder] = r.translate(LabelSearchPlaceholder, 1)
+		}
+		labels[LabelSearchButton] = r.translate(LabelSearchButton, 1)
+	}
+	if options.RowNumbers {
+		labels[LabelRowNumber] = r.translate(LabelRowNumber, 1)
+	}
+	if options.Selection != nil && options.Selection.Enabled {
+		labels[LabelSelectAll] = r.translate(LabelSelectAll, 1)
+		labels[LabelSelectRow] = r.translate(LabelSelectRow, 1)
+	}
+	if options.Actions != nil && len(options.Actions.Items) > 0 {
+		labels[LabelActions] = options.Actions.Header
+		if labels[LabelActions] == "" {
+			labels[LabelActions] = r.translate(LabelActions, 1)
+		}
+	}
+	if options.QuickFilter && scriptsAllowed(options) {
+		labels[LabelQuickFilter] = r.translate(LabelQuickFilter, 1)
+	}
+	return labels
+}
@@ -0,0 +1,20 @@
+package tablerenderer
+
+import "strconv"
+
+// Responsive priorities of Column.Priority. Columns of priority 2 to 6 are
+// hidden below the viewport widths of the priority-N classes in the table
+// CSS: 480, 640, 800, 960 and 1120px.
+const (
+	PriorityEssential = 1 // Always shown
+	PriorityLowest    = 6 // Hidden first as the viewport narrows
+)
+
+// priorityClass returns the responsive class of a column, or "" when it is
+// always shown
+func priorityClass(column *Column) string {
+	if column == nil || column.Priority <= PriorityEssential {
+		return ""
+	}
+	return "priority-" + strconv.Itoa(min(column.Priority, PriorityLowest))
+}
@@ -0,0 +1,82 @@
+package tablerenderer
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Rounding modes for Column.Rounding
+const (
+	RoundHalfUp   = "half_up"   // Ties round away from zero: 2.5 -> 3, -2.5 -> -3
+	RoundHalfEven = "half_even" // Ties round to the even digit (bankers' rounding): 2.5 -> 2, 3.5 -> 4
+)
+
+// roundFloat rounds x to the given number of decimals with the rounding
+// mode, treating x as the decimal it prints as so that 2.675 rounds to 2.68
+// rather than to the 2.67 its binary approximation would give. decimals
+// comes from columnPrecision and is never negative.
+func roundFloat(x float64, decimals int, mode string) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	value, ok := new(big.Rat).SetString(strconv.FormatFloat(x, 'f', -1, 64))
+	if !ok {
+		return x
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value.Mul(value, new(big.Rat).SetInt(scale))
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	half := remainder.Abs(remainder).Lsh(remainder, 1).Cmp(value.Denom())
+	if half > 0 || (half == 0 && (mode != RoundHalfEven || quotient.Bit(0) == 1)) {
+		if value.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	rounded, _ := new(big.Rat).SetFrac(quotient, scale).Float64()
+	return rounded
+}
+
+// columnPrecision returns the decimals of the column's Precision, negative
+// values counting as 0, and whether it has one
+func columnPrecision(column *Column) (int, bool) {
+	if column == nil || column.Precision == nil {
+		return 0, false
+	}
+	return max(*column.Precision, 0), true
+}
+
+// roundValue applies the column's precision to floating point values,
+// returning the rounded float64 and true, and leaves other values unchanged
+func roundValue(column *Column, value interface{}) (interface{}, bool) {
+	precision, ok := columnPrecision(column)
+	if !ok {
+		return value, false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32:
+		// Widen through the shortest float32 text so 0.1 stays 0.1
+		x, _ := strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'f', -1, 32), 64)
+		return roundFloat(x, precision, column.Rounding), true
+	case reflect.Float64:
+		return roundFloat(v.Float(), precision, column.Rounding), true
+	}
+	return value, false
+}
+
+// precisionFormat returns the column's number format with its decimals set
+// to the column's precision
+func precisionFormat(column *Column) *NumberFormat {
+	format := NumberFormat{}
+	if column.Format != nil {
+		format = *column.Format
+	}
+	format.Decimals, _ = columnPrecision(column)
+	return &format
+}
@@ -0,0 +1,63 @@
+package tablerenderer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        float64
+		decimals int
+		mode     string
+		want     float64
+	}{
+		{"half up tie", 2.5, 0, RoundHalfUp, 3},
+		{"half up negative tie", -2.5, 0, RoundHalfUp, -3},
+		{"half even tie down", 2.5, 0, RoundHalfEven, 2},
+		{"half even tie up", 3.5, 0, RoundHalfEven, 4},
+		{"half even negative tie", -2.5, 0, RoundHalfEven, -2},
+		{"default mode is half up", 0.125, 2, "", 0.13},
+		{"decimal not binary value", 2.675, 2, RoundHalfUp, 2.68},
+		{"half even decimals", 0.125, 2, RoundHalfEven, 0.12},
+		{"below half", 1.2344, 3, RoundHalfUp, 1.234},
+		{"above half", 1.2346, 3, RoundHalfEven, 1.235},
+		{"infinity unchanged", math.Inf(1), 2, RoundHalfUp, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundFloat(tt.x, tt.decimals, tt.mode); got != tt.want {
+				t.Errorf("roundFloat(%v, %d, %q) = %v, want %v", tt.x, tt.decimals, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundValue(t *testing.T) {
+	precision := func(n int) *int { return &n }
+	tests := []struct {
+		name        string
+		column      *Column
+		value       interface{}
+		want        interface{}
+		wantRounded bool
+	}{
+		{"no column", nil, 1.25, 1.25, false},
+		{"no precision", &Column{}, 1.25, 1.25, false},
+		{"float64", &Column{Precision: precision(1)}, 1.25, 1.3, true},
+		{"float64 half even", &Column{Precision: precision(1), Rounding: RoundHalfEven}, 1.25, 1.2, true},
+		{"float32 keeps its short form", &Column{Precision: precision(1)}, float32(0.15), 0.2, true},
+		{"negative precision counts as 0", &Column{Precision: precision(-2)}, 1.5, 2.0, true},
+		{"integers unchanged", &Column{Precision: precision(1)}, 7, 7, false},
+		{"text unchanged", &Column{Precision: precision(1)}, "1.25", "1.25", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rounded := roundValue(tt.column, tt.value)
+			if got != tt.want || rounded != tt.wantRounded {
+				t.Errorf("roundValue() = %v, %v, want %v, %v", got, rounded, tt.want, tt.wantRounded)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// dataAttributeName converts a header or key to a data-* attribute name,
+// e.g. "Customer ID" to "data-customer-id", or "" when nothing is left
+func dataAttributeName(name string) string {
+	var attr strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(name) {
+		if ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			if dash && attr.Len() > 0 {
+				attr.WriteByte('-')
+			}
+			attr.WriteRune(c)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if attr.Len() == 0 {
+		return ""
+	}
+	return "data-" + attr.String()
+}
+
+// rowDataAttributes renders the data-* attributes of a row from the
+// configured headers and callback
+func rowDataAttributes(options TableOptions, row RowView) string {
+	values := make(map[string]string)
+	for _, header := range options.RowData {
+		if name := dataAttributeName(header); name != "" {
+			values[name] = exportValue(row.Get(header))
+		}
+	}
+	if options.RowDataFunc != nil {
+		for key, value := range options.RowDataFunc(row) {
+			if name := dataAttributeName(key); name != "" {
+				values[name] = value
+			}
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := make([]string, len(names))
+	for i, name := range names {
+		attrs[i] = fmt.Sprintf(`%s="%s"`, name, template.HTMLEscapeString(values[name]))
+	}
+	return strings.Join(attrs, " ")
+}
+
+// applyRowData adds the data-* attributes to the rendered rows
+func applyRowData(rendered []tableRow, headers []string, rows [][]interface{}, options TableOptions) {
+	if len(options.RowData) == 0 && options.RowDataFunc == nil {
+		return
+	}
+	for i := range rendered {
+		attrs := rowDataAttributes(options, RowView{Index: i, Headers: headers, Values: rows[i]})
+		if attrs == "" {
+			continue
+		}
+		if rendered[i].Attributes != "" {
+			attrs = string(rendered[i].Attributes) + " " + attrs
+		}
+		rendered[i].Attributes = template.HTMLAttr(attrs)
+	}
+}
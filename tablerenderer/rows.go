@@ -0,0 +1,33 @@
+package tablerenderer
+
+// RowView gives read access to a row's values by header
+type RowView struct {
+	Index   int           // Position of the row in the data being rendered
+	Headers []string      // Headers of the table
+	Values  []interface{} // Row values in header order
+}
+
+// Get returns the value for a header, or nil if the header is unknown
+func (v RowView) Get(header string) interface{} {
+	for i, h := range v.Headers {
+		if h == header && i < len(v.Values) {
+			return v.Values[i]
+		}
+	}
+	return nil
+}
+
+// filterRows returns the rows accepted by filter, or all rows when filter is nil
+func filterRows(headers []string, rows [][]interface{}, filter func(row RowView) bool) [][]interface{} {
+	if filter == nil {
+		return rows
+	}
+
+	filtered := make([][]interface{}, 0, len(rows))
+	for i, row := range rows {
+		if filter(RowView{Index: i, Headers: headers, Values: row}) {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
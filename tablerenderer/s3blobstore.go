@@ -0,0 +1,348 @@
+package tablerenderer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3BlobStore stores blobs in an S3-compatible bucket using path-style
+// requests signed with AWS Signature Version 4. It works with Amazon S3,
+// Google Cloud Storage (see NewGCSBlobStore) and other compatible stores
+// such as MinIO, without pulling in a cloud SDK. Uploads use a single PUT,
+// which limits artifacts to 5 GB.
+type S3BlobStore struct {
+	Endpoint        string       // Service endpoint (default: "https://s3.<Region>.amazonaws.com")
+	Region          string       // Signing region, e.g. "us-east-1"
+	Bucket          string       // Bucket name
+	Prefix          string       // Key prefix for all blobs, e.g. "exports/"
+	AccessKeyID     string       // Access key
+	SecretAccessKey string       // Secret key
+	SessionToken    string       // Session token for temporary credentials
+	Client          *http.Client // HTTP client (default: http.DefaultClient)
+}
+
+// NewS3BlobStore creates a blob store for an Amazon S3 bucket
+func NewS3BlobStore(region string, bucket string, accessKeyID string, secretAccessKey string) *S3BlobStore {
+	return &S3BlobStore{
+		Region:          region,
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+}
+
+// NewGCSBlobStore creates a blob store for a Google Cloud Storage bucket,
+// using the XML API interoperability endpoint with an HMAC key
+func NewGCSBlobStore(bucket string, hmacAccessID string, hmacSecret string) *S3BlobStore {
+	return &S3BlobStore{
+		Endpoint:        "https://storage.googleapis.com",
+		Region:          "auto",
+		Bucket:          bucket,
+		AccessKeyID:     hmacAccessID,
+		SecretAccessKey: hmacSecret,
+	}
+}
+
+// Put implements BlobStore; content is spooled to a temporary file because
+// a single PUT needs to know the content length up front
+func (s *S3BlobStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	spool, err := os.CreateTemp("", "tablerenderer-blob-*")
+	if err != nil {
+		return fmt.Errorf("failed to spool blob: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	digest := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, digest), content)
+	if err != nil {
+		return fmt.Errorf("failed to spool blob: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to spool blob: %w", err)
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, spool)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, hex.EncodeToString(digest.Sum(nil)))
+	if err != nil {
+		return fmt.Errorf("failed to upload blob: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Open implements BlobStore; the object is fetched lazily with ranged GET
+// requests, so seeking (e.g. to serve a resumed download) is cheap
+func (s *S3BlobStore) Open(ctx context.Context, key string) (Blob, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	resp.Body.Close()
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &s3Blob{
+		store: s,
+		ctx:   ctx,
+		key:   key,
+		artifact: ExportArtifact{
+			Name:        path.Base(key),
+			ContentType: resp.Header.Get("Content-Type"),
+			Size:        resp.ContentLength,
+			ModTime:     modTime,
+		},
+	}, nil
+}
+
+// Delete implements BlobStore
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// newRequest builds a path-style request for a blob key
+func (s *S3BlobStore) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	cleaned, err := cleanBlobKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.Region)
+	}
+	objectPath := "/" + s.Bucket + "/" + s.Prefix + cleaned
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(endpoint, "/")+uriEncode(objectPath, false), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob request: %w", err)
+	}
+	return req, nil
+}
+
+// do signs and sends a request, turning error statuses into errors
+func (s *S3BlobStore) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, time.Now().UTC())
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to a request
+func (s *S3BlobStore) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	// Sign the host, range and all x-amz-* headers
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "range" || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the sorted, encoded query string of a request
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters, keeping
+// "/" unless encodeSlash is set, as Signature Version 4 requires
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// hmacSHA256 computes HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Blob reads an object with ranged GET requests starting at the current
+// offset
+type s3Blob struct {
+	store    *S3BlobStore
+	ctx      context.Context
+	key      string
+	artifact ExportArtifact
+	offset   int64
+	body     io.ReadCloser
+}
+
+// Read implements io.Reader
+func (b *s3Blob) Read(p []byte) (int, error) {
+	if b.offset >= b.artifact.Size {
+		return 0, io.EOF
+	}
+	if b.body == nil {
+		req, err := b.store.newRequest(b.ctx, http.MethodGet, b.key, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(b.offset, 10)+"-")
+		resp, err := b.store.do(req, emptyPayloadHash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read blob: %w", err)
+		}
+		b.body = resp.Body
+	}
+
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	return n, err
+}
+
+// Seek implements io.Seeker; the next Read starts a new ranged request
+func (b *s3Blob) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = b.offset + offset
+	case io.SeekEnd:
+		next = b.artifact.Size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("negative position")
+	}
+	if next != b.offset && b.body != nil {
+		b.body.Close()
+		b.body = nil
+	}
+	b.offset = next
+	return next, nil
+}
+
+// Close implements io.Closer
+func (b *s3Blob) Close() error {
+	if b.body == nil {
+		return nil
+	}
+	err := b.body.Close()
+	b.body = nil
+	return err
+}
+
+// Artifact implements Blob
+func (b *s3Blob) Artifact() ExportArtifact {
+	return b.artifact
+}
@@ -0,0 +1,54 @@
+package tablerenderer
+
+import (
+	"html/template"
+)
+
+// JSPolicy controls whether the renderer may emit JavaScript
+type JSPolicy string
+
+// JavaScript policies
+const (
+	JSInline JSPolicy = "inline" // Inline scripts are allowed (default)
+	JSNone   JSPolicy = "none"   // No JavaScript is emitted; script-based enhancements are skipped
+)
+
+// scriptsAllowed reports whether script-based enhancements may be rendered
+func scriptsAllowed(options TableOptions) bool {
+	return options.JSPolicy != JSNone
+}
+
+// rowScripts returns the scripts the data rows need: navigation of row
+// links and the offsets of frozen columns
+func rowScripts(options TableOptions) template.HTML {
+	if !scriptsAllowed(options) {
+		return ""
+	}
+	var scripts template.HTML
+	if hasRowLinks(options.Columns) {
+		scripts += scriptTag(options, rowLinkScript)
+	}
+	if frozenDataColumns(options) > 0 {
+		scripts += scriptTag(options, frozenColumnsScript)
+	}
+	return scripts
+}
+
+// scriptTag wraps trusted JavaScript in a script element carrying the
+// nonce of options. With OmitScripts, or in CSP mode without a nonce, it
+// renders a marker that TableScript runs the script for instead.
+func scriptTag(options TableOptions, js string) template.HTML {
+	if options.OmitScripts || (options.CSP && options.Nonce == "") {
+		return template.HTML(`<template data-table-script="` + tableScriptName(js) + `"></template>`)
+	}
+	return template.HTML("<script" + nonceAttr(options.Nonce) + ">" + js + "</script>")
+}
+
+// nonceAttr returns the nonce attribute of script and style elements, or ""
+// without a nonce
+func nonceAttr(nonce string) string {
+	if nonce == "" {
+		return ""
+	}
+	return ` nonce="` + template.HTMLEscapeString(nonce) + `"`
+}
@@ -0,0 +1,111 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Selection holds configuration for the checkbox column used to select rows
+// for bulk operations
+type Selection struct {
+	Enabled   bool           `json:"enabled"`
+	IDColumn  string         `json:"id_column,omitempty"`  // Header whose value is submitted for a selected row (default: the row number)
+	InputName string         `json:"input_name,omitempty"` // Name of the checkboxes (default: "selected")
+	Selected  []string       `json:"selected,omitempty"`   // IDs of the rows checked initially
+	Form      *SelectionForm `json:"form,omitempty"`       // Form the checkboxes submit with (default: none, wrap the table in your own form)
+
+	// BulkActions are buttons of a bar above the table, each submitting the
+	// selected IDs to its endpoint, e.g. DeleteSelected and ExportSelected.
+	// They belong to the selection form, which they add when Form is nil;
+	// its hidden fields, e.g. a CSRF token, go with every action.
+	BulkActions []BulkAction `json:"bulk_actions,omitempty"`
+}
+
+// SelectionForm is a form rendered before the table that the selection
+// checkboxes belong to through their form attribute, so it can be submitted
+// without nesting the table inside it
+type SelectionForm struct {
+	ID     string            `json:"id,omitempty"`     // Form ID (default: "<table ID>-selection" or "table-selection")
+	Action string            `json:"action"`           // URL the selection is submitted to
+	Method string            `json:"method,omitempty"` // "GET" or "POST" (default: "POST")
+	Hidden map[string]string `json:"hidden,omitempty"` // Hidden fields, e.g. a CSRF token
+}
+
+// selectAllScript connects the header checkbox to the row checkboxes of the
+// enclosing table container, showing a mixed state for partial selections
+const selectAllScript = `(function(){
+var c=document.currentScript.closest('.table-container');if(!c)return;
+var all=c.querySelector('.select-all');if(!all)return;
+var boxes=function(){return c.querySelectorAll('.select-row');};
+var sync=function(){var n=0,b=boxes();b.forEach(function(x){if(x.checked)n++;});all.checked=n>0&&n===b.length;all.indeterminate=n>0&&n<b.length;};
+all.addEventListener('change',function(){boxes().forEach(function(x){x.checked=all.checked;});});
+c.addEventListener('change',function(e){if(e.target.classList.contains('select-row'))sync();});
+sync();
+})();`
+
+// selectionInputName returns the name of the selection checkboxes
+func selectionInputName(selection *Selection) string {
+	if selection.InputName != "" {
+		return selection.InputName
+	}
+	return "selected"
+}
+
+// selectionFormID returns the ID of the selection form, or "" when the
+// selection has no form
+func selectionFormID(selection *Selection, tableID string) string {
+	if selection.Form == nil && len(selection.BulkActions) == 0 {
+		return ""
+	}
+	if selection.Form != nil && selection.Form.ID != "" {
+		return selection.Form.ID
+	}
+	if tableID != "" {
+		return tableID + "-selection"
+	}
+	return "table-selection"
+}
+
+// selectionID returns the value submitted when a row is selected
+func selectionID(selection *Selection, row RowView, number int) string {
+	if selection.IDColumn != "" {
+		return exportValue(row.Get(selection.IDColumn))
+	}
+	return strconv.Itoa(number)
+}
+
+// generateSelectionFormHTML renders the form the selection checkboxes
+// belong to, with the bulk actions bar
+func (r *Renderer) generateSelectionFormHTML(selection *Selection, formID string) string {
+	if formID == "" {
+		return ""
+	}
+	form := selection.Form
+	if form == nil {
+		form = &SelectionForm{}
+	}
+
+	method := strings.ToUpper(form.Method)
+	if method != "GET" {
+		method = "POST"
+	}
+
+	var html strings.Builder
+	html.WriteString(fmt.Sprintf(`<form id="%s" method="%s" action="%s" class="selection-form">`,
+		template.HTMLEscapeString(formID), method, template.HTMLEscapeString(form.Action)))
+	names := make([]string, 0, len(form.Hidden))
+	for name := range form.Hidden {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+			template.HTMLEscapeString(name), template.HTMLEscapeString(form.Hidden[name])))
+	}
+	html.WriteString(r.generateBulkActionsHTML(selection.BulkActions))
+	html.WriteString(`</form>`)
+	return html.String()
+}
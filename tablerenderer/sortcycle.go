@@ -0,0 +1,33 @@
+package tablerenderer
+
+// Header click cycles for Sorting.Cycle
+const (
+	SortCycleTwoState   = "two_state"   // asc -> desc -> asc
+	SortCycleThreeState = "three_state" // asc -> desc -> unsorted
+)
+
+// nextSortOrder returns the order a click on the header sets, or "" when
+// the click clears sorting. Columns with a fixed SortOrder always sort that
+// way, and with the three-state cycle a second click clears it.
+func nextSortOrder(sorting *Sorting, column *Column, header string) string {
+	sorted := sorting.SortBy == header
+	threeState := sorting.Cycle == SortCycleThreeState
+
+	if column != nil && (column.SortOrder == "asc" || column.SortOrder == "desc") {
+		if sorted && threeState {
+			return ""
+		}
+		return column.SortOrder
+	}
+
+	switch {
+	case !sorted:
+		return "asc"
+	case sorting.SortOrder == "asc":
+		return "desc"
+	case threeState:
+		return ""
+	default:
+		return "asc"
+	}
+}
@@ -0,0 +1,77 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// SourceFormat shows where each row came from, e.g. the upstream system of
+// a record, taken from a field that is not rendered as a column
+type SourceFormat struct {
+	Header  string       `json:"header"`            // Field holding the source; it is removed from the visible columns
+	Tooltip bool         `json:"tooltip,omitempty"` // Show the source as the row's tooltip instead of a badge in its first cell
+	Badges  *BadgeFormat `json:"badges,omitempty"`  // Badge per source value (default: gray badge with the value)
+}
+
+// extractSource removes the source field from the headers and rows and
+// returns the source value of each row
+func extractSource(headers []string, rows [][]interface{}, source *SourceFormat) ([]string, [][]interface{}, []interface{}) {
+	if source == nil || source.Header == "" {
+		return headers, rows, nil
+	}
+	index := -1
+	for i, header := range headers {
+		if header == source.Header {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return headers, rows, nil
+	}
+
+	visible := append(append([]string{}, headers[:index]...), headers[index+1:]...)
+	sources := make([]interface{}, len(rows))
+	trimmed := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		if index >= len(row) {
+			trimmed[i] = row
+			continue
+		}
+		sources[i] = row[index]
+		trimmed[i] = append(append([]interface{}{}, row[:index]...), row[index+1:]...)
+	}
+	return visible, trimmed, sources
+}
+
+// applySource adds the source of each row as a badge in its first cell or
+// as its tooltip. With classes, badge colors are set by classes, as in CSP
+// mode.
+func applySource(rendered []tableRow, sources []interface{}, source *SourceFormat, classes bool) {
+	for i, value := range sources {
+		if isEmptyValue(value) || i >= len(rendered) {
+			continue
+		}
+		text := exportValue(value)
+
+		if source.Tooltip || len(rendered[i].Cells) == 0 {
+			attr := fmt.Sprintf(`title="%s" data-source="%s"`, template.HTMLEscapeString(text), template.HTMLEscapeString(text))
+			if rendered[i].Attributes != "" {
+				attr = string(rendered[i].Attributes) + " " + attr
+			}
+			rendered[i].Attributes = template.HTMLAttr(attr)
+			continue
+		}
+
+		badges := source.Badges
+		if badges == nil {
+			badges = &BadgeFormat{}
+		}
+		badge, ok := formatBadge(value, badges, classes)
+		if !ok {
+			badge, _ = formatBadge(value, &BadgeFormat{Default: &Badge{Color: "gray"}}, classes)
+		}
+		rendered[i].Cells[0] = template.HTML(fmt.Sprintf(`<span class="source-badge" title="%s">%s</span> %s`,
+			template.HTMLEscapeString(text), badge, cellHTML(rendered[i].Cells[0])))
+	}
+}
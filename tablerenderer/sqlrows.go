@@ -0,0 +1,104 @@
+package tablerenderer
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// RenderFromSQLRows renders the rows of a query as the current page, using
+// the result column names as headers. All rows are read and rows is closed.
+// For database pagination, run the query with LIMIT and OFFSET and set
+// opts.Pagination.TotalCount from a separate count query.
+func (r *Renderer) RenderFromSQLRows(rows *sql.Rows, opts TableOptions) (string, error) {
+	headers, values, err := scanSQLRows(rows)
+	if err != nil {
+		return "", err
+	}
+
+	data := DatabasePaginatedData{
+		Headers: headers,
+		Rows:    values,
+		Options: opts,
+	}
+	if opts.Pagination != nil {
+		data.TotalCount = opts.Pagination.TotalCount
+	}
+	return r.RenderHTML(data)
+}
+
+// scanSQLRows reads the column names and all values of rows, then closes it
+func scanSQLRows(rows *sql.Rows) ([]string, [][]interface{}, error) {
+	source, err := NewSQLSource(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := [][]interface{}{}
+	for {
+		row, ok := source.Next()
+		if !ok {
+			break
+		}
+		values = append(values, row)
+	}
+	if err := source.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+	return source.Headers(), values, nil
+}
+
+// SQLSource is a RowSource reading the rows of a query one at a time
+// Text columns that drivers return as []byte are converted to strings.
+type SQLSource struct {
+	rows    *sql.Rows
+	headers []string
+	err     error
+}
+
+// NewSQLSource returns a source for rows, which it closes once exhausted
+func NewSQLSource(rows *sql.Rows) (*SQLSource, error) {
+	headers, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	return &SQLSource{rows: rows, headers: headers}, nil
+}
+
+// Headers returns the result column names
+func (s *SQLSource) Headers() []string {
+	return s.headers
+}
+
+// Next implements RowSource
+func (s *SQLSource) Next() ([]interface{}, bool) {
+	if s.err != nil || !s.rows.Next() {
+		if s.err == nil {
+			s.err = s.rows.Err()
+		}
+		s.rows.Close()
+		return nil, false
+	}
+
+	row := make([]interface{}, len(s.headers))
+	pointers := make([]interface{}, len(s.headers))
+	for i := range row {
+		pointers[i] = &row[i]
+	}
+	if err := s.rows.Scan(pointers...); err != nil {
+		s.err = fmt.Errorf("failed to scan row: %w", err)
+		s.rows.Close()
+		return nil, false
+	}
+	for i, value := range row {
+		if b, ok := value.([]byte); ok {
+			row[i] = string(b)
+		}
+	}
+	return row, true
+}
+
+// Err returns the error that ended the rows, if any
+func (s *SQLSource) Err() error {
+	return s.err
+}
@@ -0,0 +1,200 @@
+package tablerenderer
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// TableState is the page, sort, search, filters, visible columns and column
+// widths a request asks for
+type TableState struct {
+	Page      int      `json:"page"`                 // 1-based page number (default: 1)
+	PageSize  int      `json:"page_size,omitempty"`  // Rows per page, 0 for the table's default
+	AllRows   bool     `json:"all_rows,omitempty"`   // page_size=all: every row, where Pagination.ShowAllOption allows it
+	SortBy    string   `json:"sort_by,omitempty"`    // Header of the sorted column
+	SortOrder string   `json:"sort_order,omitempty"` // "asc" or "desc" (default: "asc")
+	Nulls     string   `json:"nulls,omitempty"`      // NullsFirst, NullsLast or "" for the default
+	Search    string   `json:"search,omitempty"`     // Search term
+	Filters   []Filter `json:"filters,omitempty"`    // Field filters, from filter[field] and filter[field][op] parameters
+	Columns   []string `json:"columns,omitempty"`    // Headers shown, in order (default: all), from the columns parameter
+	After     string   `json:"after,omitempty"`      // Cursor the page starts after, with cursor pagination
+	Before    string   `json:"before,omitempty"`     // Cursor the page ends before, with cursor pagination
+
+	Widths map[string]int `json:"widths,omitempty"` // Column widths in pixels by header, from the widths parameter
+}
+
+// StateParams names the query parameters of the table state. Empty names
+// use the defaults shown.
+type StateParams struct {
+	Page      string // (default: "page")
+	PageSize  string // (default: "page_size")
+	SortBy    string // (default: "sort_by")
+	SortOrder string // (default: "sort_order")
+	Nulls     string // (default: "nulls")
+	Search    string // (default: "search")
+	Columns   string // Comma-separated visible headers (default: "columns")
+	View      string // Saved view token from TableState.Encode (default: "view")
+	After     string // Cursor of cursor pagination (default: "after")
+	Before    string // Cursor of cursor pagination (default: "before")
+	Widths    string // Column widths, see ParseColumnWidths (default: "widths")
+}
+
+// withDefaults returns the parameter names with empty names defaulted
+func (p StateParams) withDefaults() StateParams {
+	def := func(name *string, fallback string) {
+		if *name == "" {
+			*name = fallback
+		}
+	}
+	def(&p.Page, "page")
+	def(&p.PageSize, "page_size")
+	def(&p.SortBy, "sort_by")
+	def(&p.SortOrder, "sort_order")
+	def(&p.Nulls, "nulls")
+	def(&p.Search, "search")
+	def(&p.Columns, "columns")
+	def(&p.View, "view")
+	def(&p.After, "after")
+	def(&p.Before, "before")
+	def(&p.Widths, "widths")
+	return p
+}
+
+// ParseState reads the table state from the query of req with the default
+// parameter names. defaults supplies the state used for missing or invalid
+// parameters, e.g. TableState{PageSize: 25, SortBy: "Created", SortOrder: "desc"}.
+func ParseState(req *http.Request, defaults ...TableState) TableState {
+	var def TableState
+	if len(defaults) > 0 {
+		def = defaults[0]
+	}
+	return ParseStateValues(req.URL.Query(), StateParams{}, def)
+}
+
+// ParseStateValues reads the table state from decoded query values with the
+// given parameter names. A valid saved view token replaces the defaults;
+// the other parameters override the view.
+func ParseStateValues(values url.Values, params StateParams, defaults TableState) TableState {
+	params = params.withDefaults()
+
+	state := defaults
+	if token := values.Get(params.View); token != "" {
+		if view, err := DecodeState(token); err == nil {
+			if view.PageSize == 0 {
+				view.PageSize = defaults.PageSize
+			}
+			state = view
+		}
+	}
+	if state.Page < 1 {
+		state.Page = 1
+	}
+	if page, err := strconv.Atoi(values.Get(params.Page)); err == nil && page > 0 {
+		state.Page = page
+	}
+	if pageSize, err := strconv.Atoi(values.Get(params.PageSize)); err == nil && pageSize > 0 {
+		state.PageSize = pageSize
+	} else if strings.EqualFold(values.Get(params.PageSize), pageSizeAll) {
+		state.AllRows = true
+	}
+	if sortBy := values.Get(params.SortBy); sortBy != "" {
+		state.SortBy = sortBy
+		state.SortOrder = "asc"
+	}
+	if sortOrder := strings.ToLower(values.Get(params.SortOrder)); sortOrder == "asc" || sortOrder == "desc" {
+		state.SortOrder = sortOrder
+	}
+	if state.SortOrder == "" {
+		state.SortOrder = "asc"
+	}
+	if nulls := values.Get(params.Nulls); validNullsPosition(nulls) {
+		state.Nulls = nulls
+	}
+	if search, ok := values[params.Search]; ok && len(search) > 0 {
+		state.Search = search[0]
+	}
+
+	if filters := ParseFilters(values); len(filters) > 0 {
+		state.Filters = filters
+	}
+	if columns := values.Get(params.Columns); columns != "" {
+		state.Columns = strings.Split(columns, ",")
+	}
+	if widths := ParseColumnWidths(values.Get(params.Widths)); widths != nil {
+		state.Widths = widths
+	}
+	if after := values.Get(params.After); after != "" {
+		state.After = after
+	} else if before := values.Get(params.Before); before != "" {
+		state.Before = before
+	}
+	return state
+}
+
+// Apply sets the page or cursor, sort and search term of state on the pagination,
+// sorting and search options present in options, adds its filters to the
+// configured ones and sets its visible columns and column widths
+func (s TableState) Apply(options *TableOptions) {
+	if len(s.Filters) > 0 {
+		options.Filters = append(options.Filters[:len(options.Filters):len(options.Filters)], s.Filters...)
+	}
+	if len(s.Columns) > 0 {
+		options.VisibleColumns = s.Columns
+	}
+	if len(s.Widths) > 0 {
+		options.ColumnWidths = s.Widths
+	}
+	if options.Pagination != nil {
+		pagination := *options.Pagination
+		pagination.CurrentPage = s.Page
+		pagination.After = s.After
+		pagination.Before = s.Before
+		if s.PageSize > 0 {
+			pagination.PageSize = s.PageSize
+		}
+		if s.AllRows && pagination.ShowAllOption {
+			pagination.AllRows = true
+			pagination.PageSize = maxAllRows(&pagination)
+		}
+		options.Pagination = &pagination
+	}
+	if options.Sorting != nil {
+		sorting := *options.Sorting
+		sorting.SortBy = s.SortBy
+		sorting.SortOrder = s.SortOrder
+		sorting.NullsPosition = s.Nulls
+		options.Sorting = &sorting
+	}
+	if options.Search != nil {
+		search := *options.Search
+		search.SearchTerm = s.Search
+		options.Search = &search
+	}
+}
+
+// stateParams returns the parameter names configured in options
+func stateParams(options TableOptions) StateParams {
+	var params StateParams
+	if options.Pagination != nil {
+		params.Page = options.Pagination.QueryParam
+	}
+	if options.Sorting != nil {
+		params.SortBy = options.Sorting.QueryParam
+		params.SortOrder = options.Sorting.OrderParam
+		params.Nulls = options.Sorting.NullsParam
+	}
+	if options.Search != nil {
+		params.Search = options.Search.QueryParam
+	}
+	params.Widths = options.WidthsParam
+	return params
+}
+
+// parseQuery decodes a raw query string with or without the leading "?",
+// keeping the parameters that decode when others are malformed
+func parseQuery(queryString string) url.Values {
+	values, _ := url.ParseQuery(strings.TrimPrefix(queryString, "?"))
+	return values
+}
@@ -0,0 +1,118 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// State token formats, the first character of a token
+const (
+	stateTokenJSON    = '0' // Base64 JSON
+	stateTokenDeflate = '1' // Base64 deflated JSON, for states with many filters or columns
+)
+
+// stateToken is the compact JSON form of a TableState
+type stateToken struct {
+	Page      int        `json:"p,omitempty"`
+	PageSize  int        `json:"n,omitempty"`
+	SortBy    string     `json:"s,omitempty"`
+	SortOrder string     `json:"o,omitempty"`
+	Nulls     string     `json:"u,omitempty"`
+	Search    string     `json:"q,omitempty"`
+	Filters   [][]string `json:"f,omitempty"` // Field, operator and values
+	Columns   []string   `json:"c,omitempty"`
+
+	Widths map[string]int `json:"w,omitempty"`
+}
+
+// Encode returns a short URL-safe token of the state, for saved views
+// stored per user or bookmarked in the view query parameter. DecodeState
+// reverses it.
+func (s TableState) Encode() string {
+	token := stateToken{
+		PageSize: s.PageSize,
+		SortBy:   s.SortBy,
+		Nulls:    s.Nulls,
+		Search:   s.Search,
+		Columns:  s.Columns,
+		Widths:   s.Widths,
+	}
+	if s.Page > 1 {
+		token.Page = s.Page
+	}
+	if s.SortBy != "" && s.SortOrder == "desc" {
+		token.SortOrder = "d"
+	}
+	for _, filter := range s.Filters {
+		token.Filters = append(token.Filters, append([]string{filter.Field, filter.operator()}, filter.Values...))
+	}
+
+	// Encoding a struct of strings and ints cannot fail
+	encoded, _ := json.Marshal(token)
+	format := byte(stateTokenJSON)
+	var deflated bytes.Buffer
+	writer, _ := flate.NewWriter(&deflated, flate.BestCompression)
+	writer.Write(encoded)
+	writer.Close()
+	if deflated.Len() < len(encoded) {
+		encoded = deflated.Bytes()
+		format = stateTokenDeflate
+	}
+	return string(format) + base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeState parses a token made by TableState.Encode
+func DecodeState(token string) (TableState, error) {
+	if token == "" {
+		return TableState{}, fmt.Errorf("invalid table state token: empty")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token[1:])
+	if err != nil {
+		return TableState{}, fmt.Errorf("invalid table state token: %w", err)
+	}
+	switch token[0] {
+	case stateTokenJSON:
+	case stateTokenDeflate:
+		// States are small; the limit guards against deflate bombs
+		data, err = io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), 64<<10))
+		if err != nil {
+			return TableState{}, fmt.Errorf("invalid table state token: %w", err)
+		}
+	default:
+		return TableState{}, fmt.Errorf("invalid table state token: unknown format %q", token[0])
+	}
+
+	var decoded stateToken
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return TableState{}, fmt.Errorf("invalid table state token: %w", err)
+	}
+	state := TableState{
+		Page:      max(decoded.Page, 1),
+		PageSize:  max(decoded.PageSize, 0),
+		SortBy:    decoded.SortBy,
+		SortOrder: "asc",
+		Search:    decoded.Search,
+		Columns:   decoded.Columns,
+		Widths:    decoded.Widths,
+	}
+	if decoded.SortOrder == "d" {
+		state.SortOrder = "desc"
+	}
+	if validNullsPosition(decoded.Nulls) {
+		state.Nulls = decoded.Nulls
+	}
+	for _, values := range decoded.Filters {
+		if len(values) < 2 {
+			continue
+		}
+		filter := Filter{Field: values[0], Operator: values[1], Values: values[2:]}
+		if filter.valid() {
+			state.Filters = append(state.Filters, filter)
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,91 @@
+package tablerenderer
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStateTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  TableState
+		format byte // Expected token format, 0 for either
+	}{
+		{
+			name:   "first page",
+			state:  TableState{Page: 1, SortOrder: "asc"},
+			format: stateTokenJSON,
+		},
+		{
+			name: "sorted and filtered",
+			state: TableState{
+				Page:      3,
+				PageSize:  50,
+				SortBy:    "Name",
+				SortOrder: "desc",
+				Nulls:     NullsLast,
+				Search:    "ada",
+				Filters: []Filter{
+					{Field: "age", Operator: FilterBetween, Values: []string{"18", "30"}},
+					{Field: "status", Operator: FilterEquals, Values: []string{"open"}},
+				},
+				Columns: []string{"Name", "Email"},
+				Widths:  map[string]int{"Name": 120},
+			},
+		},
+		{
+			name: "deflated",
+			state: TableState{
+				Page:      1,
+				SortOrder: "asc",
+				Columns:   strings.Split(strings.Repeat("Column,", 40)+"Last", ","),
+			},
+			format: stateTokenDeflate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.state.Encode()
+			if tt.format != 0 && token[0] != tt.format {
+				t.Errorf("Encode() = %q, want format %q", token, tt.format)
+			}
+			got, err := DecodeState(token)
+			if err != nil {
+				t.Fatalf("DecodeState(%q) error = %v", token, err)
+			}
+			if !reflect.DeepEqual(got, tt.state) {
+				t.Errorf("DecodeState(Encode()) = %+v, want %+v", got, tt.state)
+			}
+		})
+	}
+}
+
+func TestDecodeStateInvalid(t *testing.T) {
+	// A deflated token expanding past the 64KiB limit
+	var bomb bytes.Buffer
+	writer, _ := flate.NewWriter(&bomb, flate.BestCompression)
+	writer.Write([]byte(`{"q":"` + strings.Repeat("a", 70<<10) + `"}`))
+	writer.Close()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"unknown format", "9" + base64.RawURLEncoding.EncodeToString([]byte(`{}`))},
+		{"bad base64", "0!!!"},
+		{"bad JSON", "0" + base64.RawURLEncoding.EncodeToString([]byte(`{`))},
+		{"over the size limit", "1" + base64.RawURLEncoding.EncodeToString(bomb.Bytes())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if state, err := DecodeState(tt.token); err == nil {
+				t.Errorf("DecodeState() = %+v, want an error", state)
+			}
+		})
+	}
+}
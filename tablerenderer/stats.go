@@ -0,0 +1,165 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ColumnStats summarizes the values of a column
+type ColumnStats struct {
+	Count    int         `json:"count"`         // Non-null values
+	Nulls    int         `json:"nulls"`         // Nil, empty and zero-time values
+	Distinct int         `json:"distinct"`      // Distinct non-null values
+	Min      interface{} `json:"min,omitempty"` // Smallest number, time or string
+	Max      interface{} `json:"max,omitempty"` // Largest number, time or string
+}
+
+// Statistics holds configuration for the column statistics footer
+type Statistics struct {
+	Enabled bool                   `json:"enabled"`
+	Columns map[string]ColumnStats `json:"columns,omitempty"` // Aggregates over the full dataset per header, e.g. from SQL (default: computed from the current page)
+}
+
+// computeColumnStats computes the statistics of each column over rows
+func computeColumnStats(headers []string, rows [][]interface{}) []ColumnStats {
+	stats := make([]ColumnStats, len(headers))
+	distinct := make([]map[string]bool, len(headers))
+	for i := range distinct {
+		distinct[i] = make(map[string]bool)
+	}
+
+	for _, row := range rows {
+		for i, value := range row {
+			if i >= len(headers) {
+				break
+			}
+			if isEmptyValue(value) {
+				stats[i].Nulls++
+				continue
+			}
+			stats[i].Count++
+			distinct[i][exportValue(value)] = true
+
+			if stats[i].Min == nil {
+				if _, ok := compareValues(value, value); ok {
+					stats[i].Min, stats[i].Max = value, value
+				}
+				continue
+			}
+			if cmp, ok := compareValues(value, stats[i].Min); ok && cmp < 0 {
+				stats[i].Min = value
+			}
+			if cmp, ok := compareValues(value, stats[i].Max); ok && cmp > 0 {
+				stats[i].Max = value
+			}
+		}
+	}
+
+	for i := range stats {
+		stats[i].Distinct = len(distinct[i])
+	}
+	return stats
+}
+
+// compareValues orders two numbers, times or strings, reporting false when
+// the values are not comparable
+func compareValues(a interface{}, b interface{}) (int, bool) {
+	if x, ok := numericValue(a); ok {
+		y, ok := numericValue(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	if x, ok := timeValue(a); ok {
+		y, ok := timeValue(b)
+		if !ok {
+			return 0, false
+		}
+		return x.Compare(y), true
+	}
+
+	x, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	y, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+// numericValue converts integer and floating point values to float64
+func numericValue(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// timeValue unwraps time.Time and *time.Time values
+func timeValue(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// generateStatisticsHTML renders the footer cell of each column, using the
+// configured aggregates where available and the current page otherwise
+func (r *Renderer) generateStatisticsHTML(headers []string, rows [][]interface{}, columns []Column, statistics *Statistics) []template.HTML {
+	if statistics == nil || !statistics.Enabled {
+		return nil
+	}
+
+	configs := columnsFor(headers, columns)
+	pageStats := computeColumnStats(headers, rows)
+
+	cells := make([]template.HTML, len(headers))
+	for i, header := range headers {
+		stats, ok := statistics.Columns[header]
+		if !ok {
+			stats = pageStats[i]
+		}
+
+		var html strings.Builder
+		html.WriteString(`<dl class="column-stats">`)
+		writeStat := func(label string, value string) {
+			html.WriteString(fmt.Sprintf(`<dt>%s</dt><dd>%s</dd>`, template.HTMLEscapeString(r.translate(label, 1)), value))
+		}
+		writeStat(LabelStatsCount, fmt.Sprint(stats.Count))
+		writeStat(LabelStatsNulls, fmt.Sprint(stats.Nulls))
+		writeStat(LabelStatsDistinct, fmt.Sprint(stats.Distinct))
+		if !isEmptyValue(stats.Min) {
+			writeStat(LabelStatsMin, cellHTML(r.formatValue(configs[i], stats.Min)))
+		}
+		if !isEmptyValue(stats.Max) {
+			writeStat(LabelStatsMax, cellHTML(r.formatValue(configs[i], stats.Max)))
+		}
+		html.WriteString(`</dl>`)
+		cells[i] = template.HTML(html.String())
+	}
+	return cells
+}
@@ -0,0 +1,141 @@
+package tablerenderer
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// streamFlushRows is the number of rows RenderStream writes between flushes
+const streamFlushRows = 100
+
+// RowSource yields table rows one at a time, e.g. from a channel or a
+// database cursor. Next returns false once the rows are exhausted. Sources
+// that can fail also implement Err, which RenderStream checks at the end.
+type RowSource interface {
+	Next() ([]interface{}, bool)
+}
+
+// ChannelSource returns a RowSource reading rows from ch until it is closed
+func ChannelSource(ch <-chan []interface{}) RowSource {
+	return channelSource(ch)
+}
+
+// channelSource is a RowSource reading from a channel
+type channelSource <-chan []interface{}
+
+// Next implements RowSource
+func (c channelSource) Next() ([]interface{}, bool) {
+	row, ok := <-c
+	return row, ok
+}
+
+// RenderStream writes a table with the rows of source as they arrive,
+// without holding them in memory. Column formatting, links, row links,
+// frozen columns and the table and cell classes apply; pagination, sorting,
+// search and the other controls that need the whole data set do not. When w
+// is an http.ResponseWriter the response is flushed every few rows so the
+// client sees them early.
+func (r *Renderer) RenderStream(w io.Writer, headers []string, source RowSource, options TableOptions) error {
+	headerContents, err := r.renderHeaderContents(headers, options.Columns, nil)
+	if err != nil {
+		return err
+	}
+
+	cssClasses := []string{"table", "data-table"}
+	if options.CSSClass != "" {
+		cssClasses = append(cssClasses, options.CSSClass)
+	}
+	if options.Striped {
+		cssClasses = append(cssClasses, "table-striped")
+	}
+	if options.Bordered {
+		cssClasses = append(cssClasses, "table-bordered")
+	}
+
+	out := bufio.NewWriter(w)
+	flush := func() error {
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	// Row link and frozen column scripts look for the table container
+	fmt.Fprintf(out, `<div class="table-container"><table class="%s"`, template.HTMLEscapeString(strings.Join(cssClasses, " ")))
+	if options.ID != "" {
+		fmt.Fprintf(out, ` id="%s"`, template.HTMLEscapeString(options.ID))
+	}
+	if direction := textDirection(options.Direction); direction != "" {
+		fmt.Fprintf(out, ` dir="%s"`, direction)
+	}
+	frozen := frozenDataColumns(options)
+	out.WriteString(">\n<thead>\n<tr>")
+	for j, class := range headerClasses(headers, options.Columns, frozen) {
+		if class != "" {
+			fmt.Fprintf(out, `<th class="%s">%s</th>`, template.HTMLEscapeString(class), headerContents[j])
+		} else {
+			fmt.Fprintf(out, "<th>%s</th>", headerContents[j])
+		}
+	}
+	out.WriteString("</tr>\n</thead>\n<tbody>\n")
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to write table header: %w", err)
+	}
+
+	configs := columnsFor(headers, options.Columns)
+	classes := columnClasses(headers, options.Columns)
+	count := 0
+	for {
+		row, ok := source.Next()
+		if !ok {
+			break
+		}
+		rendered := r.renderRow(headers, configs, count, row)
+		markRowClasses(&rendered, RowView{Index: count, Headers: headers, Values: row}, classes, frozen, options.CellClassFunc)
+		out.WriteString("<tr")
+		if rendered.Attributes != "" {
+			fmt.Fprintf(out, " %s", rendered.Attributes)
+		}
+		out.WriteString(">")
+		for j, cell := range rendered.Cells {
+			if j < len(rendered.Classes) && rendered.Classes[j] != "" {
+				fmt.Fprintf(out, `<td class="%s">%s</td>`, template.HTMLEscapeString(rendered.Classes[j]), cellHTML(cell))
+			} else {
+				fmt.Fprintf(out, "<td>%s</td>", cellHTML(cell))
+			}
+		}
+		out.WriteString("</tr>\n")
+
+		count++
+		if count%streamFlushRows == 0 {
+			if err := flush(); err != nil {
+				return fmt.Errorf("failed to write rows: %w", err)
+			}
+		}
+	}
+
+	if failing, ok := source.(interface{ Err() error }); ok {
+		if err := failing.Err(); err != nil {
+			return fmt.Errorf("failed to read rows: %w", err)
+		}
+	}
+
+	if count == 0 {
+		fmt.Fprintf(out, `<tr class="empty-state-row"><td colspan="%d">%s</td></tr>`+"\n",
+			max(len(headers), 1), template.HTMLEscapeString(r.translate(LabelNoRecords, 0)))
+	}
+	out.WriteString("</tbody>\n</table>\n")
+	out.WriteString(string(rowScripts(options)))
+	out.WriteString("</div>\n")
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+	return nil
+}
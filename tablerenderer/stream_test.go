@@ -0,0 +1,82 @@
+package tablerenderer
+
+import (
+	"strings"
+	"testing"
+)
+
+// sliceRows is a RowSource over a slice of rows
+type sliceRows [][]interface{}
+
+// Next implements RowSource
+func (s *sliceRows) Next() ([]interface{}, bool) {
+	if len(*s) == 0 {
+		return nil, false
+	}
+	row := (*s)[0]
+	*s = (*s)[1:]
+	return row, true
+}
+
+func TestRenderStreamRowDecoration(t *testing.T) {
+	options := TableOptions{
+		FrozenColumns: 1,
+		Columns: []Column{
+			{Header: "ID", Link: &LinkFormat{URL: "/users/{ID}", RowLink: true}},
+			{Header: "Age", CellClass: "text-end"},
+		},
+		CellClassFunc: func(row RowView, header string, value interface{}) string {
+			if header == "Age" && value.(int) >= 40 {
+				return "senior"
+			}
+			return ""
+		},
+	}
+	tests := []struct {
+		name     string
+		jsPolicy JSPolicy
+		want     []string
+		notWant  []string
+	}{
+		{
+			name: "scripts",
+			want: []string{
+				`<th class="frozen">`,
+				`class="row-link" data-href="/users/1"`,
+				`<td class="frozen"><a href="/users/1">1</a></td>`,
+				`<td class="text-end">36</td>`,
+				`<td class="text-end senior">41</td>`,
+				rowLinkScript,
+				frozenColumnsScript,
+			},
+		},
+		{
+			name:     "no scripts",
+			jsPolicy: JSNone,
+			want:     []string{`<td class="frozen"><a href="/users/1">1</a></td>`, `<td class="text-end senior">41</td>`},
+			notWant:  []string{"<script"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options
+			opts.JSPolicy = tt.jsPolicy
+			rows := sliceRows{{1, 36}, {2, 41}}
+			var out strings.Builder
+			if err := NewRenderer().RenderStream(&out, []string{"ID", "Age"}, &rows, opts); err != nil {
+				t.Fatalf("RenderStream() error = %v", err)
+			}
+			html := out.String()
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("missing %q in:\n%s", want, html)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(html, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, html)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package tablerenderer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structTag is the struct tag controlling the columns of a field, with
+// comma-separated options: `table:"-"` skips the field, "inline" gives the
+// fields of a nested struct columns named like the fields themselves, and
+// "order=N" moves the column, or a nested struct's columns, by weight N;
+// columns are ordered by ascending weight, then by field order, and
+// default to 0. Other nested structs are flattened into "Parent.Child"
+// columns. Embedded structs without a json name are inlined, as
+// encoding/json promotes their fields.
+const structTag = "table"
+
+// structTagOptions are the parsed options of a structTag
+type structTagOptions struct {
+	skip     bool
+	inline   bool
+	order    int
+	hasOrder bool
+}
+
+// parseStructTag parses a structTag value; unknown options are ignored
+func parseStructTag(tag string) structTagOptions {
+	var options structTagOptions
+	if tag == "-" {
+		options.skip = true
+		return options
+	}
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "inline":
+			options.inline = true
+		case strings.HasPrefix(option, "order="):
+			if order, err := strconv.Atoi(strings.TrimPrefix(option, "order=")); err == nil {
+				options.order = order
+				options.hasOrder = true
+			}
+		}
+	}
+	return options
+}
+
+// structInfo is the table metadata of a struct type: a header and field
+// index path per column
+type structInfo struct {
+	headers []string
+	fields  [][]int
+}
+
+// structColumn is a candidate column found while walking a struct type
+type structColumn struct {
+	header string
+	index  []int
+	tagged bool // Header comes from a json tag
+	order  int  // Weight from the order tag option
+}
+
+// structInfoCache holds the *structInfo of each struct type rendered so far,
+// so tag parsing happens once per type instead of on every render
+var structInfoCache sync.Map
+
+// structInfoFor returns the cached metadata of a struct type
+func structInfoFor(structType reflect.Type) *structInfo {
+	if cached, ok := structInfoCache.Load(structType); ok {
+		return cached.(*structInfo)
+	}
+
+	var columns []structColumn
+	collectStructColumns(&columns, structType, "", nil, 0, map[reflect.Type]bool{structType: true})
+	columns = dominantColumns(columns)
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].order < columns[j].order
+	})
+
+	info := &structInfo{}
+	for _, column := range columns {
+		info.headers = append(info.headers, column.header)
+		info.fields = append(info.fields, column.index)
+	}
+	cached, _ := structInfoCache.LoadOrStore(structType, info)
+	return cached.(*structInfo)
+}
+
+// StructHeaders returns the headers of the columns of a struct type, in the
+// order rendered from a slice of it: exported fields, with nested structs
+// flattened and embedded ones promoted. A field's name in the nameTag tag,
+// e.g. "db", replaces its own name in the header, so "Address.City" with
+// `db:"city"` becomes "Address.city"; without nameTag, or for fields
+// without a name in it, headers are the json tag or field names. Fields are
+// skipped with the table tag only, e.g. `table:"-"`.
+func StructHeaders(structType reflect.Type, nameTag string) []string {
+	info := structInfoFor(structType)
+	headers := append([]string(nil), info.headers...)
+	if nameTag == "" {
+		return headers
+	}
+	for i, index := range info.fields {
+		field := structType.FieldByIndex(index)
+		name, _, _ := strings.Cut(field.Tag.Get(nameTag), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		own, _ := structFieldHeader(field)
+		headers[i] = strings.TrimSuffix(headers[i], own) + name
+	}
+	return headers
+}
+
+// collectStructColumns adds the columns of the exported fields of
+// structType, prefixing headers with prefix and field indexes with index.
+// Fields without an order option get the order of the enclosing field.
+// Types already on the path are not expanded again, so recursive types
+// terminate.
+func collectStructColumns(columns *[]structColumn, structType reflect.Type, prefix string, index []int, order int, path map[reflect.Type]bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := parseStructTag(field.Tag.Get(structTag))
+		fieldOrder := order
+		if tag.hasOrder {
+			fieldOrder = tag.order
+		}
+		nested, isNested := nestedStruct(field.Type)
+		// Embedded structs of unexported types still promote their fields,
+		// except through pointers, which reflection cannot read
+		promoted := field.Anonymous && isNested && field.Type.Kind() != reflect.Ptr
+		if tag.skip || (!field.IsExported() && !promoted) {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		header, tagged := structFieldHeader(field)
+		header = prefix + header
+
+		if isNested && !path[nested] {
+			nestedPrefix := header + "."
+			if tag.inline || (field.Anonymous && !tagged) {
+				nestedPrefix = prefix
+			}
+			path[nested] = true
+			collectStructColumns(columns, nested, nestedPrefix, fieldIndex, fieldOrder, path)
+			delete(path, nested)
+			continue
+		}
+
+		*columns = append(*columns, structColumn{header: header, index: fieldIndex, tagged: tagged, order: fieldOrder})
+	}
+}
+
+// dominantColumns resolves columns sharing a header the way encoding/json
+// resolves promoted fields: the shallowest wins, then the only tagged one at
+// that depth; when that leaves a tie, the header is dropped
+func dominantColumns(columns []structColumn) []structColumn {
+	byHeader := make(map[string][]structColumn)
+	for _, column := range columns {
+		byHeader[column.header] = append(byHeader[column.header], column)
+	}
+
+	var result []structColumn
+	for _, column := range columns {
+		candidates := byHeader[column.header]
+		if len(candidates) == 1 {
+			result = append(result, column)
+			continue
+		}
+		var dominant []structColumn
+		for _, candidate := range candidates {
+			switch {
+			case len(dominant) == 0 || len(candidate.index) < len(dominant[0].index):
+				dominant = []structColumn{candidate}
+			case len(candidate.index) == len(dominant[0].index):
+				dominant = append(dominant, candidate)
+			}
+		}
+		if len(dominant) > 1 {
+			var tagged []structColumn
+			for _, candidate := range dominant {
+				if candidate.tagged {
+					tagged = append(tagged, candidate)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 && reflect.DeepEqual(dominant[0].index, column.index) {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// nestedStruct returns the struct type of a struct or struct pointer field
+// whose fields become columns. Times and types with their own text form,
+// such as fmt.Stringer and driver.Valuer implementations, stay one column.
+func nestedStruct(fieldType reflect.Type) (reflect.Type, bool) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct || fieldType == reflect.TypeOf(time.Time{}) {
+		return nil, false
+	}
+	for _, t := range []reflect.Type{fieldType, reflect.PointerTo(fieldType)} {
+		if t.Implements(stringerType) || t.Implements(textMarshalerType) || t.Implements(valuerType) {
+			return nil, false
+		}
+	}
+	return fieldType, true
+}
+
+// Interfaces that give a struct its own single-column representation
+var (
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// structFieldHeader returns the json tag name of a field and true, or its
+// name and false when it has none
+func structFieldHeader(field reflect.StructField) (string, bool) {
+	if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
+		// Remove omitempty and other options
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name, true
+		}
+	}
+	return field.Name, false
+}
+
+// structFieldValue returns the value at a field index path, or nil when a
+// nested struct pointer on the way is nil
+func structFieldValue(v reflect.Value, index []int) interface{} {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return structCellValue(v)
+}
+
+// structCellValue returns the underlying value of a struct field: pointers
+// are dereferenced and sql.Null* values unwrapped, giving nil for nil
+// pointers and invalid nulls so the column placeholder is shown
+func structCellValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case sql.NullString:
+		if value.Valid {
+			return value.String
+		}
+	case sql.NullInt64:
+		if value.Valid {
+			return value.Int64
+		}
+	case sql.NullInt32:
+		if value.Valid {
+			return value.Int32
+		}
+	case sql.NullInt16:
+		if value.Valid {
+			return value.Int16
+		}
+	case sql.NullByte:
+		if value.Valid {
+			return value.Byte
+		}
+	case sql.NullFloat64:
+		if value.Valid {
+			return value.Float64
+		}
+	case sql.NullBool:
+		if value.Valid {
+			return value.Bool
+		}
+	case sql.NullTime:
+		if value.Valid {
+			return value.Time
+		}
+	default:
+		return value
+	}
+	return nil
+}
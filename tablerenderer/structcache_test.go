@@ -0,0 +1,164 @@
+package tablerenderer
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPlain struct {
+	ID    int `json:"id,omitempty"`
+	Name  string
+	Email string `json:"-"`
+}
+
+type testAddress struct {
+	Street string
+	City   string `json:"city" db:"town"`
+}
+
+type testNested struct {
+	Name    string
+	Secret  string `table:"-"`
+	Address testAddress
+	Billing *testAddress `table:"inline"`
+}
+
+type testNode struct {
+	Name string
+	Next *testNode
+}
+
+type testAudit struct {
+	CreatedBy string `json:"created_by"`
+	Name      string
+}
+
+type testEmbedded struct {
+	Name string
+	testAudit
+	private string
+}
+
+type testNullable struct {
+	Note    sql.NullString
+	Count   *int
+	Created *time.Time
+}
+
+type testOrdered struct {
+	ID      int
+	Name    string `table:"order=-1"`
+	Created string `table:"order=10"`
+	Email   string
+}
+
+func TestConvertStructSliceToRows(t *testing.T) {
+	count := 3
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name        string
+		data        interface{}
+		wantHeaders []string
+		wantRows    [][]interface{}
+	}{
+		{
+			name:        "tag names",
+			data:        []testPlain{{ID: 1, Name: "Ada", Email: "ada@example.com"}},
+			wantHeaders: []string{"id", "Name", "Email"},
+			wantRows:    [][]interface{}{{1, "Ada", "ada@example.com"}},
+		},
+		{
+			name:        "pointers to structs",
+			data:        []*testPlain{{ID: 2, Name: "Bob"}},
+			wantHeaders: []string{"id", "Name", "Email"},
+			wantRows:    [][]interface{}{{2, "Bob", ""}},
+		},
+		{
+			name:        "nested and inline structs",
+			data:        []testNested{{Name: "Ada", Secret: "x", Address: testAddress{"Main St", "Berlin"}, Billing: &testAddress{"Side St", "Paris"}}},
+			wantHeaders: []string{"Name", "Address.Street", "Address.city", "Street", "city"},
+			wantRows:    [][]interface{}{{"Ada", "Main St", "Berlin", "Side St", "Paris"}},
+		},
+		{
+			name:        "recursive type",
+			data:        []testNode{{Name: "a"}},
+			wantHeaders: []string{"Name", "Next"},
+			wantRows:    [][]interface{}{{"a", nil}},
+		},
+		{
+			name:        "embedded fields promoted",
+			data:        []testEmbedded{{Name: "Ada", testAudit: testAudit{CreatedBy: "admin", Name: "shadowed"}}},
+			wantHeaders: []string{"Name", "created_by"},
+			wantRows:    [][]interface{}{{"Ada", "admin"}},
+		},
+		{
+			name:        "pointer and null values",
+			data:        []testNullable{{Note: sql.NullString{String: "vip", Valid: true}, Count: &count, Created: &created}, {}},
+			wantHeaders: []string{"Note", "Count", "Created"},
+			wantRows:    [][]interface{}{{"vip", 3, created}, {nil, nil, nil}},
+		},
+		{
+			name:        "nil inline struct",
+			data:        []testNested{{Name: "Bob"}},
+			wantHeaders: []string{"Name", "Address.Street", "Address.city", "Street", "city"},
+			wantRows:    [][]interface{}{{"Bob", "", "", nil, nil}},
+		},
+		{
+			name:        "order weights",
+			data:        []testOrdered{{ID: 1, Name: "Ada", Created: "today", Email: "ada@example.com"}},
+			wantHeaders: []string{"Name", "ID", "Email", "Created"},
+			wantRows:    [][]interface{}{{"Ada", 1, "ada@example.com", "today"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, rows, err := convertStructSliceToRows(tt.data)
+			if err != nil {
+				t.Fatalf("convertStructSliceToRows() error = %v", err)
+			}
+			if !reflect.DeepEqual(headers, tt.wantHeaders) {
+				t.Errorf("headers = %q, want %q", headers, tt.wantHeaders)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %#v, want %#v", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestStructInfoCached(t *testing.T) {
+	structType := reflect.TypeOf(testPlain{})
+	if structInfoFor(structType) != structInfoFor(structType) {
+		t.Error("structInfoFor() built the metadata of a type twice")
+	}
+}
+
+func TestStructHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		nameTag string
+		want    []string
+	}{
+		{"json names", testNested{}, "", []string{"Name", "Address.Street", "Address.city", "Street", "city"}},
+		{"db names", testNested{}, "db", []string{"Name", "Address.Street", "Address.town", "Street", "town"}},
+		{"ordered", testOrdered{}, "", []string{"Name", "ID", "Email", "Created"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StructHeaders(reflect.TypeOf(tt.value), tt.nameTag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructHeaders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructHeadersCopy(t *testing.T) {
+	headers := StructHeaders(reflect.TypeOf(testNode{}), "")
+	headers[0] = "changed"
+	if got := StructHeaders(reflect.TypeOf(testNode{}), ""); got[0] != "Name" {
+		t.Errorf("StructHeaders() returned the cached slice, got %q after changing a copy", got[0])
+	}
+}
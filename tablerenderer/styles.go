@@ -0,0 +1,840 @@
+package tablerenderer
+
+import "html/template"
+
+// tableStyles is the CSS of tables
+const tableStyles = `
+.table-container {
+	font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
+	background: #ffffff;
+	border-radius: 8px;
+	box-shadow: 0 1px 3px rgba(0,0,0,0.1);
+	overflow: hidden;
+}
+
+.table-header {
+	display: flex;
+	justify-content: space-between;
+	align-items: center;
+	padding: 1rem;
+	background: #f8f9fa;
+	border-bottom: 1px solid #dee2e6;
+}
+
+.toolbar-slot {
+	display: flex;
+	align-items: center;
+	gap: 0.5rem;
+}
+
+.page-size-control {
+	display: flex;
+	align-items: center;
+	gap: 0.5rem;
+	font-size: 0.875rem;
+	color: #6c757d;
+}
+
+.page-size-control select {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	background: white;
+	font-size: 0.875rem;
+	min-width: 100px;
+}
+
+.search-control {
+	display: flex;
+	align-items: center;
+	gap: 0.5rem;
+}
+
+.search-control label {
+	font-size: 0.875rem;
+	color: #6c757d;
+	margin: 0;
+}
+
+.search-input-group {
+	display: flex;
+	align-items: center;
+	position: relative;
+}
+
+.search-input-group input {
+	padding: 0.5rem 0.75rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	font-size: 0.875rem;
+	min-width: 200px;
+	outline: none;
+}
+
+.search-input-group input:focus {
+	border-color: #80bdff;
+	box-shadow: 0 0 0 0.2rem rgba(0,123,255,0.25);
+}
+
+.search-btn {
+	margin-inline-start: 0.5rem;
+	padding: 0.5rem 0.75rem;
+	background: #007bff;
+	color: white;
+	border: none;
+	border-radius: 4px;
+	cursor: pointer;
+	font-size: 0.875rem;
+	transition: background-color 0.2s ease;
+}
+
+.search-btn:hover {
+	background: #0056b3;
+}
+
+.search-btn:focus {
+	outline: none;
+	box-shadow: 0 0 0 0.2rem rgba(0,123,255,0.25);
+}
+
+.search-clear-btn {
+	margin-inline-start: 0.5rem;
+	padding: 0.5rem;
+	background: #dc3545;
+	color: white;
+	border: none;
+	border-radius: 4px;
+	cursor: pointer;
+	font-size: 0.75rem;
+}
+
+.data-table {
+	width: 100%;
+	border-collapse: collapse;
+	margin: 0;
+	background: white;
+}
+
+.data-table thead th {
+	background: #f8f9fa;
+	font-weight: 600;
+	padding: 0.75rem;
+	text-align: start;
+	border-bottom: 2px solid #dee2e6;
+	color: #495057;
+	font-size: 0.875rem;
+}
+
+.data-table tbody td {
+	padding: 0.75rem;
+	border-bottom: 1px solid #dee2e6;
+	color: #212529;
+	font-size: 0.875rem;
+}
+
+.data-table tbody tr:nth-child(even) {
+	background-color: #f8f9fa;
+}
+
+.data-table tbody tr:hover {
+	background-color: #e9ecef;
+}
+
+.sort-link {
+	color: inherit;
+	text-decoration: none;
+	display: flex;
+	align-items: center;
+	justify-content: space-between;
+	width: 100%;
+}
+
+.sort-link:hover {
+	color: #495057;
+}
+
+.table-container svg.icon {
+	vertical-align: -0.125em;
+}
+
+.sort-icon {
+	font-size: 0.75rem;
+	margin-inline-start: 0.5rem;
+	opacity: 0.6;
+}
+
+.sort-icon.active {
+	opacity: 1;
+	color: #007bff;
+}
+
+.nulls-indicator {
+	margin-inline-start: 0.125rem;
+	font-size: 0.625rem;
+	vertical-align: super;
+}
+
+.nulls-indicator.nulls-first {
+	vertical-align: sub;
+}
+
+.table-footer {
+	display: flex;
+	justify-content: space-between;
+	align-items: center;
+	padding: 1rem;
+	background: #f8f9fa;
+	border-top: 1px solid #dee2e6;
+}
+
+.pagination-info {
+	font-size: 0.875rem;
+	color: #6c757d;
+	margin: 0;
+}
+
+.pagination {
+	display: flex;
+	list-style: none;
+	margin: 0;
+	padding: 0;
+	gap: 0.25rem;
+}
+
+.load-more-button {
+	display: inline-block;
+	padding: 0.5rem 1.5rem;
+	color: #007bff;
+	text-decoration: none;
+	border: 1px solid #dee2e6;
+	border-radius: 4px;
+	font-size: 0.875rem;
+}
+
+.load-more-button:hover {
+	background-color: #e9ecef;
+}
+
+.load-more-button[aria-busy] {
+	opacity: 0.6;
+	pointer-events: none;
+}
+
+.pagination-controls {
+	display: flex;
+	align-items: center;
+	gap: 1rem;
+}
+
+.jump-to-page {
+	display: flex;
+	align-items: center;
+	gap: 0.5rem;
+	margin: 0;
+	font-size: 0.875rem;
+	color: #6c757d;
+}
+
+.jump-to-page input {
+	width: 4.5rem;
+	padding: 0.375rem 0.5rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	font-size: 0.875rem;
+}
+
+.jump-to-page button {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #dee2e6;
+	border-radius: 4px;
+	background: white;
+	color: #007bff;
+	font-size: 0.875rem;
+	cursor: pointer;
+}
+
+.pagination .page-ellipsis .page-link {
+	border-color: transparent;
+}
+
+.export-toolbar {
+	display: flex;
+	gap: 0.25rem;
+}
+
+.export-button {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #dee2e6;
+	border-radius: 0.25rem;
+	color: #007bff;
+	font-size: 0.875rem;
+	text-decoration: none;
+}
+
+.export-button:hover,
+.copy-button:hover {
+	background-color: #e9ecef;
+}
+
+.copy-button {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #dee2e6;
+	border-radius: 0.25rem;
+	background: none;
+	color: #007bff;
+	font-size: 0.875rem;
+	cursor: pointer;
+}
+
+.export-button[data-copied],
+.copy-button[data-copied] {
+	border-color: #28a745;
+	color: #28a745;
+}
+
+.bulk-actions {
+	display: flex;
+	flex-wrap: wrap;
+	gap: 0.5rem;
+	margin-bottom: 0.5rem;
+}
+
+.bulk-actions button:disabled {
+	opacity: 0.5;
+	cursor: not-allowed;
+}
+
+.inline-edit-table {
+	display: flex;
+	justify-content: flex-end;
+	margin-bottom: 0.5rem;
+}
+
+.cell-editor {
+	width: 100%;
+	min-width: 4rem;
+	padding: 0.25rem 0.375rem;
+	border: 1px solid #ced4da;
+	border-radius: 0.25rem;
+	font: inherit;
+}
+
+.cell-editor[type="checkbox"] {
+	width: auto;
+	min-width: 0;
+}
+
+.edit-save {
+	padding: 0.25rem 0.75rem;
+	border: 1px solid #007bff;
+	border-radius: 0.25rem;
+	background-color: #007bff;
+	color: #ffffff;
+	font-size: 0.875rem;
+	cursor: pointer;
+}
+
+.data-table[data-resizable] th:not(.frozen) {
+	position: relative;
+}
+
+.resize-grip {
+	position: absolute;
+	top: 0;
+	right: 0;
+	width: 6px;
+	height: 100%;
+	cursor: col-resize;
+	user-select: none;
+	touch-action: none;
+}
+
+[dir="rtl"] .resize-grip {
+	right: auto;
+	left: 0;
+}
+
+.resize-grip:hover {
+	background-color: #dee2e6;
+}
+
+.pagination .page-item {
+	display: block;
+}
+
+.pagination .page-link {
+	display: block;
+	padding: 0.5rem 0.75rem;
+	color: #007bff;
+	text-decoration: none;
+	border: 1px solid #dee2e6;
+	border-radius: 4px;
+	font-size: 0.875rem;
+}
+
+.pagination .page-link:hover {
+	background-color: #e9ecef;
+	border-color: #adb5bd;
+}
+
+.pagination .page-item.active .page-link {
+	background-color: #007bff;
+	border-color: #007bff;
+	color: white;
+}
+
+.pagination .page-item.disabled .page-link {
+	color: #6c757d;
+	background-color: white;
+	border-color: #dee2e6;
+	cursor: not-allowed;
+}
+
+.badge {
+	display: inline-block;
+	padding: 0.25em 0.5em;
+	border-radius: 4px;
+	font-size: 0.75rem;
+	font-weight: 600;
+	line-height: 1;
+}
+
+.badge-true {
+	background-color: #d4edda;
+	color: #155724;
+}
+
+.badge-false {
+	background-color: #f8d7da;
+	color: #721c24;
+}
+
+.keyboard-help-control {
+	position: relative;
+}
+
+.keyboard-help-btn {
+	padding: 0.375rem 0.5rem;
+	background: white;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	cursor: pointer;
+}
+
+.keyboard-help {
+	position: absolute;
+	inset-inline-end: 0;
+	top: 100%;
+	z-index: 10;
+	margin-top: 0.25rem;
+	padding: 0.75rem;
+	min-width: 220px;
+	background: white;
+	border: 1px solid #dee2e6;
+	border-radius: 4px;
+	box-shadow: 0 2px 6px rgba(0,0,0,0.15);
+	font-size: 0.875rem;
+}
+
+.keyboard-help dl {
+	display: grid;
+	grid-template-columns: auto 1fr;
+	gap: 0.25rem 0.75rem;
+	margin: 0.5rem 0 0;
+}
+
+.keyboard-help dd {
+	margin: 0;
+}
+
+.badge-green {
+	background-color: #d4edda;
+	color: #155724;
+}
+
+.badge-red {
+	background-color: #f8d7da;
+	color: #721c24;
+}
+
+.badge-yellow {
+	background-color: #fff3cd;
+	color: #856404;
+}
+
+.badge-blue {
+	background-color: #cce5ff;
+	color: #004085;
+}
+
+.badge-gray {
+	background-color: #e2e3e5;
+	color: #383d41;
+}
+
+.cell-truncated {
+	display: inline-block;
+	max-width: 100%;
+	overflow: hidden;
+	text-overflow: ellipsis;
+	white-space: nowrap;
+	vertical-align: bottom;
+}
+
+.data-table tbody tr.row-link {
+	cursor: pointer;
+}
+
+.data-table .row-number {
+	width: 1%;
+	white-space: nowrap;
+	text-align: end;
+	color: #6c757d;
+	font-variant-numeric: tabular-nums;
+}
+
+.data-table tbody td.cell-missing {
+	background-color: #fffbea;
+}
+
+.data-table tbody td.cell-invalid {
+	background-color: #fdf0f0;
+	box-shadow: inset 3px 0 0 #dc3545;
+}
+
+.data-quality-notice {
+	padding: 0.5rem 1rem;
+	background: #fff3cd;
+	border-bottom: 1px solid #ffeeba;
+	color: #856404;
+	font-size: 0.875rem;
+}
+
+.validation-warning {
+	padding: 0.5rem 1rem;
+	background: #f8d7da;
+	border-bottom: 1px solid #f5c6cb;
+	color: #721c24;
+	font-size: 0.875rem;
+}
+
+.validation-warning ul {
+	margin: 0.25rem 0 0;
+	padding-inline-start: 1.25rem;
+}
+
+.data-table .actions-cell {
+	width: 1%;
+	white-space: nowrap;
+}
+
+.row-actions {
+	display: flex;
+	gap: 0.25rem;
+}
+
+.action-form {
+	display: inline;
+	margin: 0;
+}
+
+.action-btn {
+	display: inline-block;
+	padding: 0.25rem 0.5rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	background: white;
+	color: #495057;
+	font-size: 0.75rem;
+	line-height: 1.2;
+	text-decoration: none;
+	cursor: pointer;
+}
+
+.action-btn:hover {
+	background: #e9ecef;
+}
+
+.action-primary {
+	border-color: #007bff;
+	background: #007bff;
+	color: white;
+}
+
+.action-danger {
+	border-color: #dc3545;
+	color: #dc3545;
+}
+
+.data-table.transposed tbody th {
+	padding: 0.75rem;
+	background: #f8f9fa;
+	border-bottom: 1px solid #dee2e6;
+	border-inline-end: 2px solid #dee2e6;
+	color: #495057;
+	font-size: 0.875rem;
+	font-weight: 600;
+	text-align: start;
+	white-space: nowrap;
+}
+
+.data-table th[data-column] {
+	cursor: pointer;
+}
+
+.data-table th[aria-sort="ascending"]::after {
+	content: " ▲";
+	font-size: 0.75rem;
+	color: #007bff;
+}
+
+.data-table th[aria-sort="descending"]::after {
+	content: " ▼";
+	font-size: 0.75rem;
+	color: #007bff;
+}
+
+.client-search input {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+}
+
+.client-pagination {
+	display: flex;
+	align-items: center;
+	justify-content: flex-end;
+	gap: 0.5rem;
+	padding: 0.5rem 1rem;
+}
+
+.data-table mark {
+	background: #fff3cd;
+	color: inherit;
+	padding: 0;
+}
+
+.facet-filters {
+	display: flex;
+	align-items: center;
+	gap: 0.5rem;
+}
+
+.facet-filters .facet {
+	display: flex;
+	align-items: center;
+	gap: 0.25rem;
+	font-size: 0.875rem;
+}
+
+.facet-filters select {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	font-size: 0.875rem;
+}
+
+.quick-filter input {
+	padding: 0.375rem 0.75rem;
+	border: 1px solid #ced4da;
+	border-radius: 4px;
+	font-size: 0.875rem;
+	min-width: 160px;
+}
+
+.table-scroll {
+	overflow-x: auto;
+}
+
+.data-table .text-end {
+	text-align: end;
+}
+
+@media screen and (max-width: 1119.98px) {
+	.data-table .priority-6 {
+		display: none;
+	}
+}
+
+@media screen and (max-width: 959.98px) {
+	.data-table .priority-5 {
+		display: none;
+	}
+}
+
+@media screen and (max-width: 799.98px) {
+	.data-table .priority-4 {
+		display: none;
+	}
+}
+
+@media screen and (max-width: 639.98px) {
+	.data-table .priority-3 {
+		display: none;
+	}
+}
+
+@media screen and (max-width: 479.98px) {
+	.data-table .priority-2 {
+		display: none;
+	}
+}
+
+.table-scroll-y {
+	overflow-y: auto;
+}
+
+.table-scroll-y .data-table thead th,
+.table-scroll-y .data-table[data-resizable] thead th:not(.frozen) {
+	position: sticky;
+	top: 0;
+	z-index: 2;
+	box-shadow: inset 0 -2px 0 #dee2e6;
+}
+
+.table-scroll-y .data-table thead th.frozen {
+	z-index: 3;
+}
+
+.data-table .frozen {
+	position: sticky;
+	inset-inline-start: 0;
+	z-index: 1;
+	background-color: #ffffff;
+}
+
+.data-table thead th.frozen {
+	z-index: 2;
+	background-color: #f8f9fa;
+}
+
+.data-table tbody tr:nth-child(even) .frozen {
+	background-color: #f8f9fa;
+}
+
+.data-table .select-cell {
+	width: 1%;
+	text-align: center;
+}
+
+.data-table tfoot td {
+	padding: 0.5rem 0.75rem;
+	background: #f8f9fa;
+	border-top: 2px solid #dee2e6;
+	vertical-align: top;
+}
+
+.column-stats {
+	display: grid;
+	grid-template-columns: auto 1fr;
+	gap: 0.125rem 0.5rem;
+	margin: 0;
+	font-size: 0.75rem;
+	color: #6c757d;
+}
+
+.column-stats dd {
+	margin: 0;
+	color: #212529;
+}
+
+.data-table tbody tr.empty-state-row:hover {
+	background-color: transparent;
+}
+
+.data-table tbody tr.group-header th {
+	padding: 0.5rem 0.75rem;
+	background: #e9ecef;
+	border-bottom: 1px solid #dee2e6;
+	color: #495057;
+	font-size: 0.875rem;
+	font-weight: 600;
+	text-align: start;
+}
+
+.data-table tbody tr.group-subtotal td {
+	font-weight: 600;
+	border-bottom: 2px solid #dee2e6;
+}
+
+.empty-state {
+	padding: 2rem 1rem;
+	text-align: center;
+	color: #6c757d;
+}
+
+.empty-state-icon {
+	font-size: 2rem;
+	line-height: 1;
+	margin-bottom: 0.5rem;
+}
+
+.empty-state-message {
+	margin: 0;
+}
+
+.empty-state-action {
+	margin-top: 1rem;
+}
+
+.no-results {
+	text-align: center;
+	padding: 2rem;
+	color: #6c757d;
+	font-style: italic;
+}
+
+.table-responsive {
+	overflow-x: auto;
+	-webkit-overflow-scrolling: touch;
+}
+`
+
+// printStyles is the CSS of the print view, see RenderPrint
+const printStyles = `
+.table-print {
+	box-shadow: none;
+	border-radius: 0;
+	overflow: visible;
+}
+
+.table-print .data-table thead {
+	display: table-header-group;
+}
+
+.table-print .data-table tfoot {
+	display: table-footer-group;
+}
+
+.table-print .data-table tr {
+	break-inside: avoid;
+	page-break-inside: avoid;
+}
+
+@media print {
+	.table-print {
+		font-size: 10pt;
+		print-color-adjust: exact;
+		-webkit-print-color-adjust: exact;
+	}
+
+	.table-print .data-table th,
+	.table-print .data-table td {
+		padding: 0.25rem 0.5rem;
+	}
+
+	.table-print a {
+		color: inherit;
+		text-decoration: none;
+	}
+}
+`
+
+// tableStyleSheet returns the CSS of a table, with the print view's when
+// print is set
+func tableStyleSheet(print bool) template.CSS {
+	if print {
+		return template.CSS(tableStyles + printStyles)
+	}
+	return template.CSS(tableStyles)
+}
@@ -3,7 +3,9 @@ package tablerenderer
 import (
 	"fmt"
 	"html/template"
+	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -12,7 +14,7 @@ import (
 type TableData struct {
 	Headers []string        `json:"headers"`
 	Rows    [][]interface{} `json:"rows"`
-	Data    interface{}     `json:"data,omitempty"` // New field for struct slices
+	Data    interface{}     `json:"data,omitempty"` // Struct slice or []map[string]interface{} used instead of Headers and Rows
 	Options TableOptions    `json:"options,omitempty"`
 }
 
@@ -34,9 +36,86 @@ type TableOptions struct {
 	Bordered   bool        `json:"bordered,omitempty"`
 	Responsive bool        `json:"responsive,omitempty"`
 	Style      string      `json:"style,omitempty"`
+	Direction  string      `json:"direction,omitempty"` // Text direction: "ltr" or "rtl" (default: "ltr")
 	Pagination *Pagination `json:"pagination,omitempty"`
 	Sorting    *Sorting    `json:"sorting,omitempty"`
 	Search     *Search     `json:"search,omitempty"`
+	Filters    []Filter    `json:"filters,omitempty"`     // Field filters with operators, e.g. from ParseFilters (needs FeatureAdvancedFilters)
+	Facets     []Facet     `json:"facets,omitempty"`      // Dropdown filters shown next to the search box (needs FeatureAdvancedFilters)
+	Columns    []Column    `json:"columns,omitempty"`     // Per-column configuration
+	RawHeaders bool        `json:"raw_headers,omitempty"` // Show struct field and json tag names as they are instead of humanized labels
+	MaxHeight  string      `json:"max_height,omitempty"`  // CSS max height, e.g. "400px" or "50vh", scrolling the rows under a sticky header
+	Toolbar    *Toolbar    `json:"toolbar,omitempty"`     // Toolbar layout and custom controls
+	JSPolicy   JSPolicy    `json:"js_policy,omitempty"`   // Whether JavaScript may be emitted (default: "inline")
+
+	KeyboardShortcuts bool          `json:"keyboard_shortcuts,omitempty"` // "/" focuses search, arrow keys page, "e" exports, "?" shows help
+	OmitStyles        bool          `json:"omit_styles,omitempty"`        // Leave out the inline <style> block; include the table CSS once in the page layout
+	OmitScripts       bool          `json:"omit_scripts,omitempty"`       // Render markers instead of inline scripts; include Assets().JS once in the page layout
+	Minify            bool          `json:"minify,omitempty"`             // Collapse whitespace between tags in the output
+	Statistics        *Statistics   `json:"statistics,omitempty"`         // Footer with per-column count, distinct, null, min and max values
+	RowNumbers        bool          `json:"row_numbers,omitempty"`        // Prepend a "#" column numbering rows across pages, e.g. page 3 of 10 rows starts at 21
+	Selection         *Selection    `json:"selection,omitempty"`          // Checkbox column with select-all for bulk operations
+	HighlightMissing  bool          `json:"highlight_missing,omitempty"`  // Flag empty and unparseable cells and show how many there are
+	Actions           *Actions      `json:"actions,omitempty"`            // Column of per-row action links and buttons
+	InlineEdit        *InlineEdit   `json:"inline_edit,omitempty"`        // Render editable columns as inputs saved per row or per table (needs FeatureInlineEdit)
+	Source            *SourceFormat `json:"source,omitempty"`             // Hidden field shown as a per-row provenance badge or tooltip
+	RowData           []string      `json:"row_data,omitempty"`           // Headers emitted as data-* attributes on each <tr>, e.g. "ID" as data-id
+	FrozenColumns     int           `json:"frozen_columns,omitempty"`     // Keep the first N columns visible when scrolling wide tables horizontally
+	QuickFilter       bool          `json:"quick_filter,omitempty"`       // Client-side box hiding rows of the current page that do not match (needs scripts)
+	CopyButton        bool          `json:"copy_button,omitempty"`        // Button copying the rows shown as tab-separated values, e.g. to paste into a spreadsheet (needs scripts)
+	ClientSide        *ClientSide   `json:"client_side,omitempty"`        // Sort, search and page all rendered rows in the browser (needs scripts)
+	DataTables        *DataTables   `json:"datatables,omitempty"`         // Hand sorting, search and paging to DataTables.js
+	TurboFrame        *TurboFrame   `json:"turbo_frame,omitempty"`        // Wrap the table in a Hotwire <turbo-frame> its controls navigate
+	VisibleColumns    []string      `json:"visible_columns,omitempty"`    // Headers shown, in order; others are dropped before rendering (default: all)
+	Transpose         bool          `json:"transpose,omitempty"`          // Show headers down the first column and records across, e.g. to compare a few records with many fields
+	EmptyState        *EmptyState   `json:"empty_state,omitempty"`        // Message, icon and call to action shown inside the table body when there are no rows
+	ExportLinks       *ExportLinks  `json:"export_links,omitempty"`       // Export URLs listed in the link map
+	EmbedLinks        bool          `json:"embed_links,omitempty"`        // Embed the sort, page and export URLs as JSON (script.table-links) for client scripts
+	GroupBy           *GroupBy      `json:"group_by,omitempty"`           // Split rows into sections with header and subtotal rows, e.g. by day
+	Print             bool          `json:"print,omitempty"`              // Print view: no pagination or other controls, print styles repeating the header on each page; see RenderPrint
+
+	// ResizableColumns adds grips to the header cells for dragging column
+	// widths, which persist in the WidthsParam parameter and localStorage
+	// (needs scripts). ColumnWidths are the widths in pixels by header, e.g.
+	// from that parameter.
+	ResizableColumns bool           `json:"resizable_columns,omitempty"`
+	ColumnWidths     map[string]int `json:"column_widths,omitempty"`
+	WidthsParam      string         `json:"widths_param,omitempty"` // Query parameter name for the column widths (default: "widths")
+
+	// CSP renders output a strict Content-Security-Policy allows: no inline
+	// event handlers or style attributes, and with a Nonce the <style> and
+	// <script> elements carry it. Styles otherwise set by style attributes,
+	// e.g. of MaxHeight or badge colors, become classes whose rules
+	// CSPStyles returns; they are added to the <style> block. Without a
+	// Nonce the <style> block is left out and scripts are replaced by
+	// markers, as with OmitStyles and OmitScripts; see Renderer.Assets and
+	// CSPStyles.
+	CSP   bool   `json:"csp,omitempty"`
+	Nonce string `json:"-"` // Per-response CSP nonce, e.g. from the request context
+
+	// Validation checks the rows and options before rendering, see
+	// Renderer.Validate (default: off)
+	Validation ValidationMode `json:"validation,omitempty"`
+
+	// RowFilter hides rows for which it returns false, e.g. to trim rows the
+	// current user may not see. It runs on the rows handed to the renderer,
+	// after the page was fetched, so it is a convenience for small tables and
+	// not a security boundary on its own: pair it with the equivalent SQL
+	// constraint so that counts, pages and other queries agree with it.
+	RowFilter func(row RowView) bool `json:"-"`
+
+	// RowDataFunc returns extra data-* attributes for a row, keyed by name
+	// without the "data-" prefix, e.g. {"status": "open"} for data-status
+	RowDataFunc func(row RowView) map[string]string `json:"-"`
+
+	// CellClassFunc returns extra CSS classes for a cell from its row,
+	// header and raw value, e.g. "negative" for amounts below zero, or ""
+	// for none
+	CellClassFunc func(row RowView, header string, value interface{}) string `json:"-"`
+
+	// stateApplied marks options the OnState hooks already ran on, so
+	// options passed on to another render are not changed twice
+	stateApplied bool
 }
 
 // Pagination holds pagination configuration
@@ -47,11 +126,41 @@ type Pagination struct {
 	ShowControls    bool   `json:"show_controls"`
 	ShowInfo        bool   `json:"show_info"`
 	ShowPageSizer   bool   `json:"show_page_sizer,omitempty"`   // Show page size dropdown
+	ShowJumpToPage  bool   `json:"show_jump_to_page,omitempty"` // Show a "Go to page" input with the page links
+	ShowFirstLast   bool   `json:"show_first_last,omitempty"`   // Show First and Last buttons around Previous and Next
+	ShowEllipsis    bool   `json:"show_ellipsis,omitempty"`     // Link the first and last page past the window, with "…" for the pages skipped
+	PageWindow      int    `json:"page_window,omitempty"`       // Pages linked on each side of the current page (default: 2)
 	PageSizeOptions []int  `json:"page_size_options,omitempty"` // Available page size options
+	ShowAllOption   bool   `json:"show_all_option,omitempty"`   // Offer "All" in the page size dropdown, showing every row up to MaxAllRows
+	MaxAllRows      int    `json:"max_all_rows,omitempty"`      // Safety cap on the rows "All" shows (default: 1000)
+	AllRows         bool   `json:"all_rows,omitempty"`          // Whether "All" is chosen; PageSize then holds the cap
 	BaseURL         string `json:"base_url,omitempty"`          // Base URL for pagination links
 	QueryParam      string `json:"query_param,omitempty"`       // Query parameter name for page (default: "page")
 	PreserveQuery   bool   `json:"preserve_query,omitempty"`    // Whether to preserve other query parameters
 	TotalCount      int    `json:"total_count,omitempty"`       // Total records (for database pagination)
+	SkipCount       bool   `json:"skip_count,omitempty"`        // Page without a total count: the source sets HasNext instead of TotalCount, see TrimPage
+	HasNext         bool   `json:"has_next,omitempty"`          // Whether rows follow the current page, with SkipCount
+
+	// Cursor pages with after/before cursors instead of page numbers, see
+	// Keyset and CursorPage. The controls show only Previous and Next.
+	Cursor     bool   `json:"cursor,omitempty"`
+	After      string `json:"after,omitempty"`       // Cursor the requested page starts after
+	Before     string `json:"before,omitempty"`      // Cursor the requested page ends before
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor of the page's last row, set by the source when rows follow
+	PrevCursor string `json:"prev_cursor,omitempty"` // Cursor of the page's first row, set by the source when rows precede
+
+	// LoadMore replaces the page links with a single button appending the
+	// rows of the next page to the table. Without scripts it links to the
+	// next page. With HTMX, tables sharing a page need distinct IDs.
+	LoadMore       bool `json:"load_more,omitempty"`
+	HTMX           bool `json:"htmx,omitempty"`            // Load more with htmx attributes instead of the built-in script
+	InfiniteScroll bool `json:"infinite_scroll,omitempty"` // Load more as the button scrolls into view; implies LoadMore (needs FeatureLiveUpdates)
+
+	// ApproximateTotal marks TotalCount as an estimate, e.g. from
+	// pg_class.reltuples. Counts read "about 1.2M entries", and neither the
+	// estimated last page nor pages past MaxPages are linked.
+	ApproximateTotal bool `json:"approximate_total,omitempty"`
+	MaxPages         int  `json:"max_pages,omitempty"` // Highest page linked with ApproximateTotal (default: 1000)
 }
 
 // Sorting holds sorting configuration for server-side sorting
@@ -62,6 +171,12 @@ type Sorting struct {
 	BaseURL    string `json:"base_url,omitempty"`    // Base URL for sorting links
 	QueryParam string `json:"query_param,omitempty"` // Query parameter name for sort (default: "sort_by")
 	OrderParam string `json:"order_param,omitempty"` // Query parameter name for order (default: "sort_order")
+	Cycle      string `json:"cycle,omitempty"`       // Header click cycle: SortCycleTwoState or SortCycleThreeState (default: SortCycleTwoState)
+
+	// NullsPosition puts empty values first or last regardless of the sort
+	// order, so the table sorts the same on every database
+	NullsPosition string `json:"nulls_position,omitempty"` // "first" or "last" (default: database order)
+	NullsParam    string `json:"nulls_param,omitempty"`    // Query parameter name for the nulls position (default: "nulls")
 }
 
 // Search holds search configuration for server-side search
@@ -74,11 +189,22 @@ type Search struct {
 	BaseURL       string   `json:"base_url,omitempty"`       // Base URL for search
 	QueryParam    string   `json:"query_param,omitempty"`    // Query parameter name (default: "search")
 	MinLength     int      `json:"min_length,omitempty"`     // Minimum search length (default: 1)
+	Highlight     bool     `json:"highlight,omitempty"`      // Mark the matches of the search term in the cells with <mark>
+	Live          bool     `json:"live,omitempty"`           // Submit the search while typing (needs scripts and FeatureLiveUpdates)
+	LiveDelay     int      `json:"live_delay,omitempty"`     // Pause in typing before a live search is submitted, in milliseconds (default: 300)
 }
 
 // Renderer is the main struct for rendering tables
 type Renderer struct {
-	// Remove unused template field to fix lint warning
+	Translator  Translator  // Resolves user-facing labels (default: LocaleEnglish)
+	TimeLayout  string      // Default layout for time.Time cells (default: time.RFC3339)
+	BoolFormat  *BoolFormat // Default text for bool cells (default: BoolCheckmarks)
+	Placeholder string      // Default text for nil, empty and zero-time cells, e.g. "—" (default: empty)
+	Features    Features    // Experimental subsystems to enable, e.g. FeatureInlineEdit (default: none)
+	Icons       IconSet     // Icons of the search, sort and keyboard help controls (default: IconsText)
+
+	plugins []Plugin
+	tables  map[string]TableDefinition
 }
 
 // NewRenderer creates a new table renderer instance
@@ -94,6 +220,8 @@ type PaginationInfo struct {
 	PageSize    int
 	StartRow    int
 	EndRow      int
+	Uncounted   bool // Totals cover only the pages seen so far, with Pagination.SkipCount
+	Approximate bool // TotalRows is an estimate, with Pagination.ApproximateTotal
 }
 
 // calculatePagination calculates pagination for database-level pagination
@@ -109,6 +237,12 @@ func (r *Renderer) calculatePagination(currentPageDataCount int, pagination *Pag
 			EndRow:      currentPageDataCount,
 		}
 	}
+	if pagination.SkipCount {
+		return uncountedPagination(currentPageDataCount, pagination)
+	}
+	if pagination.ApproximateTotal && pagination.TotalCount > 0 {
+		return approximatePagination(currentPageDataCount, pagination)
+	}
 
 	// Use TotalCount from pagination config for database pagination
 	totalRows := pagination.TotalCount
@@ -143,112 +277,238 @@ func (r *Renderer) calculatePagination(currentPageDataCount int, pagination *Pag
 }
 
 // generatePaginationHTML generates HTML for pagination controls
-func (r *Renderer) generatePaginationHTML(paginationInfo PaginationInfo, pagination *Pagination, currentQueryParams map[string]string) string {
+func (r *Renderer) generatePaginationHTML(paginationInfo PaginationInfo, pagination *Pagination, currentQueryParams map[string]string, frame string) string {
+	if pagination.Cursor {
+		return r.generateCursorPaginationHTML(pagination, currentQueryParams, frame)
+	}
 	if paginationInfo.TotalPages <= 1 {
 		return ""
 	}
 
-	// Set defaults for URL generation
-	baseURL := pagination.BaseURL
-	if baseURL == "" {
-		baseURL = ""
-	}
-	queryParam := pagination.QueryParam
-	if queryParam == "" {
-		queryParam = "page"
+	generateURL := func(page int) string {
+		return pageURL(pagination, currentQueryParams, page)
 	}
 
-	// Helper function to generate URL for a page while preserving other query parameters
-	generateURL := func(page int) string {
-		params := make([]string, 0)
+	// Without a total only the neighbouring pages are known
+	if paginationInfo.Uncounted {
+		var previousURL, nextURL string
+		if paginationInfo.CurrentPage > 1 {
+			previousURL = generateURL(paginationInfo.CurrentPage - 1)
+		}
+		if pagination.HasNext {
+			nextURL = generateURL(paginationInfo.CurrentPage + 1)
+		}
+		return r.generatePrevNextHTML("pagination-uncounted", previousURL, nextURL, frame)
+	}
 
-		// Add page parameter
-		params = append(params, fmt.Sprintf("%s=%d", queryParam, page))
+	previousLabel := template.HTMLEscapeString(r.translate(LabelPrevious, 1))
+	nextLabel := template.HTMLEscapeString(r.translate(LabelNext, 1))
+	frameAttr := turboFrameAttr(frame)
 
-		// Add other preserved parameters (like sorting)
-		for key, value := range currentQueryParams {
-			if key != queryParam { // Don't duplicate page param
-				params = append(params, fmt.Sprintf("%s=%s", key, value))
-			}
-		}
+	var html strings.Builder
 
-		queryString := strings.Join(params, "&")
+	html.WriteString(`<ul class="pagination">`)
 
-		if baseURL == "" {
-			return "?" + queryString
+	pageLink := func(page int) {
+		if page == paginationInfo.CurrentPage {
+			html.WriteString(fmt.Sprintf(`<li class="page-item active"><span class="page-link">%d</span></li>`, page))
+		} else {
+			html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" href="%s"%s>%d</a></li>`,
+				template.HTMLEscapeString(generateURL(page)), frameAttr, page))
 		}
-		if strings.Contains(baseURL, "?") {
-			return baseURL + "&" + queryString
+	}
+	// edgeLink links the first or last page, disabled when it is current
+	edgeLink := func(page int, class string, label string) {
+		if page == paginationInfo.CurrentPage {
+			html.WriteString(fmt.Sprintf(`<li class="page-item %s disabled"><span class="page-link">%s</span></li>`, class, label))
+		} else {
+			html.WriteString(fmt.Sprintf(`<li class="page-item %s"><a class="page-link" href="%s"%s>%s</a></li>`,
+				class, template.HTMLEscapeString(generateURL(page)), frameAttr, label))
 		}
-		return baseURL + "?" + queryString
 	}
+	ellipsis := `<li class="page-item disabled page-ellipsis"><span class="page-link">…</span></li>`
 
-	var html strings.Builder
-
-	html.WriteString(`<ul class="pagination">`)
+	if pagination.ShowFirstLast {
+		edgeLink(1, "page-first", template.HTMLEscapeString(r.translate(LabelFirst, 1)))
+	}
 
 	// Previous button
 	if paginationInfo.CurrentPage > 1 {
-		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" href="%s">Previous</a></li>`,
-			generateURL(paginationInfo.CurrentPage-1)))
+		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" rel="prev" href="%s"%s>%s</a></li>`,
+			template.HTMLEscapeString(generateURL(paginationInfo.CurrentPage-1)), frameAttr, previousLabel))
 	} else {
-		html.WriteString(`<li class="page-item disabled"><span class="page-link">Previous</span></li>`)
+		html.WriteString(fmt.Sprintf(`<li class="page-item disabled"><span class="page-link">%s</span></li>`, previousLabel))
 	}
 
 	// Page numbers
+	start, end := pageWindow(paginationInfo, pagination.PageWindow)
+
+	// With the ellipsis, a gap of a single page shows that page instead
+	if pagination.ShowEllipsis && start > 1 {
+		pageLink(1)
+		if start == 3 {
+			pageLink(2)
+		} else if start > 3 {
+			html.WriteString(ellipsis)
+		}
+	}
+	for i := start; i <= end; i++ {
+		pageLink(i)
+	}
+	// An estimated last page may not exist, so only the ellipsis is shown
+	if pagination.ShowEllipsis && paginationInfo.Approximate && end < paginationInfo.TotalPages {
+		html.WriteString(ellipsis)
+	} else if pagination.ShowEllipsis && end < paginationInfo.TotalPages {
+		if end == paginationInfo.TotalPages-2 {
+			pageLink(end + 1)
+		} else if end < paginationInfo.TotalPages-2 {
+			html.WriteString(ellipsis)
+		}
+		pageLink(paginationInfo.TotalPages)
+	}
+
+	// Next button
+	if paginationInfo.CurrentPage < paginationInfo.TotalPages {
+		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" rel="next" href="%s"%s>%s</a></li>`,
+			template.HTMLEscapeString(generateURL(paginationInfo.CurrentPage+1)), frameAttr, nextLabel))
+	} else {
+		html.WriteString(fmt.Sprintf(`<li class="page-item disabled"><span class="page-link">%s</span></li>`, nextLabel))
+	}
+
+	if pagination.ShowFirstLast && !paginationInfo.Approximate {
+		edgeLink(paginationInfo.TotalPages, "page-last", template.HTMLEscapeString(r.translate(LabelLast, 1)))
+	}
+
+	html.WriteString(`</ul>`)
+
+	return html.String()
+}
+
+// pageWindow returns the first and last page number linked from the
+// pagination controls: radius pages on each side of the current page
+// (default: 2), shifted to keep the window full near either end
+func pageWindow(paginationInfo PaginationInfo, radius int) (int, int) {
+	if radius <= 0 {
+		radius = 2
+	}
+	size := 2*radius + 1
 	start := 1
 	end := paginationInfo.TotalPages
 
-	// Show only 5 pages around current page for large datasets
-	if paginationInfo.TotalPages > 5 {
-		start = paginationInfo.CurrentPage - 2
+	// Show only the window around the current page for large datasets
+	if paginationInfo.TotalPages > size {
+		start = paginationInfo.CurrentPage - radius
 		if start < 1 {
 			start = 1
 		}
-		end = start + 4
+		end = start + size - 1
 		if end > paginationInfo.TotalPages {
 			end = paginationInfo.TotalPages
-			start = end - 4
+			start = end - size + 1
 			if start < 1 {
 				start = 1
 			}
 		}
 	}
+	return start, end
+}
 
-	for i := start; i <= end; i++ {
-		if i == paginationInfo.CurrentPage {
-			html.WriteString(fmt.Sprintf(`<li class="page-item active"><span class="page-link">%d</span></li>`, i))
-		} else {
-			html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" href="%s">%d</a></li>`,
-				generateURL(i), i))
-		}
+// pageURL generates the URL of a page while preserving other query parameters
+func pageURL(pagination *Pagination, currentQueryParams map[string]string, page int) string {
+	baseURL := pagination.BaseURL
+	queryParam := pagination.QueryParam
+	if queryParam == "" {
+		queryParam = "page"
 	}
 
-	// Next button
-	if paginationInfo.CurrentPage < paginationInfo.TotalPages {
-		html.WriteString(fmt.Sprintf(`<li class="page-item"><a class="page-link" href="%s">Next</a></li>`,
-			generateURL(paginationInfo.CurrentPage+1)))
-	} else {
-		html.WriteString(`<li class="page-item disabled"><span class="page-link">Next</span></li>`)
-	}
+	// Preserve other parameters (like sorting) and set the page
+	params := queryValues(currentQueryParams)
+	params.Set(queryParam, strconv.Itoa(page))
 
-	html.WriteString(`</ul>`)
+	return linkURL(baseURL, params)
+}
 
-	return html.String()
+// paginationLinkParams returns the query parameters preserved in pagination
+// links: the current sort order, page size and search term
+func (r *Renderer) paginationLinkParams(options TableOptions, paginationInfo PaginationInfo) map[string]string {
+	// Parse current query parameters to preserve them in pagination links
+	currentParams := r.parseQueryParams(options.Pagination.BaseURL)
+	if options.Sorting != nil && options.Sorting.Enabled {
+		if options.Sorting.SortBy != "" {
+			currentParams["sort_by"] = options.Sorting.SortBy
+		}
+		if options.Sorting.SortOrder != "" {
+			currentParams["sort_order"] = options.Sorting.SortOrder
+		}
+	}
+	setNullsParam(currentParams, options.Sorting)
+	setFilterParams(currentParams, options.Filters)
+	setColumnsParam(currentParams, options.VisibleColumns)
+	setWidthsParam(currentParams, options)
+	// Add current page size to preserve it in pagination links
+	if paginationInfo.PageSize > 0 {
+		currentParams["page_size"] = pageSizeValue(options.Pagination, paginationInfo.PageSize)
+	}
+	// Add current search term to preserve it in pagination links
+	if options.Search != nil && options.Search.Enabled && options.Search.SearchTerm != "" {
+		searchParam := options.Search.QueryParam
+		if searchParam == "" {
+			searchParam = "search"
+		}
+		currentParams[searchParam] = options.Search.SearchTerm
+	}
+	return currentParams
+}
+
+// sortLinkParams returns the query parameters preserved in sorting links:
+// the current page, page size and search term
+func (r *Renderer) sortLinkParams(options TableOptions, paginationInfo PaginationInfo) map[string]string {
+	// Parse current query parameters to preserve them in sorting links
+	currentParams := r.parseQueryParams(options.Sorting.BaseURL)
+	if options.Pagination != nil && options.Pagination.Enabled {
+		// Preserve current page in sorting links
+		currentParams["page"] = fmt.Sprintf("%d", options.Pagination.CurrentPage)
+		// Add current page size to preserve it in sorting links
+		if paginationInfo.PageSize > 0 {
+			currentParams["page_size"] = pageSizeValue(options.Pagination, paginationInfo.PageSize)
+		}
+	}
+	// Add current search term to preserve it in sorting links
+	if options.Search != nil && options.Search.Enabled && options.Search.SearchTerm != "" {
+		searchParam := options.Search.QueryParam
+		if searchParam == "" {
+			searchParam = "search"
+		}
+		currentParams[searchParam] = options.Search.SearchTerm
+	}
+	setNullsParam(currentParams, options.Sorting)
+	setFilterParams(currentParams, options.Filters)
+	setColumnsParam(currentParams, options.VisibleColumns)
+	setWidthsParam(currentParams, options)
+	return currentParams
 }
 
 // generatePaginationInfoHTML generates HTML showing pagination information
 func (r *Renderer) generatePaginationInfoHTML(paginationInfo PaginationInfo) string {
 	if paginationInfo.TotalRows == 0 {
-		return `No records found`
+		return template.HTMLEscapeString(r.translate(LabelNoRecords, 0))
+	}
+	if paginationInfo.Uncounted {
+		count := paginationInfo.EndRow - paginationInfo.StartRow + 1
+		return template.HTMLEscapeString(r.translate(LabelShowingCount, count, count))
+	}
+	if paginationInfo.Approximate {
+		return template.HTMLEscapeString(r.translate(LabelShowingEstimate, paginationInfo.TotalRows,
+			paginationInfo.StartRow, paginationInfo.EndRow, formatApproximateCount(paginationInfo.TotalRows)))
 	}
 
-	return fmt.Sprintf(`Showing %d to %d of %d entries`,
-		paginationInfo.StartRow, paginationInfo.EndRow, paginationInfo.TotalRows)
+	return template.HTMLEscapeString(r.translate(LabelShowingEntries, paginationInfo.TotalRows,
+		paginationInfo.StartRow, paginationInfo.EndRow, paginationInfo.TotalRows))
 }
 
 // generatePageSizeHTML generates HTML for page size dropdown
-func (r *Renderer) generatePageSizeHTML(pagination *Pagination, currentQueryParams map[string]string) string {
+// Without scripts the sizes are rendered as plain links instead of a dropdown
+func (r *Renderer) generatePageSizeHTML(pagination *Pagination, currentQueryParams map[string]string, allowScripts bool, frame string) string {
 	if pagination == nil || !pagination.ShowPageSizer {
 		return ""
 	}
@@ -266,43 +526,63 @@ func (r *Renderer) generatePageSizeHTML(pagination *Pagination, currentQueryPara
 	}
 
 	// Helper function to generate URL for a page size while preserving other query parameters
-	generateURL := func(pageSize int) string {
-		params := make([]string, 0)
-
-		// Add page size parameter
-		params = append(params, fmt.Sprintf("page_size=%d", pageSize))
+	generateURL := func(pageSize string) string {
+		params := queryValues(currentQueryParams)
+		params.Set("page_size", pageSize)
 
 		// Reset to page 1 when changing page size
-		params = append(params, "page=1")
+		params.Set("page", "1")
 
-		// Add other preserved parameters (except page and page_size)
-		for key, value := range currentQueryParams {
-			if key != "page" && key != "page_size" {
-				params = append(params, fmt.Sprintf("%s=%s", key, value))
-			}
-		}
+		return linkURL(baseURL, params)
+	}
 
-		queryString := strings.Join(params, "&")
+	var html strings.Builder
 
-		if baseURL == "" {
-			return "?" + queryString
+	if !allowScripts {
+		html.WriteString(`<span class="page-size-links">`)
+		for _, size := range options {
+			label := template.HTMLEscapeString(r.translate(LabelEntriesPerPage, size, size))
+			if size == pagination.PageSize && !pagination.AllRows {
+				html.WriteString(fmt.Sprintf(`<strong aria-current="true">%s</strong> `, label))
+			} else {
+				html.WriteString(fmt.Sprintf(`<a href="%s"%s>%s</a> `, template.HTMLEscapeString(generateURL(strconv.Itoa(size))), turboFrameAttr(frame), label))
+			}
 		}
-		if strings.Contains(baseURL, "?") {
-			return baseURL + "&" + queryString
+		if pagination.ShowAllOption {
+			label := template.HTMLEscapeString(r.translate(LabelAllRows, 1))
+			if pagination.AllRows {
+				html.WriteString(fmt.Sprintf(`<strong aria-current="true">%s</strong> `, label))
+			} else {
+				html.WriteString(fmt.Sprintf(`<a href="%s"%s>%s</a> `, template.HTMLEscapeString(generateURL(pageSizeAll)), turboFrameAttr(frame), label))
+			}
 		}
-		return baseURL + "?" + queryString
+		html.WriteString(`</span>`)
+		return html.String()
 	}
 
-	var html strings.Builder
-	html.WriteString(`<select onchange="window.location.href=this.value">`)
+	// formControlsScript navigates on change, loading the page size into the
+	// frame when Turbo is available
+	if frame != "" {
+		html.WriteString(fmt.Sprintf(`<select data-table-navigate data-frame="%s">`, template.HTMLEscapeString(frame)))
+	} else {
+		html.WriteString(`<select data-table-navigate>`)
+	}
 
 	for _, size := range options {
 		selected := ""
-		if size == pagination.PageSize {
+		if size == pagination.PageSize && !pagination.AllRows {
 			selected = " selected"
 		}
-		html.WriteString(fmt.Sprintf(`<option value="%s"%s>%d entries per page</option>`,
-			generateURL(size), selected, size))
+		html.WriteString(fmt.Sprintf(`<option value="%s"%s>%s</option>`,
+			template.HTMLEscapeString(generateURL(strconv.Itoa(size))), selected, template.HTMLEscapeString(r.translate(LabelEntriesPerPage, size, size))))
+	}
+	if pagination.ShowAllOption {
+		selected := ""
+		if pagination.AllRows {
+			selected = " selected"
+		}
+		html.WriteString(fmt.Sprintf(`<option value="%s"%s>%s</option>`,
+			template.HTMLEscapeString(generateURL(pageSizeAll)), selected, template.HTMLEscapeString(r.translate(LabelAllRows, 1))))
 	}
 
 	html.WriteString(`</select>`)
@@ -311,7 +591,7 @@ func (r *Renderer) generatePageSizeHTML(pagination *Pagination, currentQueryPara
 }
 
 // generateSearchHTML generates HTML for search input
-func (r *Renderer) generateSearchHTML(search *Search, currentQueryParams map[string]string) string {
+func (r *Renderer) generateSearchHTML(search *Search, currentQueryParams map[string]string, frame string) string {
 	if search == nil || !search.Enabled {
 		return ""
 	}
@@ -319,7 +599,7 @@ func (r *Renderer) generateSearchHTML(search *Search, currentQueryParams map[str
 	// Set defaults
 	placeholder := search.Placeholder
 	if placeholder == "" {
-		placeholder = "Search all columns..."
+		placeholder = r.translate(LabelSearchPlaceholder, 1)
 	}
 	queryParam := search.QueryParam
 	if queryParam == "" {
@@ -333,81 +613,54 @@ func (r *Renderer) generateSearchHTML(search *Search, currentQueryParams map[str
 	// Get current search term
 	searchTerm := search.SearchTerm
 
-	// Build form action URL with preserved parameters
-	actionParams := make([]string, 0)
-	for key, value := range currentQueryParams {
-		if key != queryParam && key != "page" { // Exclude search param and reset page
-			actionParams = append(actionParams, fmt.Sprintf("%s=%s", key, value))
-		}
-	}
+	// Preserve other parameters, except the search term and page
+	params := queryValues(currentQueryParams)
+	params.Del(queryParam)
+	params.Del("page")
 
-	var actionURL string
-	if len(actionParams) > 0 {
-		queryString := strings.Join(actionParams, "&")
-		if baseURL == "" {
-			actionURL = "?" + queryString
-		} else if strings.Contains(baseURL, "?") {
-			actionURL = baseURL + "&" + queryString
-		} else {
-			actionURL = baseURL + "?" + queryString
-		}
-	} else {
-		actionURL = baseURL
-		if actionURL == "" {
-			actionURL = ""
-		}
+	// Browsers replace the query of a GET form's action with the form
+	// fields, so the preserved parameters go into hidden inputs
+	actionURL := ""
+	if baseURL != "" {
+		actionURL = linkURL(baseURL, nil)
 	}
 
 	var html strings.Builder
-	html.WriteString(`<form method="GET" action="` + actionURL + `" class="search-form">`)
+	live := ""
+	if search.Live {
+		live = fmt.Sprintf(` data-live-delay="%d" data-min-length="%d"`, liveSearchDelay(search), max(search.MinLength, 1))
+	}
+	html.WriteString(fmt.Sprintf(`<form method="GET" action="%s" class="search-form"%s%s>`, template.HTMLEscapeString(actionURL), turboFrameAttr(frame), live))
 
 	// Add hidden fields for preserved parameters
-	for key, value := range currentQueryParams {
-		if key != queryParam && key != "page" {
-			html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`, key, value))
-		}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		html.WriteString(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+			template.HTMLEscapeString(key), template.HTMLEscapeString(params.Get(key))))
 	}
 
-	html.WriteString(`<label>Search:</label>`)
+	html.WriteString(fmt.Sprintf(`<label>%s</label>`, template.HTMLEscapeString(r.translate(LabelSearch, 1))))
 	html.WriteString(`<div class="search-input-group">`)
 	html.WriteString(fmt.Sprintf(`<input type="text" name="%s" placeholder="%s" value="%s">`,
-		queryParam, placeholder, searchTerm))
+		template.HTMLEscapeString(queryParam), template.HTMLEscapeString(placeholder), template.HTMLEscapeString(searchTerm)))
 
 	// Add search button
-	html.WriteString(`<button type="submit" class="search-btn" title="Search">🔍</button>`)
+	html.WriteString(fmt.Sprintf(`<button type="submit" class="search-btn" title="%s">%s</button>`,
+		template.HTMLEscapeString(r.translate(LabelSearchButton, 1)), r.icon(IconSearch)))
 
 	// Clear search button if there's a search term
 	if searchTerm != "" {
-		clearURL := actionURL
-		if clearURL == "" {
-			clearURL = "?"
-		} else if !strings.Contains(clearURL, "?") {
-			clearURL += "?"
-		} else {
-			clearURL += "&"
-		}
-		// Add preserved parameters for clear URL
-		clearParams := make([]string, 0)
-		for key, value := range currentQueryParams {
-			if key != queryParam && key != "page" {
-				clearParams = append(clearParams, fmt.Sprintf("%s=%s", key, value))
-			}
-		}
-		if len(clearParams) > 0 {
-			if strings.HasSuffix(clearURL, "?") {
-				clearURL += strings.Join(clearParams, "&")
-			} else {
-				clearURL += strings.Join(clearParams, "&")
-			}
-		} else {
-			clearURL = strings.TrimSuffix(clearURL, "?")
-			clearURL = strings.TrimSuffix(clearURL, "&")
-			if clearURL == "" {
-				clearURL = "/"
-			}
+		clearURL := linkURL(baseURL, params)
+		if clearURL == "?" {
+			clearURL = "/"
 		}
 
-		html.WriteString(fmt.Sprintf(`<a href="%s" class="search-clear-btn" title="Clear search">×</a>`, clearURL))
+		html.WriteString(fmt.Sprintf(`<a href="%s" class="search-clear-btn" title="%s"%s>%s</a>`,
+			template.HTMLEscapeString(clearURL), template.HTMLEscapeString(r.translate(LabelClearSearch, 1)), turboFrameAttr(frame), r.icon(IconClear)))
 	}
 
 	html.WriteString(`</div>`)
@@ -418,23 +671,8 @@ func (r *Renderer) generateSearchHTML(search *Search, currentQueryParams map[str
 
 // extractHeadersFromStruct extracts field names from a struct type to use as headers
 func extractHeadersFromStruct(structType reflect.Type) []string {
-	var headers []string
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
-		// Use json tag if available, otherwise use field name
-		if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
-			// Remove omitempty and other options
-			tagName := strings.Split(tag, ",")[0]
-			if tagName != "" {
-				headers = append(headers, tagName)
-			} else {
-				headers = append(headers, field.Name)
-			}
-		} else {
-			headers = append(headers, field.Name)
-		}
-	}
-	return headers
+	// Copy so callers cannot change the cached headers
+	return append([]string(nil), structInfoFor(structType).headers...)
 }
 
 // convertStructSliceToRows converts a slice of structs to [][]interface{}
@@ -465,6 +703,7 @@ func convertStructSliceToRows(data interface{}) ([]string, [][]interface{}, erro
 	}
 
 	structType := firstElem.Type()
+	info := structInfoFor(structType)
 	headers := extractHeadersFromStruct(structType)
 
 	// Convert each struct to a row
@@ -475,9 +714,9 @@ func convertStructSliceToRows(data interface{}) ([]string, [][]interface{}, erro
 			elem = elem.Elem()
 		}
 
-		row := make([]interface{}, elem.NumField())
-		for j := 0; j < elem.NumField(); j++ {
-			row[j] = elem.Field(j).Interface()
+		row := make([]interface{}, len(info.fields))
+		for j, index := range info.fields {
+			row[j] = structFieldValue(elem, index)
 		}
 		rows[i] = row
 	}
@@ -485,27 +724,147 @@ func convertStructSliceToRows(data interface{}) ([]string, [][]interface{}, erro
 	return headers, rows, nil
 }
 
+// resolveHeadersAndRows returns the headers and rows to render, preferring the
+// struct or map slice in data over the explicit rows when it is provided
+func resolveHeadersAndRows(headers []string, rows [][]interface{}, data interface{}, columns []Column) ([]string, [][]interface{}, error) {
+	// Use traditional Headers and Rows fields
+	if data == nil {
+		return headers, rows, nil
+	}
+
+	// Map rows have no field order, so the columns are ordered explicitly
+	if maps, ok := mapSlice(data); ok {
+		mapHeaders, mapRows := convertMapSliceToRows(maps, headers, columns)
+		return mapHeaders, mapRows, nil
+	}
+
+	// If Data field is provided (struct slice), use it and auto-generate headers/rows
+	structHeaders, structRows, err := convertStructSliceToRows(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert struct data: %w", err)
+	}
+	// Override with manual headers if provided
+	if len(headers) > 0 {
+		structHeaders = headers
+	}
+	return structHeaders, structRows, nil
+}
+
+// prepareTable resolves the headers and rows of a render or export, then runs
+// the plugin hooks and the row filter on them
+func (r *Renderer) prepareTable(headers []string, rows [][]interface{}, data interface{}, options *TableOptions) ([]string, [][]interface{}, error) {
+	r.applyStatePlugins(options)
+	r.dropDisabledFeatures(options)
+	if options.Print {
+		printOptions(options)
+	}
+
+	explicitHeaders := len(headers) > 0
+	headers, rows, err := resolveHeadersAndRows(headers, rows, data, options.Columns)
+	if err != nil {
+		return nil, nil, err
+	}
+	labelStructHeaders(headers, data, explicitHeaders, options)
+	rows = filterRows(headers, rows, options.RowFilter)
+	options.Columns = r.applyColumnPlugins(headers, options.Columns)
+	rows = r.applyRowPlugins(headers, rows)
+	headers, rows = selectColumns(headers, rows, options.VisibleColumns, options.Source)
+	if options.CSP {
+		options.Columns = withStyleClasses(options.Columns)
+	}
+	return headers, rows, nil
+}
+
+// decorateRows formats the rows of the current page, highlights the search
+// term and attaches their numbers, provenance, data attributes, data quality
+// classes, actions, frozen cells and selection state. The missing and
+// invalid cell counts are only gathered with HighlightMissing.
+func (r *Renderer) decorateRows(headers []string, rows [][]interface{}, sources []interface{}, options TableOptions, startRow int) ([]tableRow, int, int) {
+	// Rows are already paginated at database level, so numbering continues
+	// from the first row of the current page
+	rendered := r.renderRows(headers, rows, options.Columns)
+	for i := range rendered {
+		rendered[i].Number = startRow + i
+	}
+	highlightSearch(rendered, headers, options.Search)
+	if sources != nil {
+		applySource(rendered, sources, options.Source, options.CSP)
+	}
+	applyRowData(rendered, headers, rows, options)
+
+	var missing, invalid int
+	if options.HighlightMissing {
+		missing, invalid = flagDataQuality(rendered, headers, rows, options.Columns)
+	}
+
+	// Action URLs are filled from the row values
+	if options.Actions != nil && len(options.Actions.Items) > 0 {
+		for i := range rendered {
+			rendered[i].Actions = generateActionsHTML(options.Actions, RowView{Index: i, Headers: headers, Values: rows[i]})
+		}
+	}
+
+	if options.InlineEdit != nil && options.InlineEdit.Enabled {
+		r.applyInlineEdit(rendered, headers, rows, options)
+	}
+
+	// Client-side sorting compares raw values rather than formatted text
+	if options.ClientSide != nil && scriptsAllowed(options) {
+		for i := range rendered {
+			rendered[i].SortValues = clientSortValues(rows[i])
+		}
+	}
+
+	// Frozen columns stick to the start of the scrolling table
+	markCellClasses(rendered, headers, rows, options)
+
+	// Selection checkboxes identify rows by their ID column
+	if selection := options.Selection; selection != nil && selection.Enabled {
+		selected := make(map[string]bool, len(selection.Selected))
+		for _, id := range selection.Selected {
+			selected[id] = true
+		}
+		for i := range rendered {
+			id := selectionID(selection, RowView{Index: i, Headers: headers, Values: rows[i]}, rendered[i].Number)
+			rendered[i].SelectID = id
+			rendered[i].Selected = selected[id]
+		}
+	}
+	return rendered, missing, invalid
+}
+
 // RenderHTML renders table data with database-level pagination
 // This method expects only the current page data and uses TotalCount from pagination config
 func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
-	var headers []string
-	var rows [][]interface{}
-	var err error
+	var validationHTML string
+	if data.Options.Validation == ValidationStrict || data.Options.Validation == ValidationLenient {
+		if problems := r.Validate(data); len(problems) > 0 {
+			if data.Options.Validation == ValidationStrict {
+				return "", problems
+			}
+			validationHTML = r.generateValidationHTML(problems)
+		}
+	}
 
-	// If Data field is provided (struct slice), use it and auto-generate headers/rows
-	if data.Data != nil {
-		headers, rows, err = convertStructSliceToRows(data.Data)
-		if err != nil {
-			return "", fmt.Errorf("failed to convert struct data: %w", err)
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return "", err
+	}
+	headers, rows, sources := extractSource(headers, rows, data.Options.Source)
+
+	// DataTables draws its own controls from the payload, so the server
+	// controls are dropped once it is built
+	var dataTablesHTML template.HTML
+	if data.Options.DataTables != nil {
+		totalCount := data.TotalCount
+		if data.Options.Pagination != nil && data.Options.Pagination.TotalCount > 0 {
+			totalCount = data.Options.Pagination.TotalCount
 		}
-		// Override with manual headers if provided
-		if len(data.Headers) > 0 {
-			headers = data.Headers
+		dataTablesHTML, err = generateDataTablesHTML(headers, data.Options, totalCount, len(rows))
+		if err != nil {
+			return "", err
 		}
-	} else {
-		// Use traditional Headers and Rows fields
-		headers = data.Headers
-		rows = data.Rows
+		data.Options = withoutServerControls(data.Options)
 	}
 
 	// Calculate pagination info using database pagination method
@@ -530,273 +889,116 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 
 	// Enhanced HTML template with modern styling to match the design
 	htmlTemplate := `
-<div class="table-container">
-	<style>
-		.table-container {
-			font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
-			background: #ffffff;
-			border-radius: 8px;
-			box-shadow: 0 1px 3px rgba(0,0,0,0.1);
-			overflow: hidden;
-		}
-		
-		.table-header {
-			display: flex;
-			justify-content: space-between;
-			align-items: center;
-			padding: 1rem;
-			background: #f8f9fa;
-			border-bottom: 1px solid #dee2e6;
-		}
-		
-		.page-size-control {
-			display: flex;
-			align-items: center;
-			gap: 0.5rem;
-			font-size: 0.875rem;
-			color: #6c757d;
-		}
-		
-		.page-size-control select {
-			padding: 0.375rem 0.75rem;
-			border: 1px solid #ced4da;
-			border-radius: 4px;
-			background: white;
-			font-size: 0.875rem;
-			min-width: 100px;
-		}
-		
-		.search-control {
-			display: flex;
-			align-items: center;
-			gap: 0.5rem;
-		}
-		
-		.search-control label {
-			font-size: 0.875rem;
-			color: #6c757d;
-			margin: 0;
-		}
-		
-		.search-input-group {
-			display: flex;
-			align-items: center;
-			position: relative;
-		}
-		
-		.search-input-group input {
-			padding: 0.5rem 0.75rem;
-			border: 1px solid #ced4da;
-			border-radius: 4px;
-			font-size: 0.875rem;
-			min-width: 200px;
-			outline: none;
-		}
-		
-		.search-input-group input:focus {
-			border-color: #80bdff;
-			box-shadow: 0 0 0 0.2rem rgba(0,123,255,0.25);
-		}
-		
-		.search-btn {
-			margin-left: 0.5rem;
-			padding: 0.5rem 0.75rem;
-			background: #007bff;
-			color: white;
-			border: none;
-			border-radius: 4px;
-			cursor: pointer;
-			font-size: 0.875rem;
-			transition: background-color 0.2s ease;
-		}
-		
-		.search-btn:hover {
-			background: #0056b3;
-		}
-		
-		.search-btn:focus {
-			outline: none;
-			box-shadow: 0 0 0 0.2rem rgba(0,123,255,0.25);
-		}
-		
-		.search-clear-btn {
-			margin-left: 0.5rem;
-			padding: 0.5rem;
-			background: #dc3545;
-			color: white;
-			border: none;
-			border-radius: 4px;
-			cursor: pointer;
-			font-size: 0.75rem;
-		}
-		
-		.data-table {
-			width: 100%;
-			border-collapse: collapse;
-			margin: 0;
-			background: white;
-		}
-		
-		.data-table thead th {
-			background: #f8f9fa;
-			font-weight: 600;
-			padding: 0.75rem;
-			text-align: left;
-			border-bottom: 2px solid #dee2e6;
-			color: #495057;
-			font-size: 0.875rem;
-		}
-		
-		.data-table tbody td {
-			padding: 0.75rem;
-			border-bottom: 1px solid #dee2e6;
-			color: #212529;
-			font-size: 0.875rem;
-		}
-		
-		.data-table tbody tr:nth-child(even) {
-			background-color: #f8f9fa;
-		}
-		
-		.data-table tbody tr:hover {
-			background-color: #e9ecef;
-		}
-		
-		.sort-link {
-			color: inherit;
-			text-decoration: none;
-			display: flex;
-			align-items: center;
-			justify-content: space-between;
-			width: 100%;
-		}
-		
-		.sort-link:hover {
-			color: #495057;
-		}
-		
-		.sort-icon {
-			font-size: 0.75rem;
-			margin-left: 0.5rem;
-			opacity: 0.6;
-		}
-		
-		.sort-icon.active {
-			opacity: 1;
-			color: #007bff;
-		}
-		
-		.table-footer {
-			display: flex;
-			justify-content: space-between;
-			align-items: center;
-			padding: 1rem;
-			background: #f8f9fa;
-			border-top: 1px solid #dee2e6;
-		}
-		
-		.pagination-info {
-			font-size: 0.875rem;
-			color: #6c757d;
-			margin: 0;
-		}
-		
-		.pagination {
-			display: flex;
-			list-style: none;
-			margin: 0;
-			padding: 0;
-			gap: 0.25rem;
-		}
-		
-		.pagination .page-item {
-			display: block;
-		}
-		
-		.pagination .page-link {
-			display: block;
-			padding: 0.5rem 0.75rem;
-			color: #007bff;
-			text-decoration: none;
-			border: 1px solid #dee2e6;
-			border-radius: 4px;
-			font-size: 0.875rem;
-		}
-		
-		.pagination .page-link:hover {
-			background-color: #e9ecef;
-			border-color: #adb5bd;
-		}
-		
-		.pagination .page-item.active .page-link {
-			background-color: #007bff;
-			border-color: #007bff;
-			color: white;
-		}
-		
-		.pagination .page-item.disabled .page-link {
-			color: #6c757d;
-			background-color: white;
-			border-color: #dee2e6;
-			cursor: not-allowed;
-		}
-		
-		.no-results {
-			text-align: center;
-			padding: 2rem;
-			color: #6c757d;
-			font-style: italic;
-		}
+<div class="table-container{{if .Print}} table-print{{end}}"{{if .Direction}} dir="{{.Direction}}"{{end}}>
+	{{if not .OmitStyles}}
+	<style{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
+{{.Styles}}
 	</style>
+	{{end}}
+	{{.PluginAssets}}
 	
 	<div class="table-header">
-		<div class="page-size-control">
-			{{if .ShowPageSizer}}
-				<label>Show:</label>
-				{{.PageSizerHTML}}
-			{{end}}
-		</div>
-		<div class="search-control">
-			{{if .ShowSearch}}
-				{{.SearchHTML}}
-			{{end}}
-		</div>
+		{{.ToolbarHTML}}
 	</div>
 	
-	{{if gt (len .Rows) 0}}
-	<table class="data-table">
+	{{.SelectionFormHTML}}
+	{{.InlineEditForms}}
+	{{.ValidationWarning}}
+	{{.DataQualityNotice}}
+	{{if or (gt (len .Rows) 0) .EmptyState}}
+	{{if .Transposed}}
+	<div class="table-scroll{{if .MaxHeight}} table-scroll-y{{with .MaxHeightClass}} {{.}}{{end}}{{end}}"{{if and .MaxHeight (not .MaxHeightClass)}} style="max-height: {{.MaxHeight}}"{{end}}>
+	<table class="data-table transposed">
+		{{if .RowNumbers}}
+		<thead>
+			<tr>
+				<th class="row-number">{{.RowNumberHeader}}</th>
+				{{range .Rows}}<th class="row-number">{{.Number}}</th>{{end}}
+			</tr>
+		</thead>
+		{{end}}
+		<tbody>
+			{{range .Transposed}}
+			<tr>
+				<th scope="row">{{.Header}}</th>
+				{{$classes := .Classes}}
+				{{range $i, $cell := .Cells}}
+				<td{{with index $classes $i}} class="{{.}}"{{end}}>{{$cell}}</td>
+				{{end}}
+			</tr>
+			{{end}}
+		</tbody>
+	</table>
+	</div>
+	{{else}}
+	{{if or .FrozenColumns .MaxHeight}}<div class="table-scroll{{if .MaxHeight}} table-scroll-y{{with .MaxHeightClass}} {{.}}{{end}}{{end}}"{{if and .MaxHeight (not .MaxHeightClass)}} style="max-height: {{.MaxHeight}}"{{end}}>{{end}}
+	<table class="data-table"{{if .Resizable}} data-resizable="{{.ID}}" data-widths-param="{{.WidthsParam}}"{{end}}>
 		<thead>
 			<tr>
+				{{if .Selection}}<th class="select-cell{{if .FrozenLeading}} frozen{{end}}">{{if .SelectAll}}<input type="checkbox" class="select-all" aria-label="{{.SelectAllLabel}}">{{end}}</th>{{end}}
+				{{if .RowNumbers}}<th class="row-number{{if .FrozenLeading}} frozen{{end}}">{{.RowNumberHeader}}</th>{{end}}
 				{{range $index, $header := .Headers}}
-				<th>
+				<th{{with index $.HeaderClasses $index}} class="{{.}}"{{end}}{{if $.ClientSide}} data-column="{{$index}}" aria-sort="none"{{end}}{{if $.Resizable}} data-header="{{$header}}"{{with index $.HeaderWidths $index}} data-width="{{.}}"{{end}}{{end}}>
 					{{if $.SortingEnabled}}
-						<a href="{{index $.SortLinks $index}}" class="sort-link">
-							<span>{{$header}}</span>
+						<a href="{{index $.SortLinks $index}}" class="sort-link"{{$.TurboFrameAttr}}>
+							<span>{{index $.HeaderContents $index}}</span>
 							<span class="sort-icon{{if eq $.CurrentSortBy $header}} active{{end}}">
 								{{if eq $.CurrentSortBy $header}}
-									{{if eq $.CurrentSortOrder "asc"}}▲{{else}}▼{{end}}
-								{{else}}⬍{{end}}
+									{{if eq $.CurrentSortOrder "asc"}}{{$.SortAscIcon}}{{else}}{{$.SortDescIcon}}{{end}}{{$.NullsIndicator}}
+								{{else}}{{$.SortableIcon}}{{end}}
 							</span>
 						</a>
 					{{else}}
-						{{$header}}
+						{{index $.HeaderContents $index}}
 					{{end}}
+					{{if $.Resizable}}<span class="resize-grip" aria-hidden="true"></span>{{end}}
 				</th>
 				{{end}}
+				{{if .EditCells}}<th class="edit-cell"></th>{{end}}
+				{{if .ShowActions}}<th class="actions-cell">{{.ActionsHeader}}</th>{{end}}
 			</tr>
 		</thead>
 		<tbody>
 			{{range .Rows}}
-			<tr>
-				{{range .}}
-				<td>{{.}}</td>
+			{{.GroupStart}}
+			<tr{{if .Attributes}} {{.Attributes}}{{end}}>
+				{{if $.Selection}}<td class="select-cell{{if $.FrozenLeading}} frozen{{end}}"><input type="checkbox" class="select-row" name="{{$.SelectionName}}" value="{{.SelectID}}"{{if $.SelectionFormID}} form="{{$.SelectionFormID}}"{{end}}{{if .Selected}} checked{{end}} aria-label="{{$.SelectRowLabel}}"></td>{{end}}
+				{{if $.RowNumbers}}<td class="row-number{{if $.FrozenLeading}} frozen{{end}}">{{.Number}}</td>{{end}}
+				{{$classes := .Classes}}{{$sortValues := .SortValues}}
+				{{range $i, $cell := .Cells}}
+				<td{{if $classes}}{{with index $classes $i}} class="{{.}}"{{end}}{{end}}{{if $sortValues}} data-sort="{{index $sortValues $i}}"{{end}}>{{$cell}}</td>
 				{{end}}
+				{{if $.EditCells}}<td class="edit-cell">{{.Edit}}</td>{{end}}
+				{{if $.ShowActions}}<td class="actions-cell">{{.Actions}}</td>{{end}}
+			</tr>
+			{{.GroupEnd}}
+			{{else}}
+			<tr class="empty-state-row">
+				<td colspan="{{.EmptyColspan}}">{{.EmptyState}}</td>
 			</tr>
 			{{end}}
 		</tbody>
+		{{if .StatisticsCells}}
+		<tfoot>
+			<tr>
+				{{if .Selection}}<td></td>{{end}}
+				{{if .RowNumbers}}<td></td>{{end}}
+				{{range $i, $cell := .StatisticsCells}}
+				<td{{with index $.ColumnClasses $i}} class="{{.}}"{{end}}>{{$cell}}</td>
+				{{end}}
+				{{if .EditCells}}<td></td>{{end}}
+				{{if .ShowActions}}<td></td>{{end}}
+			</tr>
+		</tfoot>
+		{{end}}
 	</table>
+	{{if or .FrozenColumns .MaxHeight}}</div>{{end}}
+	{{end}}
+	{{.QuickFilterEmpty}}
+	{{.ClientSideEmpty}}
+	{{.ClientPagination}}
 	{{else}}
-	<div class="no-results">No records found</div>
+	<div class="no-results">{{.NoRecordsText}}</div>
 	{{end}}
 	
 	<div class="table-footer">
@@ -807,6 +1009,7 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 			{{if .ShowPaginationControls}}{{.PaginationControls}}{{end}}
 		</div>
 	</div>
+	{{.Scripts}}
 </div>`
 
 	tmpl, err := template.New("table").Parse(htmlTemplate)
@@ -823,41 +1026,27 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 		showPaginationInfo = data.Options.Pagination.ShowInfo
 
 		if showPaginationControls {
-			// Parse current query parameters to preserve them in pagination links
-			currentParams := r.parseQueryParams(data.Options.Pagination.BaseURL)
-			if data.Options.Sorting != nil && data.Options.Sorting.Enabled {
-				if data.Options.Sorting.SortBy != "" {
-					currentParams["sort_by"] = data.Options.Sorting.SortBy
-				}
-				if data.Options.Sorting.SortOrder != "" {
-					currentParams["sort_order"] = data.Options.Sorting.SortOrder
-				}
-			}
-			// Add current page size to preserve it in pagination links
-			if paginationInfo.PageSize > 0 {
-				currentParams["page_size"] = fmt.Sprintf("%d", paginationInfo.PageSize)
-			}
-			// Add current search term to preserve it in pagination links
-			if data.Options.Search != nil && data.Options.Search.Enabled && data.Options.Search.SearchTerm != "" {
-				searchParam := data.Options.Search.QueryParam
-				if searchParam == "" {
-					searchParam = "search"
+			currentParams := r.paginationLinkParams(data.Options, paginationInfo)
+			if data.Options.Pagination.loadsMore() {
+				paginationControls = r.generateLoadMoreHTML(paginationInfo, data.Options, currentParams)
+			} else {
+				paginationControls = r.generatePaginationHTML(paginationInfo, data.Options.Pagination, currentParams, turboFrameID(data.Options))
+				if data.Options.Pagination.ShowJumpToPage && !data.Options.Pagination.Cursor && !paginationInfo.Uncounted {
+					paginationControls += r.generateJumpToPageHTML(paginationInfo, data.Options.Pagination, currentParams, turboFrameID(data.Options))
 				}
-				currentParams[searchParam] = data.Options.Search.SearchTerm
 			}
-			paginationControls = r.generatePaginationHTML(paginationInfo, data.Options.Pagination, currentParams)
 		}
-		if showPaginationInfo {
+		// Cursor pages have no known offset to report, and appended pages
+		// would outdate it
+		if showPaginationInfo && !data.Options.Pagination.Cursor && !data.Options.Pagination.loadsMore() {
 			paginationInfoHTML = r.generatePaginationInfoHTML(paginationInfo)
 		}
 	}
 
 	// Generate page size control HTML
 	var pageSizerHTML string
-	var showPageSizer bool
 
 	if data.Options.Pagination != nil && data.Options.Pagination.Enabled && data.Options.Pagination.ShowPageSizer {
-		showPageSizer = true
 		// Parse current query parameters to preserve them in page size links
 		currentParams := r.parseQueryParams(data.Options.Pagination.BaseURL)
 		if data.Options.Sorting != nil && data.Options.Sorting.Enabled {
@@ -868,6 +1057,10 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 				currentParams["sort_order"] = data.Options.Sorting.SortOrder
 			}
 		}
+		setNullsParam(currentParams, data.Options.Sorting)
+		setFilterParams(currentParams, data.Options.Filters)
+		setColumnsParam(currentParams, data.Options.VisibleColumns)
+		setWidthsParam(currentParams, data.Options)
 		// Add current search term to preserve it in page size links
 		if data.Options.Search != nil && data.Options.Search.Enabled && data.Options.Search.SearchTerm != "" {
 			searchParam := data.Options.Search.QueryParam
@@ -876,7 +1069,7 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 			}
 			currentParams[searchParam] = data.Options.Search.SearchTerm
 		}
-		pageSizerHTML = r.generatePageSizeHTML(data.Options.Pagination, currentParams)
+		pageSizerHTML = r.generatePageSizeHTML(data.Options.Pagination, currentParams, scriptsAllowed(data.Options), turboFrameID(data.Options))
 	}
 
 	// Generate sorting links and data
@@ -889,26 +1082,8 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 		currentSortBy = data.Options.Sorting.SortBy
 		currentSortOrder = data.Options.Sorting.SortOrder
 
-		// Parse current query parameters to preserve them in sorting links
-		currentParams := r.parseQueryParams(data.Options.Sorting.BaseURL)
-		if data.Options.Pagination != nil && data.Options.Pagination.Enabled {
-			// Preserve current page in sorting links
-			currentParams["page"] = fmt.Sprintf("%d", data.Options.Pagination.CurrentPage)
-			// Add current page size to preserve it in sorting links
-			if paginationInfo.PageSize > 0 {
-				currentParams["page_size"] = fmt.Sprintf("%d", paginationInfo.PageSize)
-			}
-		}
-		// Add current search term to preserve it in sorting links
-		if data.Options.Search != nil && data.Options.Search.Enabled && data.Options.Search.SearchTerm != "" {
-			searchParam := data.Options.Search.QueryParam
-			if searchParam == "" {
-				searchParam = "search"
-			}
-			currentParams[searchParam] = data.Options.Search.SearchTerm
-		}
-
-		sortLinks = r.generateSortLinks(headers, data.Options.Sorting, currentParams)
+		currentParams := r.sortLinkParams(data.Options, paginationInfo)
+		sortLinks = r.generateSortLinks(headers, data.Options.Columns, data.Options.Sorting, currentParams)
 	} else {
 		// Create empty sort links for non-sortable tables
 		sortLinks = make([]string, len(headers))
@@ -916,11 +1091,9 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 
 	// Generate search control HTML
 	var searchHTML string
-	var showSearch bool
 	var currentSearchTerm string
 
 	if data.Options.Search != nil && data.Options.Search.Enabled {
-		showSearch = true
 		currentSearchTerm = data.Options.Search.SearchTerm
 		// Parse current query parameters to preserve them in search
 		currentParams := r.parseQueryParams(data.Options.Search.BaseURL)
@@ -932,21 +1105,197 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 				currentParams["sort_order"] = data.Options.Sorting.SortOrder
 			}
 		}
+		setNullsParam(currentParams, data.Options.Sorting)
+		setFilterParams(currentParams, data.Options.Filters)
+		setColumnsParam(currentParams, data.Options.VisibleColumns)
+		setWidthsParam(currentParams, data.Options)
 		if data.Options.Pagination != nil && data.Options.Pagination.Enabled {
 			// Add current page size to preserve it in search
 			if paginationInfo.PageSize > 0 {
-				currentParams["page_size"] = fmt.Sprintf("%d", paginationInfo.PageSize)
+				currentParams["page_size"] = pageSizeValue(data.Options.Pagination, paginationInfo.PageSize)
+			}
+		}
+		searchHTML = r.generateSearchHTML(data.Options.Search, currentParams, turboFrameID(data.Options))
+	}
+
+	// Render header cell contents, including per-column header templates
+	headerContents, err := r.renderHeaderContents(headers, data.Options.Columns, data.Options.Sorting)
+	if err != nil {
+		return "", err
+	}
+
+	// Lay out the toolbar from the built-in controls and custom items
+	var pageSizeItemHTML, searchItemHTML string
+	if pageSizerHTML != "" {
+		pageSizeItemHTML = fmt.Sprintf(`<div class="page-size-control"><label>%s</label>%s</div>`,
+			template.HTMLEscapeString(r.translate(LabelShow, 1)), pageSizerHTML)
+	}
+	if searchHTML != "" {
+		searchItemHTML = fmt.Sprintf(`<div class="search-control">%s</div>`, searchHTML)
+	}
+	facetsHTML := r.generateFacetsHTML(headers, data.Options, paginationInfo.PageSize, scriptsAllowed(data.Options))
+	var scripts template.HTML
+	if (pageSizerHTML != "" || facetsHTML != "") && scriptsAllowed(data.Options) {
+		scripts += scriptTag(data.Options, formControlsScript)
+	}
+	// Keyboard shortcuts need a script, so they are skipped when JS is disabled
+	var keyboardHelpHTML string
+	if data.Options.KeyboardShortcuts && scriptsAllowed(data.Options) {
+		keyboardHelpHTML = r.generateKeyboardHelpHTML()
+		scripts += scriptTag(data.Options, keyboardShortcutsScript)
+	}
+	scripts += rowScripts(data.Options)
+	var quickFilterHTML, quickFilterEmptyHTML string
+	if searchHTML != "" && data.Options.Search.Live && scriptsAllowed(data.Options) {
+		scripts += scriptTag(data.Options, liveSearchScript)
+	}
+	if paginationControls != "" && data.Options.Pagination.loadsMore() && !data.Options.Pagination.HTMX && scriptsAllowed(data.Options) {
+		scripts += scriptTag(data.Options, loadMoreScript)
+		if data.Options.Pagination.InfiniteScroll {
+			scripts += scriptTag(data.Options, infiniteScrollScript)
+		}
+	}
+	if data.Options.QuickFilter && scriptsAllowed(data.Options) {
+		quickFilterHTML, quickFilterEmptyHTML = r.generateQuickFilterHTML()
+		scripts += scriptTag(data.Options, quickFilterScript)
+	}
+	resizable := data.Options.ResizableColumns && scriptsAllowed(data.Options)
+	if resizable {
+		scripts += scriptTag(data.Options, columnResizeScript)
+	}
+	var copyButtonHTML string
+	if data.Options.CopyButton && scriptsAllowed(data.Options) {
+		copyButtonHTML = r.generateCopyButtonHTML()
+		scripts += scriptTag(data.Options, copyTableScript)
+	}
+	exportToolbarHTML := r.generateExportToolbarHTML(data.Options, scriptsAllowed(data.Options))
+	if hasExportCopy(data.Options) && scriptsAllowed(data.Options) {
+		scripts += scriptTag(data.Options, exportCopyScript)
+	}
+	var clientSearchHTML, clientPaginationHTML, clientSideEmptyHTML string
+	clientSide := data.Options.ClientSide != nil && scriptsAllowed(data.Options)
+	if clientSide {
+		clientSearchHTML, clientPaginationHTML, clientSideEmptyHTML = r.generateClientSideHTML(data.Options.ClientSide)
+		scripts += scriptTag(data.Options, clientSideScript)
+	}
+
+	toolbarHTML := r.generateToolbarHTML(data.Options.Toolbar, []ToolbarItem{
+		{Name: ToolbarItemPageSize, Slot: ToolbarLeft, HTML: template.HTML(pageSizeItemHTML)},
+		{Name: ToolbarItemPlugins, Slot: ToolbarCenter, HTML: r.pluginToolbarHTML(data.Options)},
+		{Name: ToolbarItemQuickFilter, Slot: ToolbarRight, Order: -1, HTML: template.HTML(quickFilterHTML)},
+		{Name: ToolbarItemClientSearch, Slot: ToolbarRight, Order: -1, HTML: template.HTML(clientSearchHTML)},
+		{Name: ToolbarItemFacets, Slot: ToolbarRight, Order: -1, HTML: template.HTML(facetsHTML)},
+		{Name: ToolbarItemSearch, Slot: ToolbarRight, HTML: template.HTML(searchItemHTML)},
+		{Name: ToolbarItemExport, Slot: ToolbarRight, Order: 1, HTML: template.HTML(exportToolbarHTML)},
+		{Name: ToolbarItemCopy, Slot: ToolbarRight, Order: 1, HTML: template.HTML(copyButtonHTML)},
+		{Name: ToolbarItemKeyboardHelp, Slot: ToolbarRight, Order: 100, HTML: template.HTML(keyboardHelpHTML)},
+	})
+	if data.Options.Print {
+		// Custom toolbar items are controls too
+		toolbarHTML = ""
+	}
+
+	renderedRows, missing, invalid := r.decorateRows(headers, rows, sources, data.Options, paginationInfo.StartRow)
+
+	var dataQualityHTML string
+	if data.Options.HighlightMissing {
+		dataQualityHTML = r.generateDataQualityHTML(missing, invalid)
+	}
+
+	showActions := data.Options.Actions != nil && len(data.Options.Actions.Items) > 0
+	var actionsHeader string
+	if showActions {
+		actionsHeader = data.Options.Actions.Header
+		if actionsHeader == "" {
+			actionsHeader = r.translate(LabelActions, 1)
+		}
+	}
+
+	frozenColumns := frozenDataColumns(data.Options)
+
+	// Transposed tables show one row per header; sorting, selection, actions
+	// and frozen columns do not apply to them
+	var transposed []transposedRow
+	if data.Options.Transpose && len(renderedRows) > 0 {
+		transposed = transposeRows(headerContents, renderedRows)
+	}
+
+	var selection *Selection
+	var selectionName, formID, selectionFormHTML string
+	if data.Options.Selection != nil && data.Options.Selection.Enabled {
+		selection = data.Options.Selection
+		selectionName = selectionInputName(selection)
+		formID = selectionFormID(selection, data.Options.ID)
+		selectionFormHTML = r.generateSelectionFormHTML(selection, formID)
+		if scriptsAllowed(data.Options) {
+			scripts += scriptTag(data.Options, selectAllScript)
+			if len(selection.BulkActions) > 0 {
+				scripts += scriptTag(data.Options, bulkActionsScript)
 			}
 		}
-		searchHTML = r.generateSearchHTML(data.Options.Search, currentParams)
+	}
+	if ((showActions && hasConfirmActions(data.Options.Actions)) || hasConfirmBulkActions(selection)) && scriptsAllowed(data.Options) {
+		scripts += scriptTag(data.Options, confirmActionScript)
+	}
+
+	if data.Options.EmbedLinks {
+		linksHTML, err := linksScriptTag(r.generateLinks(headers, data.Options, paginationInfo))
+		if err != nil {
+			return "", err
+		}
+		scripts += linksHTML
+	}
+	scripts += dataTablesHTML
+
+	// Full-width rows span the data columns and the extra leading and
+	// trailing columns
+	var leadingColumns, trailingColumns int
+	for _, extra := range []bool{selection != nil, data.Options.RowNumbers} {
+		if extra {
+			leadingColumns++
+		}
+	}
+	var maxHeight template.CSS
+	var heightClass string
+	if cssLength.MatchString(data.Options.MaxHeight) {
+		maxHeight = template.CSS(data.Options.MaxHeight)
+		if data.Options.CSP {
+			heightClass = maxHeightClass(data.Options.MaxHeight)
+		}
+	}
+	styles := tableStyleSheet(data.Options.Print)
+	if data.Options.CSP {
+		// CSP mode sets the styles of style attributes by classes
+		styles += template.CSS("\n" + CSPStyles(data.Options))
+	}
+
+	var inlineEditFormsHTML string
+	editCells := false
+	if edit := data.Options.InlineEdit; edit != nil && edit.Enabled {
+		inlineEditFormsHTML = r.generateInlineEditFormsHTML(renderedRows, headers, rows, data.Options)
+		editCells = !edit.Table
+	}
+	for _, extra := range []bool{editCells, showActions} {
+		if extra {
+			trailingColumns++
+		}
+	}
+
+	var emptyStateHTML string
+	if data.Options.EmptyState != nil && len(renderedRows) == 0 {
+		emptyStateHTML = r.generateEmptyStateHTML(data.Options.EmptyState)
+	}
+	if data.Options.GroupBy != nil {
+		r.applyGroups(renderedRows, headers, rows, data.Options.Columns, data.Options.GroupBy, leadingColumns, trailingColumns)
 	}
 
 	// Prepare template data
 	templateData := struct {
 		Headers                []string
-		Rows                   [][]interface{}
+		Rows                   []tableRow
 		CSSClasses             string
 		ID                     string
+		Direction              string
 		Style                  template.CSS
 		PaginationControls     template.HTML
 		PaginationInfo         template.HTML
@@ -954,18 +1303,61 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 		ShowPaginationInfo     bool
 		SortingEnabled         bool
 		SortLinks              []string
+		HeaderContents         []template.HTML
 		CurrentSortBy          string
 		CurrentSortOrder       string
-		PageSizerHTML          template.HTML
-		ShowPageSizer          bool
-		SearchHTML             template.HTML
-		ShowSearch             bool
+		NullsIndicator         template.HTML
+		SortAscIcon            template.HTML
+		SortDescIcon           template.HTML
+		SortableIcon           template.HTML
+		ToolbarHTML            template.HTML
 		CurrentSearchTerm      string
+		NoRecordsText          string
+		Scripts                template.HTML
+		PluginAssets           template.HTML
+		OmitStyles             bool
+		Nonce                  string
+		Styles                 template.CSS
+		StatisticsCells        []template.HTML
+		RowNumbers             bool
+		RowNumberHeader        string
+		Selection              bool
+		SelectAll              bool
+		SelectionName          string
+		SelectionFormID        string
+		SelectionFormHTML      template.HTML
+		SelectAllLabel         string
+		SelectRowLabel         string
+		DataQualityNotice      template.HTML
+		ValidationWarning      template.HTML
+		FrozenColumns          int
+		QuickFilterEmpty       template.HTML
+		ClientSide             bool
+		TurboFrameAttr         template.HTMLAttr
+		ClientSideEmpty        template.HTML
+		ClientPagination       template.HTML
+		Transposed             []transposedRow
+		EmptyState             template.HTML
+		EmptyColspan           int
+		FrozenLeading          bool
+		ShowActions            bool
+		ActionsHeader          string
+		Print                  bool
+		Resizable              bool
+		WidthsParam            string
+		HeaderWidths           []int
+		InlineEditForms        template.HTML
+		EditCells              bool
+		MaxHeight              template.CSS
+		MaxHeightClass         string
+		HeaderClasses          []string
+		ColumnClasses          []string
 	}{
 		Headers:                headers,
-		Rows:                   rows, // Use rows as-is (already paginated at database level)
+		Rows:                   renderedRows,
 		CSSClasses:             strings.Join(cssClasses, " "),
 		ID:                     data.Options.ID,
+		Direction:              textDirection(data.Options.Direction),
 		Style:                  template.CSS(data.Options.Style),
 		PaginationControls:     template.HTML(paginationControls),
 		PaginationInfo:         template.HTML(paginationInfoHTML),
@@ -973,13 +1365,55 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 		ShowPaginationInfo:     showPaginationInfo,
 		SortingEnabled:         sortingEnabled,
 		SortLinks:              sortLinks,
+		HeaderContents:         headerContents,
 		CurrentSortBy:          currentSortBy,
 		CurrentSortOrder:       currentSortOrder,
-		PageSizerHTML:          template.HTML(pageSizerHTML),
-		ShowPageSizer:          showPageSizer,
-		SearchHTML:             template.HTML(searchHTML),
-		ShowSearch:             showSearch,
+		NullsIndicator:         r.nullsIndicatorHTML(data.Options.Sorting),
+		SortAscIcon:            r.icon(IconSortAsc),
+		SortDescIcon:           r.icon(IconSortDesc),
+		SortableIcon:           r.icon(IconSortable),
+		ToolbarHTML:            template.HTML(toolbarHTML),
 		CurrentSearchTerm:      currentSearchTerm,
+		NoRecordsText:          r.translate(LabelNoRecords, 0),
+		Scripts:                scripts,
+		PluginAssets:           r.pluginAssetsHTML(),
+		OmitStyles:             data.Options.OmitStyles || (data.Options.CSP && data.Options.Nonce == ""),
+		Nonce:                  data.Options.Nonce,
+		Styles:                 styles,
+		StatisticsCells:        r.generateStatisticsHTML(headers, rows, data.Options.Columns, data.Options.Statistics),
+		RowNumbers:             data.Options.RowNumbers,
+		RowNumberHeader:        r.translate(LabelRowNumber, 1),
+		Selection:              selection != nil,
+		SelectAll:              selection != nil && scriptsAllowed(data.Options),
+		SelectionName:          selectionName,
+		SelectionFormID:        formID,
+		SelectionFormHTML:      template.HTML(selectionFormHTML),
+		SelectAllLabel:         r.translate(LabelSelectAll, 1),
+		SelectRowLabel:         r.translate(LabelSelectRow, 1),
+		DataQualityNotice:      template.HTML(dataQualityHTML),
+		ValidationWarning:      template.HTML(validationHTML),
+		FrozenColumns:          frozenColumns,
+		QuickFilterEmpty:       template.HTML(quickFilterEmptyHTML),
+		ClientSide:             clientSide,
+		TurboFrameAttr:         template.HTMLAttr(turboFrameAttr(turboFrameID(data.Options))),
+		ClientSideEmpty:        template.HTML(clientSideEmptyHTML),
+		ClientPagination:       template.HTML(clientPaginationHTML),
+		Transposed:             transposed,
+		EmptyState:             template.HTML(emptyStateHTML),
+		EmptyColspan:           leadingColumns + len(headers) + trailingColumns,
+		FrozenLeading:          frozenColumns > 0 && scriptsAllowed(data.Options),
+		ShowActions:            showActions,
+		ActionsHeader:          actionsHeader,
+		Print:                  data.Options.Print,
+		Resizable:              resizable,
+		WidthsParam:            stateParams(data.Options).withDefaults().Widths,
+		HeaderWidths:           headerWidths(headers, data.Options.ColumnWidths),
+		InlineEditForms:        template.HTML(inlineEditFormsHTML),
+		EditCells:              editCells,
+		MaxHeight:              maxHeight,
+		MaxHeightClass:         heightClass,
+		HeaderClasses:          headerClasses(headers, data.Options.Columns, frozenColumns),
+		ColumnClasses:          columnClasses(headers, data.Options.Columns),
 	}
 
 	var result strings.Builder
@@ -988,75 +1422,47 @@ func (r *Renderer) RenderHTML(data DatabasePaginatedData) (string, error) {
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	output := result.String()
+	if data.Options.Minify {
+		output = minifyHTML(output)
+	}
+
 	// Wrap in responsive div if needed
 	if data.Options.Responsive {
-		return fmt.Sprintf(`<div class="table-responsive">%s</div>`, result.String()), nil
+		output = fmt.Sprintf(`<div class="table-responsive">%s</div>`, output)
 	}
 
-	return result.String(), nil
+	return wrapTurboFrame(output, data.Options), nil
 }
 
-// ParsePageFromQuery extracts page number from URL query string
-// This is a helper function for web applications
-func ParsePageFromQuery(queryString string, paramName string) int {
-	if paramName == "" {
-		paramName = "page"
-	}
-
-	// Simple query parameter parsing
-	if queryString == "" {
-		return 1
-	}
-
-	// Remove leading '?' if present
-	queryString = strings.TrimPrefix(queryString, "?")
-
-	// Split by '&' to get individual parameters
-	params := strings.Split(queryString, "&")
-	for _, param := range params {
-		if strings.Contains(param, "=") {
-			parts := strings.SplitN(param, "=", 2)
-			if len(parts) == 2 && parts[0] == paramName {
-				if page, err := strconv.Atoi(parts[1]); err == nil && page > 0 {
-					return page
-				}
-			}
-		}
+// textDirection normalizes the configured direction for the dir attribute
+// Pagination controls, toolbar and sort icons are laid out with flexbox and
+// logical CSS properties, so setting dir="rtl" flips their order and mirrors
+// the sort icon placement without separate markup
+func textDirection(direction string) string {
+	if strings.EqualFold(direction, "rtl") {
+		return "rtl"
 	}
+	return ""
+}
 
-	return 1
+// ParsePageFromQuery extracts page number from URL query string
+//
+// Deprecated: use ParseState, which reads the whole table state at once
+func ParsePageFromQuery(queryString string, paramName string) int {
+	return ParseStateValues(parseQuery(queryString), StateParams{Page: paramName}, TableState{}).Page
 }
 
 // ParsePageSizeFromQuery extracts page size from URL query string
-// This is a helper function for web applications
+//
+// Deprecated: use ParseState, which reads the whole table state at once
 func ParsePageSizeFromQuery(queryString string, defaultPageSize int) int {
-	// Simple query parameter parsing
-	if queryString == "" {
-		return defaultPageSize
-	}
-
-	// Remove leading '?' if present
-	queryString = strings.TrimPrefix(queryString, "?")
-
-	// Split by '&' to get individual parameters
-	params := strings.Split(queryString, "&")
-	for _, param := range params {
-		if strings.Contains(param, "=") {
-			parts := strings.SplitN(param, "=", 2)
-			if len(parts) == 2 && parts[0] == "page_size" {
-				if pageSize, err := strconv.Atoi(parts[1]); err == nil && pageSize > 0 {
-					return pageSize
-				}
-			}
-		}
-	}
-
-	return defaultPageSize
+	return ParseStateValues(parseQuery(queryString), StateParams{}, TableState{PageSize: defaultPageSize}).PageSize
 }
 
 // CreatePaginatedData creates DatabasePaginatedData for database-level pagination
 func CreatePaginatedData(data interface{}, totalCount int, baseURL string, queryString string, pageSize int) DatabasePaginatedData {
-	currentPage := ParsePageFromQuery(queryString, "page")
+	currentPage := ParseStateValues(parseQuery(queryString), StateParams{}, TableState{}).Page
 
 	return DatabasePaginatedData{
 		Data:       data, // Only current page data
@@ -1082,8 +1488,8 @@ func CreatePaginatedData(data interface{}, totalCount int, baseURL string, query
 
 // CreatePaginatedDataWithSorting creates DatabasePaginatedData with both pagination and sorting support
 func CreatePaginatedDataWithSorting(data interface{}, totalCount int, baseURL string, queryString string, pageSize int, enableSorting bool) DatabasePaginatedData {
-	currentPage := ParsePageFromQuery(queryString, "page")
-	sortBy, sortOrder := ParseSortFromQuery(queryString, "sort_by", "sort_order")
+	state := ParseStateValues(parseQuery(queryString), StateParams{}, TableState{})
+	currentPage, sortBy, sortOrder := state.Page, state.SortBy, state.SortOrder
 
 	result := DatabasePaginatedData{
 		Data:       data, // Only current page data
@@ -1116,6 +1522,8 @@ func CreatePaginatedDataWithSorting(data interface{}, totalCount int, baseURL st
 			BaseURL:    baseURL, // Use base URL without query params for sorting
 			QueryParam: "sort_by",
 			OrderParam: "sort_order",
+
+			NullsPosition: state.Nulls,
 		}
 	}
 
@@ -1124,8 +1532,8 @@ func CreatePaginatedDataWithSorting(data interface{}, totalCount int, baseURL st
 
 // CreatePaginatedDataWithSortingAndSearch creates database pagination data with sorting and search
 func CreatePaginatedDataWithSortingAndSearch(data interface{}, totalCount int, baseURL string, queryString string, pageSize int, enableSorting bool, enableSearch bool, searchTerm string) DatabasePaginatedData {
-	currentPage := ParsePageFromQuery(queryString, "page")
-	sortBy, sortOrder := ParseSortFromQuery(queryString, "sort_by", "sort_order")
+	state := ParseStateValues(parseQuery(queryString), StateParams{}, TableState{})
+	currentPage, sortBy, sortOrder := state.Page, state.SortBy, state.SortOrder
 
 	result := DatabasePaginatedData{
 		Data:       data, // Only current page data
@@ -1158,17 +1566,18 @@ func CreatePaginatedDataWithSortingAndSearch(data interface{}, totalCount int, b
 			BaseURL:    baseURL, // Use base URL without query params for sorting
 			QueryParam: "sort_by",
 			OrderParam: "sort_order",
+
+			NullsPosition: state.Nulls,
 		}
 	}
 
 	if enableSearch {
 		result.Options.Search = &Search{
-			Enabled:     true,
-			SearchTerm:  searchTerm,
-			Placeholder: "Search all columns...",
-			BaseURL:     baseURL, // Use base URL without query params for search
-			QueryParam:  "search",
-			MinLength:   1,
+			Enabled:    true,
+			SearchTerm: searchTerm,
+			BaseURL:    baseURL, // Use base URL without query params for search
+			QueryParam: "search",
+			MinLength:  1,
 		}
 	}
 
@@ -1192,7 +1601,7 @@ func CalculateDatabaseLimit(pageSize int) int {
 }
 
 // generateSortLinks generates sorting URLs for each column header
-func (r *Renderer) generateSortLinks(headers []string, sorting *Sorting, currentQueryParams map[string]string) []string {
+func (r *Renderer) generateSortLinks(headers []string, columns []Column, sorting *Sorting, currentQueryParams map[string]string) []string {
 	if sorting == nil || !sorting.Enabled {
 		return make([]string, len(headers))
 	}
@@ -1213,144 +1622,95 @@ func (r *Renderer) generateSortLinks(headers []string, sorting *Sorting, current
 
 	sortLinks := make([]string, len(headers))
 
+	configs := columnsFor(headers, columns)
 	for i, header := range headers {
-		// Determine sort order for this column
-		sortOrder := "asc"
-		if sorting.SortBy == header && sorting.SortOrder == "asc" {
-			sortOrder = "desc" // Toggle to desc if already sorting asc
-		}
+		// Determine sort order for this column; "" clears sorting
+		sortOrder := nextSortOrder(sorting, configs[i], header)
 
-		// Build parameters list preserving existing ones (except page - sorting resets to page 1)
-		params := make([]string, 0)
+		// Preserve existing parameters except page - sorting resets to page 1
+		params := queryValues(currentQueryParams)
+		params.Del("page")
+		params.Del(sortParam)
+		params.Del(orderParam)
 
 		// Add sorting parameters
-		params = append(params, fmt.Sprintf("%s=%s", sortParam, header))
-		params = append(params, fmt.Sprintf("%s=%s", orderParam, sortOrder))
-
-		// Add other preserved parameters but exclude page and sort params
-		for key, value := range currentQueryParams {
-			if key != sortParam && key != orderParam && key != "page" { // Exclude page to reset pagination
-				params = append(params, fmt.Sprintf("%s=%s", key, value))
-			}
+		if sortOrder != "" {
+			params.Set(sortParam, header)
+			params.Set(orderParam, sortOrder)
 		}
 
-		queryString := strings.Join(params, "&")
-
-		// Generate URL for this column
-		if baseURL == "" {
-			sortLinks[i] = "?" + queryString
-		} else if strings.Contains(baseURL, "?") {
-			sortLinks[i] = baseURL + "&" + queryString
-		} else {
-			sortLinks[i] = baseURL + "?" + queryString
-		}
+		sortLinks[i] = linkURL(baseURL, params)
 	}
 
 	return sortLinks
 }
 
-// parseQueryParams extracts query parameters from a URL or query string
+// parseQueryParams extracts the decoded query parameters from a URL or
+// query string. Anything that parses as a URL with a scheme, host or path
+// starting with "/" or "." is a URL, whose parameters are in its query
+// only, so "/orders" and "/t;v=1/list" have none; so does a relative path
+// without "=", e.g. "orders". The rest, e.g. "page=2&sort=name", is a query
+// string.
 func (r *Renderer) parseQueryParams(urlOrQuery string) map[string]string {
 	params := make(map[string]string)
 
-	if urlOrQuery == "" {
-		return params
-	}
-
-	// Extract query part if it's a full URL
 	queryString := urlOrQuery
-	if strings.Contains(urlOrQuery, "?") {
-		parts := strings.Split(urlOrQuery, "?")
-		if len(parts) > 1 {
-			queryString = parts[1]
+	if u, err := url.Parse(urlOrQuery); err == nil {
+		switch {
+		case u.RawQuery != "" || u.ForceQuery:
+			queryString = u.RawQuery
+		case u.Scheme != "" || u.Host != "" || strings.HasPrefix(u.Path, "/") || strings.HasPrefix(u.Path, "."),
+			!strings.Contains(u.Path, "="):
+			return params
 		}
+	} else if i := strings.Index(urlOrQuery, "?"); i >= 0 {
+		queryString = urlOrQuery[i+1:]
 	}
 
-	// Remove leading '?' if present
-	queryString = strings.TrimPrefix(queryString, "?")
-
-	if queryString == "" {
-		return params
-	}
-
-	// Split by '&' to get individual parameters
-	pairs := strings.Split(queryString, "&")
-	for _, pair := range pairs {
-		if strings.Contains(pair, "=") {
-			parts := strings.SplitN(pair, "=", 2)
-			if len(parts) == 2 {
-				params[parts[0]] = parts[1]
-			}
+	for key, values := range parseQuery(queryString) {
+		if len(values) > 0 {
+			params[key] = values[0]
 		}
 	}
-
 	return params
 }
 
-// ParseSortFromQuery extracts sort field and order from URL query string
-// This is a helper function for web applications
-func ParseSortFromQuery(queryString string, sortParam string, orderParam string) (string, string) {
-	if sortParam == "" {
-		sortParam = "sort_by"
-	}
-	if orderParam == "" {
-		orderParam = "sort_order"
+// queryValues converts preserved parameters to url.Values for editing
+func queryValues(params map[string]string) url.Values {
+	values := make(url.Values, len(params))
+	for key, value := range params {
+		values.Set(key, value)
 	}
+	return values
+}
 
-	// Simple query parameter parsing
-	if queryString == "" {
-		return "", "asc"
+// linkURL returns baseURL with its query replaced by the encoded params.
+// The parameters of baseURL's own query are expected in params already,
+// as the link params parsed from it are. Without a base URL the link is
+// relative to the current page.
+func linkURL(baseURL string, params url.Values) string {
+	path := baseURL
+	if i := strings.Index(baseURL, "?"); i >= 0 {
+		path = baseURL[:i]
 	}
-
-	// Remove leading '?' if present
-	queryString = strings.TrimPrefix(queryString, "?")
-
-	var sortBy, sortOrder string
-	sortOrder = "asc" // default
-
-	// Split by '&' to get individual parameters
-	params := strings.Split(queryString, "&")
-	for _, param := range params {
-		if strings.Contains(param, "=") {
-			parts := strings.SplitN(param, "=", 2)
-			if len(parts) == 2 {
-				if parts[0] == sortParam {
-					sortBy = parts[1]
-				} else if parts[0] == orderParam {
-					if parts[1] == "desc" {
-						sortOrder = "desc"
-					}
-				}
-			}
-		}
+	query := params.Encode()
+	if query == "" && path != "" {
+		return path
 	}
+	return path + "?" + query
+}
 
-	return sortBy, sortOrder
+// ParseSortFromQuery extracts sort field and order from URL query string
+//
+// Deprecated: use ParseState, which reads the whole table state at once
+func ParseSortFromQuery(queryString string, sortParam string, orderParam string) (string, string) {
+	state := ParseStateValues(parseQuery(queryString), StateParams{SortBy: sortParam, SortOrder: orderParam}, TableState{})
+	return state.SortBy, state.SortOrder
 }
 
 // ParseSearchFromQuery parses search term from query string
+//
+// Deprecated: use ParseState, which reads the whole table state at once
 func ParseSearchFromQuery(rawQuery string, defaultSearchParam string) string {
-	if rawQuery == "" {
-		return ""
-	}
-
-	searchParam := defaultSearchParam
-	if searchParam == "" {
-		searchParam = "search"
-	}
-
-	// Parse the raw query string
-	params := strings.Split(rawQuery, "&")
-	for _, param := range params {
-		if strings.Contains(param, "=") {
-			parts := strings.SplitN(param, "=", 2)
-			if len(parts) == 2 && parts[0] == searchParam {
-				// URL decode the search term (basic decoding)
-				decoded := strings.Replace(parts[1], "+", " ", -1)
-				return decoded
-			}
-		}
-	}
-
-	return ""
+	return ParseStateValues(parseQuery(rawQuery), StateParams{Search: defaultSearchParam}, TableState{}).Search
 }
@@ -0,0 +1,35 @@
+package tablerenderer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQueryParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		urlOrQuery string
+		want       map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{"path", "/orders", map[string]string{}},
+		{"path with equals", "/t;v=1/list", map[string]string{}},
+		{"path with query", "/t;v=1/list?page=2&q=a%20b", map[string]string{"page": "2", "q": "a b"}},
+		{"absolute URL", "https://example.com/orders?sort_by=name", map[string]string{"sort_by": "name"}},
+		{"absolute URL with equals", "https://example.com/a=b", map[string]string{}},
+		{"fragment is not query", "/orders#page=2", map[string]string{}},
+		{"query string", "page=2&q=x", map[string]string{"page": "2", "q": "x"}},
+		{"leading question mark", "?q=y", map[string]string{"q": "y"}},
+		{"relative path", "orders", map[string]string{}},
+		{"first value wins", "/orders?tag=a&tag=b", map[string]string{"tag": "a"}},
+		{"bracketed filter", "/orders?filter%5Bage%5D%5Bgt%5D=30", map[string]string{"filter[age][gt]": "30"}},
+	}
+	r := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.parseQueryParams(tt.urlOrQuery); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseQueryParams(%q) = %v, want %v", tt.urlOrQuery, got, tt.want)
+			}
+		})
+	}
+}
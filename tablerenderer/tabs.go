@@ -0,0 +1,164 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tab is a table shown in a TabGroup
+type Tab struct {
+	Name  string `json:"name"`  // Value of the tab query parameter, e.g. "orders"
+	Title string `json:"title"` // Tab label
+
+	// Load fetches the data of the tab for the request; it is only called
+	// for the active tab
+	Load func(req *http.Request) (DatabasePaginatedData, error) `json:"-"`
+}
+
+// TabGroup shows related tables as tabs, e.g. the orders, invoices and
+// tickets of a customer. The active tab is selected by a query parameter.
+type TabGroup struct {
+	ID    string `json:"id,omitempty"`    // Element ID of the group (default: "table-tabs")
+	Param string `json:"param,omitempty"` // Query parameter selecting the tab (default: "tab")
+	Tabs  []Tab  `json:"tabs"`
+	HTMX  bool   `json:"htmx,omitempty"` // Switch tabs with HTMX requests swapping the group instead of full page loads
+
+	OmitStyles bool `json:"omit_styles,omitempty"` // Leave out the inline <style> block
+}
+
+// tabGroupStyles is the CSS of the tab list
+const tabGroupStyles = `<style>
+.table-tabs .tab-list {
+	display: flex;
+	gap: 0.25rem;
+	border-bottom: 1px solid #dee2e6;
+	margin-bottom: 1rem;
+}
+.table-tabs .tab {
+	padding: 0.5rem 1rem;
+	border: 1px solid transparent;
+	border-radius: 4px 4px 0 0;
+	margin-bottom: -1px;
+	color: #007bff;
+	text-decoration: none;
+	font-size: 0.875rem;
+}
+.table-tabs .tab:hover {
+	border-color: #e9ecef #e9ecef #dee2e6;
+}
+.table-tabs .tab.active {
+	color: #495057;
+	background: #ffffff;
+	border-color: #dee2e6 #dee2e6 #ffffff;
+}
+</style>`
+
+// RenderTabGroup renders the tab list and the table of the active tab
+// Only the active tab's data is loaded. Its pagination, sorting and search
+// links keep the tab parameter, so the table stays on its tab.
+func (r *Renderer) RenderTabGroup(req *http.Request, group TabGroup) (string, error) {
+	if len(group.Tabs) == 0 {
+		return "", fmt.Errorf("tab group has no tabs")
+	}
+
+	id := group.ID
+	if id == "" {
+		id = "table-tabs"
+	}
+	param := group.Param
+	if param == "" {
+		param = "tab"
+	}
+
+	active := group.Tabs[0]
+	requested := req.URL.Query().Get(param)
+	for _, tab := range group.Tabs {
+		if tab.Name == requested {
+			active = tab
+			break
+		}
+	}
+
+	var panel string
+	if active.Load != nil {
+		data, err := active.Load(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to load tab %q: %w", active.Name, err)
+		}
+		keepTabParam(&data.Options, param, active.Name)
+		panel, err = r.RenderHTML(data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var html strings.Builder
+	html.WriteString(fmt.Sprintf(`<div class="table-tabs" id="%s">`, template.HTMLEscapeString(id)))
+	if !group.OmitStyles {
+		html.WriteString(tabGroupStyles)
+	}
+
+	html.WriteString(`<div class="tab-list" role="tablist">`)
+	for _, tab := range group.Tabs {
+		href := template.HTMLEscapeString(tabURL(req.URL.Path, param, tab.Name))
+		selected := tab.Name == active.Name
+
+		class := "tab"
+		if selected {
+			class += " active"
+		}
+		htmx := ""
+		if group.HTMX {
+			htmx = fmt.Sprintf(` hx-get="%s" hx-target="#%s" hx-swap="outerHTML" hx-push-url="true"`,
+				href, template.HTMLEscapeString(id))
+		}
+		html.WriteString(fmt.Sprintf(`<a class="%s" role="tab" aria-selected="%t" href="%s"%s>%s</a>`,
+			class, selected, href, htmx, template.HTMLEscapeString(tab.Title)))
+	}
+	html.WriteString(`</div>`)
+
+	html.WriteString(fmt.Sprintf(`<div class="tab-panel" role="tabpanel">%s</div>`, panel))
+	html.WriteString(`</div>`)
+	return html.String(), nil
+}
+
+// tabURL returns the URL selecting a tab, dropping the table state of the
+// previous tab
+func tabURL(path string, param string, name string) string {
+	return path + "?" + url.Values{param: {name}}.Encode()
+}
+
+// keepTabParam adds the tab parameter to the base URLs of the table's
+// pagination, sorting and search links
+func keepTabParam(options *TableOptions, param string, name string) {
+	withTab := func(baseURL string) string {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return baseURL
+		}
+		query := u.Query()
+		query.Set(param, name)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	// Copy the settings so the caller's configuration is not modified
+	if options.Pagination != nil {
+		pagination := *options.Pagination
+		pagination.BaseURL = withTab(pagination.BaseURL)
+		options.Pagination = &pagination
+	}
+	if options.Sorting != nil {
+		sorting := *options.Sorting
+		sorting.BaseURL = withTab(sorting.BaseURL)
+		options.Sorting = &sorting
+	}
+	if options.Search != nil {
+		search := *options.Search
+		search.BaseURL = withTab(search.BaseURL)
+		options.Search = &search
+	}
+}
@@ -0,0 +1,74 @@
+package tablerenderer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// pageTwoLink matches the first link to page 2
+var pageTwoLink = regexp.MustCompile(`href="([^"]*page=2[^"]*)"`)
+
+func TestRenderTabGroup(t *testing.T) {
+	var loaded []string
+	tab := func(name string) Tab {
+		return Tab{
+			Name:  name,
+			Title: strings.ToUpper(name),
+			Load: func(req *http.Request) (DatabasePaginatedData, error) {
+				loaded = append(loaded, name)
+				return DatabasePaginatedData{
+					Headers:    []string{"ID"},
+					Rows:       [][]interface{}{{name + "-1"}},
+					TotalCount: 30,
+					Options: TableOptions{
+						Pagination: &Pagination{Enabled: true, CurrentPage: 1, PageSize: 10, TotalCount: 30, ShowControls: true, BaseURL: "/customer"},
+					},
+				}, nil
+			},
+		}
+	}
+	group := TabGroup{Tabs: []Tab{tab("orders"), tab("invoices")}}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantLoaded string
+		wantActive string
+	}{
+		{"first tab by default", "/customer", "orders", `class="tab active" role="tab" aria-selected="true" href="/customer?tab=orders"`},
+		{"tab from the query", "/customer?tab=invoices", "invoices", `class="tab active" role="tab" aria-selected="true" href="/customer?tab=invoices"`},
+		{"unknown tab", "/customer?tab=tickets", "orders", `class="tab active" role="tab" aria-selected="true" href="/customer?tab=orders"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded = nil
+			html, err := NewRenderer().RenderTabGroup(httptest.NewRequest("GET", tt.url, nil), group)
+			if err != nil {
+				t.Fatalf("RenderTabGroup() error = %v", err)
+			}
+			if len(loaded) != 1 || loaded[0] != tt.wantLoaded {
+				t.Errorf("loaded tabs = %q, want only %q", loaded, tt.wantLoaded)
+			}
+			if !strings.Contains(html, tt.wantActive) {
+				t.Errorf("active tab %q not found in:\n%s", tt.wantActive, html)
+			}
+			if !strings.Contains(html, tt.wantLoaded+"-1") {
+				t.Errorf("panel does not show the %q rows", tt.wantLoaded)
+			}
+			// Page links of the panel stay on the tab
+			link := pageTwoLink.FindStringSubmatch(html)
+			if link == nil || !strings.Contains(link[1], "tab="+tt.wantLoaded) {
+				t.Errorf("page link %q does not keep the tab parameter", link)
+			}
+		})
+	}
+}
+
+func TestRenderTabGroupNoTabs(t *testing.T) {
+	if _, err := NewRenderer().RenderTabGroup(httptest.NewRequest("GET", "/", nil), TabGroup{}); err == nil {
+		t.Error("RenderTabGroup() with no tabs succeeded, want an error")
+	}
+}
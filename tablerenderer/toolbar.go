@@ -0,0 +1,105 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// ToolbarSlot identifies a region of the toolbar above the table
+type ToolbarSlot string
+
+// Toolbar regions
+const (
+	ToolbarLeft   ToolbarSlot = "left"
+	ToolbarCenter ToolbarSlot = "center"
+	ToolbarRight  ToolbarSlot = "right"
+)
+
+// Names of the built-in toolbar items
+const (
+	ToolbarItemPageSize     = "page_size"
+	ToolbarItemSearch       = "search"
+	ToolbarItemPlugins      = "plugins"
+	ToolbarItemKeyboardHelp = "keyboard_help"
+	ToolbarItemQuickFilter  = "quick_filter"
+	ToolbarItemFacets       = "facets"
+	ToolbarItemExport       = "export"
+	ToolbarItemCopy         = "copy"
+)
+
+// ToolbarItem is a control placed in the toolbar above the table
+type ToolbarItem struct {
+	Name  string        `json:"name"`            // Identifies the item, e.g. "refresh" or a built-in name
+	Slot  ToolbarSlot   `json:"slot,omitempty"`  // Region the item is placed in (default: "right")
+	Order int           `json:"order,omitempty"` // Position within the slot, lower first
+	HTML  template.HTML `json:"html,omitempty"`  // Trusted markup of the item
+}
+
+// Toolbar configures the toolbar above the table
+// Built-in items are "page_size" (left), "plugins" (center), "quick_filter",
+// "search", "export", "copy" and "keyboard_help" (right). An item named after a built-in with empty HTML moves that
+// built-in to the item's slot and order instead of adding a new control.
+type Toolbar struct {
+	Items []ToolbarItem `json:"items,omitempty"` // Custom items and built-in placement overrides
+	Hide  []string      `json:"hide,omitempty"`  // Names of items to omit
+}
+
+// generateToolbarHTML lays out the built-in and custom toolbar items in
+// their slots
+func (r *Renderer) generateToolbarHTML(toolbar *Toolbar, builtins []ToolbarItem) string {
+	items := make([]ToolbarItem, 0, len(builtins))
+	for _, item := range builtins {
+		if item.HTML != "" {
+			items = append(items, item)
+		}
+	}
+
+	hidden := make(map[string]bool)
+	if toolbar != nil {
+		for _, name := range toolbar.Hide {
+			hidden[name] = true
+		}
+		for _, custom := range toolbar.Items {
+			if custom.HTML != "" {
+				items = append(items, custom)
+				continue
+			}
+			// Placement override for a built-in item
+			for i := range items {
+				if items[i].Name == custom.Name {
+					if custom.Slot != "" {
+						items[i].Slot = custom.Slot
+					}
+					items[i].Order = custom.Order
+				}
+			}
+		}
+	}
+
+	slots := map[ToolbarSlot][]ToolbarItem{}
+	for _, item := range items {
+		if hidden[item.Name] {
+			continue
+		}
+		slot := item.Slot
+		if slot != ToolbarLeft && slot != ToolbarCenter {
+			slot = ToolbarRight
+		}
+		slots[slot] = append(slots[slot], item)
+	}
+
+	var html strings.Builder
+	for _, slot := range []ToolbarSlot{ToolbarLeft, ToolbarCenter, ToolbarRight} {
+		slotItems := slots[slot]
+		sort.SliceStable(slotItems, func(i, j int) bool { return slotItems[i].Order < slotItems[j].Order })
+
+		html.WriteString(fmt.Sprintf(`<div class="toolbar-slot toolbar-%s">`, slot))
+		for _, item := range slotItems {
+			html.WriteString(string(item.HTML))
+		}
+		html.WriteString(`</div>`)
+	}
+	return html.String()
+}
@@ -0,0 +1,34 @@
+package tablerenderer
+
+import (
+	"html/template"
+)
+
+// transposedRow is a field of a transposed table: its header followed by
+// the field's value in each record
+type transposedRow struct {
+	Header  template.HTML // Header cell content
+	Cells   []interface{} // Formatted value per record
+	Classes []string      // CSS class of each cell, "" for none
+}
+
+// transposeRows turns the rendered records into one row per header
+func transposeRows(headerContents []template.HTML, rendered []tableRow) []transposedRow {
+	transposed := make([]transposedRow, len(headerContents))
+	for i, header := range headerContents {
+		transposed[i] = transposedRow{
+			Header:  header,
+			Cells:   make([]interface{}, len(rendered)),
+			Classes: make([]string, len(rendered)),
+		}
+		for j, row := range rendered {
+			if i < len(row.Cells) {
+				transposed[i].Cells[j] = row.Cells[i]
+			}
+			if i < len(row.Classes) {
+				transposed[i].Classes[j] = row.Classes[i]
+			}
+		}
+	}
+	return transposed
+}
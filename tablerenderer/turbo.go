@@ -0,0 +1,61 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+// TurboFrame wraps the table in a Hotwire <turbo-frame>. Pagination, sort,
+// page size and search requests replace only the frame, while other links
+// in the table, e.g. cell links and row actions, navigate the whole page.
+// Render just the table for requests carrying the Turbo-Frame header, see
+// TurboFrameRequest.
+type TurboFrame struct {
+	ID     string `json:"id,omitempty"`     // Frame ID, matched against the frame in the response (default: TableOptions.ID, else "table")
+	Action string `json:"action,omitempty"` // History action of frame navigations: "advance" or "replace" (default: URL left unchanged)
+}
+
+// turboFrameID returns the frame ID of the table, or "" without a frame
+func turboFrameID(options TableOptions) string {
+	if options.TurboFrame == nil {
+		return ""
+	}
+	if options.TurboFrame.ID != "" {
+		return options.TurboFrame.ID
+	}
+	if options.ID != "" {
+		return options.ID
+	}
+	return "table"
+}
+
+// turboFrameAttr returns the attribute targeting a control at the frame, or
+// "" without a frame
+func turboFrameAttr(frame string) string {
+	if frame == "" {
+		return ""
+	}
+	return fmt.Sprintf(` data-turbo-frame="%s"`, template.HTMLEscapeString(frame))
+}
+
+// wrapTurboFrame wraps the rendered table in its frame. The frame targets
+// _top, so only controls marked with data-turbo-frame stay in it.
+func wrapTurboFrame(html string, options TableOptions) string {
+	frame := turboFrameID(options)
+	if frame == "" {
+		return html
+	}
+	action := ""
+	if options.TurboFrame.Action != "" {
+		action = fmt.Sprintf(` data-turbo-action="%s"`, template.HTMLEscapeString(options.TurboFrame.Action))
+	}
+	return fmt.Sprintf(`<turbo-frame id="%s" target="_top"%s>%s</turbo-frame>`,
+		template.HTMLEscapeString(frame), action, html)
+}
+
+// TurboFrameRequest returns the ID of the frame a Turbo request targets, or
+// "" for full page requests
+func TurboFrameRequest(req *http.Request) string {
+	return req.Header.Get("Turbo-Frame")
+}
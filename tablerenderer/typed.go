@@ -0,0 +1,43 @@
+package tablerenderer
+
+// TypedColumn is a column of a RenderTyped table, reading its value from a
+// row of type T without reflection. Formatting still comes from the Column
+// in TableOptions.Columns with the same Header.
+type TypedColumn[T any] struct {
+	Header string                  // Header of the column
+	Value  func(row T) interface{} // Cell value of the column for a row
+}
+
+// RenderTyped renders rows as the current page with one cell per column,
+// checked at compile time instead of converted from interface{} slices or
+// struct tags. For database pagination, set opts.Pagination.TotalCount.
+func RenderTyped[T any](r *Renderer, rows []T, columns []TypedColumn[T], opts TableOptions) (string, error) {
+	return r.RenderHTML(typedData(rows, columns, opts))
+}
+
+// typedData evaluates the columns for each row into paginated data
+func typedData[T any](rows []T, columns []TypedColumn[T], opts TableOptions) DatabasePaginatedData {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		cells := make([]interface{}, len(columns))
+		for j, column := range columns {
+			cells[j] = column.Value(row)
+		}
+		values[i] = cells
+	}
+
+	data := DatabasePaginatedData{
+		Headers: headers,
+		Rows:    values,
+		Options: opts,
+	}
+	if opts.Pagination != nil {
+		data.TotalCount = opts.Pagination.TotalCount
+	}
+	return data
+}
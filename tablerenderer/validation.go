@@ -0,0 +1,154 @@
+package tablerenderer
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ValidationMode selects what rendering does with the problems Validate
+// finds
+type ValidationMode string
+
+// Validation modes
+const (
+	ValidationOff     ValidationMode = ""        // Render without validating (default)
+	ValidationStrict  ValidationMode = "strict"  // RenderHTML returns the ValidationErrors instead of a table
+	ValidationLenient ValidationMode = "lenient" // Render the table with a warning banner listing the problems
+)
+
+// Validation error codes
+const (
+	ValidationRowLength  = "row_length"  // Row with more or fewer values than headers
+	ValidationPage       = "page"        // Negative page number or page size
+	ValidationSortColumn = "sort_column" // Sorted by a header the table does not have
+	ValidationMixedTypes = "mixed_types" // Column with values of different types, e.g. numbers and text
+)
+
+// validationBannerProblems is the number of problems the lenient mode
+// banner lists; it counts the others
+const validationBannerProblems = 10
+
+// ValidationError is a problem with the data or options of a table that
+// would render it incorrectly
+type ValidationError struct {
+	Code    string `json:"code"`             // One of the Validation* codes
+	Header  string `json:"header,omitempty"` // Column concerned, if any
+	Row     int    `json:"row"`              // 0-based index of the row concerned, -1 for none
+	Message string `json:"message"`
+}
+
+// Error implements error
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors are the problems Validate found. RenderHTML returns them
+// as its error with ValidationStrict; use errors.As to inspect them.
+type ValidationErrors []ValidationError
+
+// Error implements error
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return "invalid table: " + strings.Join(messages, "; ")
+}
+
+// Validate checks the rows and options of data for row lengths that do not
+// match the headers, negative page numbers and sizes, sorting by unknown
+// headers and columns mixing value types. It returns nil when the table is
+// fine.
+func (r *Renderer) Validate(data DatabasePaginatedData) ValidationErrors {
+	var problems ValidationErrors
+	add := func(code string, header string, row int, format string, args ...interface{}) {
+		problems = append(problems, ValidationError{Code: code, Header: header, Row: row, Message: fmt.Sprintf(format, args...)})
+	}
+
+	headers, rows, err := resolveHeadersAndRows(data.Headers, data.Rows, data.Data, data.Options.Columns)
+	if err != nil {
+		// Rendering reports conversion failures itself
+		return nil
+	}
+
+	for i, row := range rows {
+		if len(row) != len(headers) {
+			add(ValidationRowLength, "", i, "row %d: %d values for %d headers", i+1, len(row), len(headers))
+		}
+	}
+
+	if pagination := data.Options.Pagination; pagination != nil && pagination.Enabled {
+		if pagination.CurrentPage < 0 {
+			add(ValidationPage, "", -1, "page %d is negative", pagination.CurrentPage)
+		}
+		if pagination.PageSize < 0 {
+			add(ValidationPage, "", -1, "page size %d is negative", pagination.PageSize)
+		}
+	}
+
+	if sorting := data.Options.Sorting; sorting != nil && sorting.Enabled && sorting.SortBy != "" && !slices.Contains(headers, sorting.SortBy) {
+		add(ValidationSortColumn, sorting.SortBy, -1, "sorted by unknown column %q", sorting.SortBy)
+	}
+
+	for j, header := range headers {
+		first, firstRow := "", 0
+		for i, row := range rows {
+			if j >= len(row) || isEmptyValue(row[j]) {
+				continue
+			}
+			kind := valueKind(row[j])
+			if first == "" {
+				first, firstRow = kind, i
+			} else if kind != first {
+				add(ValidationMixedTypes, header, i, "column %q mixes %s (row %d) and %s (row %d) values", header, first, firstRow+1, kind, i+1)
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// valueKind classifies a cell value as number, text, bool, time or its Go
+// kind, looking through pointers and the values types describe themselves as
+func valueKind(value interface{}) string {
+	value = domainValue(value)
+	if _, ok := timeValue(value); ok {
+		return "time"
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "text"
+	case reflect.Bool:
+		return "bool"
+	}
+	return v.Kind().String()
+}
+
+// generateValidationHTML renders the warning banner listing the problems of
+// a table in lenient mode
+func (r *Renderer) generateValidationHTML(problems ValidationErrors) string {
+	var html strings.Builder
+	html.WriteString(`<div class="validation-warning" role="alert">`)
+	html.WriteString(fmt.Sprintf(`<strong>%s</strong><ul>`,
+		template.HTMLEscapeString(r.translate(LabelValidationWarning, len(problems), len(problems)))))
+	for i, problem := range problems {
+		if i == validationBannerProblems {
+			html.WriteString(fmt.Sprintf(`<li>… %d more</li>`, len(problems)-i))
+			break
+		}
+		html.WriteString(fmt.Sprintf(`<li>%s</li>`, template.HTMLEscapeString(problem.Message)))
+	}
+	html.WriteString(`</ul></div>`)
+	return html.String()
+}
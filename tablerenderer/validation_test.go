@@ -0,0 +1,84 @@
+package tablerenderer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      DatabasePaginatedData
+		wantCodes []string
+	}{
+		{
+			name: "valid",
+			data: DatabasePaginatedData{
+				Headers: []string{"Name", "Age"},
+				Rows:    [][]interface{}{{"Ada", 36}, {"Bob", nil}, {"", 41}},
+				Options: TableOptions{
+					Pagination: &Pagination{Enabled: true, CurrentPage: 1, PageSize: 10},
+					Sorting:    &Sorting{Enabled: true, SortBy: "Age"},
+				},
+			},
+		},
+		{
+			name: "row length",
+			data: DatabasePaginatedData{
+				Headers: []string{"Name", "Age"},
+				Rows:    [][]interface{}{{"Ada"}, {"Bob", 41, "extra"}},
+			},
+			wantCodes: []string{ValidationRowLength, ValidationRowLength},
+		},
+		{
+			name: "negative page and size",
+			data: DatabasePaginatedData{
+				Headers: []string{"Name"},
+				Options: TableOptions{Pagination: &Pagination{Enabled: true, CurrentPage: -1, PageSize: -5}},
+			},
+			wantCodes: []string{ValidationPage, ValidationPage},
+		},
+		{
+			name: "disabled pagination is not checked",
+			data: DatabasePaginatedData{
+				Headers: []string{"Name"},
+				Options: TableOptions{Pagination: &Pagination{CurrentPage: -1}},
+			},
+		},
+		{
+			name: "unknown sort column",
+			data: DatabasePaginatedData{
+				Headers: []string{"Name"},
+				Options: TableOptions{Sorting: &Sorting{Enabled: true, SortBy: "Email"}},
+			},
+			wantCodes: []string{ValidationSortColumn},
+		},
+		{
+			name: "mixed types",
+			data: DatabasePaginatedData{
+				Headers: []string{"Value", "When"},
+				Rows: [][]interface{}{
+					{1, time.Now()},
+					{2.5, time.Now()},
+					{"three", time.Now()},
+					{true, time.Now()},
+				},
+			},
+			wantCodes: []string{ValidationMixedTypes},
+		},
+	}
+	r := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := r.Validate(tt.data)
+			if len(problems) != len(tt.wantCodes) {
+				t.Fatalf("Validate() = %v, want codes %q", problems, tt.wantCodes)
+			}
+			for i, problem := range problems {
+				if problem.Code != tt.wantCodes[i] {
+					t.Errorf("problem %d code = %q, want %q", i, problem.Code, tt.wantCodes[i])
+				}
+			}
+		})
+	}
+}
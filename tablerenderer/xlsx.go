@@ -0,0 +1,222 @@
+package tablerenderer
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxContentType is the media type of XLSX exports
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// xlsxParts are the fixed parts of an XLSX package, a single worksheet
+// workbook, by path
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// ExportXLSX writes the full table data as an Excel workbook with one
+// worksheet. The Metadata preamble becomes a header block of label/value
+// rows above the table, and its title and author are also set as the
+// document properties. Numbers are written as numeric cells, everything
+// else as text; the CSV settings and Trailer do not apply.
+func (r *Renderer) ExportXLSX(w io.Writer, data TableData, opts ExportOptions) error {
+	_, err := r.exportXLSX(w, data, opts)
+	return err
+}
+
+// exportXLSX writes the table data as XLSX and returns the number of rows
+func (r *Renderer) exportXLSX(w io.Writer, data TableData, opts ExportOptions) (int, error) {
+	headers, rows, err := r.prepareTable(data.Headers, data.Rows, data.Data, &data.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		if err := writeZipPart(archive, part.name, part.content); err != nil {
+			return 0, err
+		}
+	}
+	if err := writeZipPart(archive, "docProps/core.xml", xlsxCoreProperties(opts.Metadata)); err != nil {
+		return 0, err
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create XLSX worksheet: %w", err)
+	}
+	sw := &xlsxSheetWriter{w: sheet}
+	sw.writeString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	if opts.Metadata != nil {
+		for _, line := range metadataLines(opts.Metadata, data.Options) {
+			sw.writeRow([]interface{}{line[0], line[1]})
+		}
+		// Blank separator row, as in the CSV preamble
+		sw.row++
+	}
+	headerRow := make([]interface{}, len(headers))
+	for i, header := range headers {
+		headerRow[i] = header
+	}
+	sw.writeRow(headerRow)
+
+	anonymizers := exportAnonymizers(headers, data.Options.Columns, opts)
+	configs := columnsFor(headers, data.Options.Columns)
+	for _, row := range rows {
+		row = anonymizeRow(row, anonymizers)
+		cells := make([]interface{}, len(row))
+		for i, value := range row {
+			if i < len(configs) {
+				value, _ = roundValue(configs[i], value)
+			}
+			cells[i] = value
+		}
+		sw.writeRow(cells)
+	}
+
+	sw.writeString(`</sheetData></worksheet>`)
+	if sw.err != nil {
+		return 0, fmt.Errorf("failed to write XLSX row: %w", sw.err)
+	}
+	if err := archive.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finish XLSX: %w", err)
+	}
+	return len(rows), nil
+}
+
+// writeZipPart adds a part with the given content to an XLSX package
+func writeZipPart(archive *zip.Writer, name string, content string) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create XLSX part %s: %w", name, err)
+	}
+	if _, err := io.WriteString(part, content); err != nil {
+		return fmt.Errorf("failed to write XLSX part %s: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxCoreProperties returns the docProps/core.xml part: the title and
+// author of the metadata and the generation time
+func xlsxCoreProperties(metadata *ExportMetadata) string {
+	var title, author string
+	created := time.Now()
+	if metadata != nil {
+		title, author = metadata.Title, metadata.Author
+		if !metadata.GeneratedAt.IsZero() {
+			created = metadata.GeneratedAt
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	if title != "" {
+		b.WriteString("<dc:title>" + xmlText(title) + "</dc:title>")
+	}
+	if author != "" {
+		b.WriteString("<dc:creator>" + xmlText(author) + "</dc:creator>")
+	}
+	b.WriteString(`<dcterms:created xsi:type="dcterms:W3CDTF">` + created.UTC().Format(time.RFC3339) + `</dcterms:created>`)
+	b.WriteString(`</cp:coreProperties>`)
+	return b.String()
+}
+
+// xlsxSheetWriter writes worksheet rows, keeping the first error
+type xlsxSheetWriter struct {
+	w   io.Writer
+	row int // Rows written or skipped so far
+	err error
+}
+
+// writeString writes raw worksheet XML
+func (sw *xlsxSheetWriter) writeString(s string) {
+	if sw.err == nil {
+		_, sw.err = io.WriteString(sw.w, s)
+	}
+}
+
+// writeRow writes the next row of cells
+func (sw *xlsxSheetWriter) writeRow(cells []interface{}) {
+	sw.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, sw.row)
+	for i, value := range cells {
+		ref := xlsxColumnName(i) + strconv.Itoa(sw.row)
+		value = domainValue(value)
+		if number, ok := xlsxNumber(value); ok {
+			fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, number)
+			continue
+		}
+		if text := exportValue(value); text != "" {
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlText(text))
+		}
+	}
+	b.WriteString(`</row>`)
+	sw.writeString(b.String())
+}
+
+// xlsxNumber returns the numeric cell value of integers and finite floats
+func xlsxNumber(value interface{}) (string, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		bits := 64
+		if v.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		text := strconv.FormatFloat(v.Float(), 'g', -1, bits)
+		if strings.ContainsAny(text, "IN") {
+			// Inf and NaN have no numeric cell form
+			return "", false
+		}
+		return text, true
+	}
+	return "", false
+}
+
+// xlsxColumnName returns the column letters of a 0-based column index,
+// e.g. "A", "Z", "AA"
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// xmlText escapes text for XML element content, replacing characters XML
+// cannot hold
+func xmlText(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
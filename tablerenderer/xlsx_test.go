@@ -0,0 +1,95 @@
+package tablerenderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportXLSX(t *testing.T) {
+	metadata := &ExportMetadata{
+		Title:       "Orders & returns",
+		Author:      "Ada",
+		GeneratedAt: time.Date(2024, 3, 9, 14, 30, 0, 0, time.UTC),
+	}
+	data := TableData{
+		Headers: []string{"ID", "Name", "Total"},
+		Rows:    [][]interface{}{{1, "<Ada>", 2.5}, {2, "Bob", nil}},
+	}
+	var buf bytes.Buffer
+	if err := NewRenderer().ExportXLSX(&buf, data, ExportOptions{Metadata: metadata}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("the export is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("opening %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		parts[file.Name] = string(content)
+	}
+
+	// Every part the relationships and content types name must exist
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml", "docProps/core.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	if len(parts) != 6 {
+		t.Errorf("got %d parts, want 6", len(parts))
+	}
+
+	for _, want := range []string{
+		"<dc:title>Orders &amp; returns</dc:title>",
+		"<dc:creator>Ada</dc:creator>",
+		`<dcterms:created xsi:type="dcterms:W3CDTF">2024-03-09T14:30:00Z</dcterms:created>`,
+	} {
+		if !strings.Contains(parts["docProps/core.xml"], want) {
+			t.Errorf("core.xml = %s, want %s", parts["docProps/core.xml"], want)
+		}
+	}
+
+	// The header row follows the metadata rows and a blank row
+	headerRow := len(metadataLines(metadata, data.Options)) + 2
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	h := headerRow
+	for _, want := range []string{
+		`<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Title</t></is></c><c r="B1" t="inlineStr"><is><t xml:space="preserve">Orders &amp; returns</t></is></c></row>`,
+		fmt.Sprintf(`<row r="%d"><c r="A%d" t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`, h, h),
+		fmt.Sprintf(`<row r="%d"><c r="A%d"><v>1</v></c><c r="B%d" t="inlineStr"><is><t xml:space="preserve">&lt;Ada&gt;</t></is></c><c r="C%d"><v>2.5</v></c></row>`, h+1, h+1, h+1, h+1),
+		fmt.Sprintf(`<row r="%d"><c r="A%d"><v>2</v></c><c r="B%d" t="inlineStr"><is><t xml:space="preserve">Bob</t></is></c></row>`, h+2, h+2, h+2),
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml = %s, want %s", sheet, want)
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := xlsxColumnName(tt.index); got != tt.want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
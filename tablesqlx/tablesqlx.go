@@ -0,0 +1,67 @@
+// Package tablesqlx builds table data from sqlx results: generic rows are
+// scanned by column name, and structs loaded with StructScan or Select get
+// their headers from the db tags.
+package tablesqlx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/faiakak/table-renderer/tablerenderer"
+)
+
+// FromRows reads all rows of a query into table data with the result column
+// names as headers, then closes rows
+func FromRows(rows *sqlx.Rows) (tablerenderer.TableData, error) {
+	defer rows.Close()
+
+	headers, err := rows.Columns()
+	if err != nil {
+		return tablerenderer.TableData{}, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	values := [][]interface{}{}
+	for rows.Next() {
+		row, err := rows.SliceScan()
+		if err != nil {
+			return tablerenderer.TableData{}, fmt.Errorf("failed to scan row: %w", err)
+		}
+		// Render text columns as text rather than byte slices
+		for i, value := range row {
+			if b, ok := value.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		values = append(values, row)
+	}
+	if err := rows.Err(); err != nil {
+		return tablerenderer.TableData{}, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return tablerenderer.TableData{Headers: headers, Rows: values}, nil
+}
+
+// FromStructs returns table data for a slice of structs, or a pointer to
+// one, as loaded by sqlx Select. The columns are those rendered for the
+// struct type, see tablerenderer.StructHeaders, with db tag names as
+// headers; hide columns with a table:"-" tag.
+func FromStructs(data interface{}) (tablerenderer.TableData, error) {
+	sliceType := reflect.TypeOf(data)
+	if sliceType != nil && sliceType.Kind() == reflect.Ptr {
+		sliceType = sliceType.Elem()
+	}
+	if sliceType == nil || sliceType.Kind() != reflect.Slice {
+		return tablerenderer.TableData{}, fmt.Errorf("data must be a slice of structs")
+	}
+	elemType := sliceType.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return tablerenderer.TableData{}, fmt.Errorf("slice elements must be structs")
+	}
+
+	return tablerenderer.TableData{Headers: tablerenderer.StructHeaders(elemType, "db"), Data: data}, nil
+}
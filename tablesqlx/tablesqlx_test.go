@@ -0,0 +1,126 @@
+package tablesqlx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeDriver answers every query with the same columns and rows
+type fakeDriver struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.d}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ d fakeDriver }
+
+func (s fakeStmt) Close() error                                    { return nil }
+func (s fakeStmt) NumInput() int                                   { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{d: s.d}, nil
+}
+
+type fakeRows struct {
+	d    fakeDriver
+	next int
+}
+
+func (r *fakeRows) Columns() []string { return r.d.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.d.rows) {
+		return io.EOF
+	}
+	copy(dest, r.d.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestFromRows(t *testing.T) {
+	sql.Register("tablesqlx-fake", fakeDriver{
+		columns: []string{"id", "name", "note"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Ada"), nil},
+			{int64(2), []byte("Bob"), "vip"},
+		},
+	})
+	db, err := sqlx.Open("tablesqlx-fake", "")
+	if err != nil {
+		t.Fatalf("sqlx.Open() error = %v", err)
+	}
+	defer db.Close()
+	rows, err := db.Queryx("SELECT id, name, note FROM users")
+	if err != nil {
+		t.Fatalf("Queryx() error = %v", err)
+	}
+
+	data, err := FromRows(rows)
+	if err != nil {
+		t.Fatalf("FromRows() error = %v", err)
+	}
+	if want := []string{"id", "name", "note"}; !reflect.DeepEqual(data.Headers, want) {
+		t.Errorf("Headers = %q, want %q", data.Headers, want)
+	}
+	want := [][]interface{}{{int64(1), "Ada", nil}, {int64(2), "Bob", "vip"}}
+	if !reflect.DeepEqual(data.Rows, want) {
+		t.Errorf("Rows = %#v, want %#v", data.Rows, want)
+	}
+}
+
+type account struct {
+	ID    int    `db:"id"`
+	Name  string `db:"name,omitempty"`
+	Email string
+}
+
+type audit struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type member struct {
+	ID       int    `db:"id"`
+	Password string `db:"password" table:"-"`
+	audit
+}
+
+func TestFromStructs(t *testing.T) {
+	accounts := []account{{ID: 1, Name: "Ada"}}
+	tests := []struct {
+		name        string
+		data        interface{}
+		wantHeaders []string
+		wantErr     bool
+	}{
+		{"slice", accounts, []string{"id", "name", "Email"}, false},
+		{"pointer to a slice", &accounts, []string{"id", "name", "Email"}, false},
+		{"slice of pointers", []*account{{ID: 1}}, []string{"id", "name", "Email"}, false},
+		{"hidden and embedded fields", []member{}, []string{"id", "created_by"}, false},
+		{"not a slice", accounts[0], nil, true},
+		{"not structs", []int{1}, nil, true},
+		{"nil", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := FromStructs(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromStructs() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(data.Headers, tt.wantHeaders) {
+				t.Errorf("Headers = %q, want %q", data.Headers, tt.wantHeaders)
+			}
+		})
+	}
+}
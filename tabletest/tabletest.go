@@ -0,0 +1,30 @@
+// Package tabletest serves a table definition from an httptest server with
+// the full table handler, so applications can test their sorting,
+// pagination, search and export flows end to end without wiring up the app.
+package tabletest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/faiakak/table-renderer/tablerenderer"
+)
+
+// NewServer starts a server serving the table of def with rows from data at
+// the path of def.BaseURL (default: "/"), configured by def.Options as in
+// TableHandler. The definition is registered with
+// the renderer so drill-down links to it resolve. Close the server when done.
+func NewServer(def tablerenderer.TableDefinition, data tablerenderer.DataSource) *httptest.Server {
+	renderer := tablerenderer.NewRenderer()
+	renderer.RegisterTable(def)
+
+	path := "/"
+	if parsed, err := url.Parse(def.BaseURL); err == nil && parsed.Path != "" {
+		path = parsed.Path
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, renderer.TableHandler(def, data))
+	return httptest.NewServer(mux)
+}
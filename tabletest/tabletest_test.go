@@ -0,0 +1,111 @@
+package tabletest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/faiakak/table-renderer/tablerenderer"
+)
+
+func TestNewServer(t *testing.T) {
+	source := tablerenderer.SliceSource{Headers: []string{"ID", "Name"}}
+	for i := 1; i <= 30; i++ {
+		source.Rows = append(source.Rows, []interface{}{i, fmt.Sprintf("order-%03d", i)})
+	}
+	server := NewServer(tablerenderer.TableDefinition{Name: "orders", BaseURL: "/orders"}, source)
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		query           string
+		wantContentType string
+		want            []string
+		notWant         []string
+	}{
+		{
+			name:            "sorted page",
+			query:           "?page=2&page_size=10&sort_by=ID&sort_order=desc",
+			wantContentType: "text/html",
+			want:            []string{"order-020", "order-011"},
+			notWant:         []string{"order-021", "order-010"},
+		},
+		{
+			name:            "search",
+			query:           "?search=order-02",
+			wantContentType: "text/html",
+			want:            []string{"order-020", "order-029"},
+			notWant:         []string{"order-019", "order-030"},
+		},
+		{
+			name:            "CSV export of every row",
+			query:           "?page=2&page_size=10&format=csv",
+			wantContentType: "text/csv",
+			want:            []string{"ID,Name\n", "1,order-001\n", "30,order-030\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/orders" + tt.query)
+			if err != nil {
+				t.Fatalf("GET error = %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+			}
+			if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, tt.wantContentType) {
+				t.Errorf("Content-Type = %q, want %s", got, tt.wantContentType)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("missing %q in:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(body), notWant) {
+					t.Errorf("unexpected %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestNewServerOptions(t *testing.T) {
+	source := tablerenderer.SliceSource{Headers: []string{"ID", "Name"}}
+	for i := 1; i <= 30; i++ {
+		source.Rows = append(source.Rows, []interface{}{i, fmt.Sprintf("order-%03d", i)})
+	}
+	server := NewServer(tablerenderer.TableDefinition{
+		Name:    "orders",
+		BaseURL: "/orders",
+		Options: &tablerenderer.TableOptions{
+			Pagination: &tablerenderer.Pagination{Enabled: true, PageSize: 5, QueryParam: "p", ShowControls: true},
+			Columns:    []tablerenderer.Column{{Header: "Name", Label: "Order name"}},
+		},
+	}, source)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/orders?p=2")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	for _, want := range []string{"Order name", "order-006", "order-010", `href="/orders?p=3`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+	for _, notWant := range []string{"order-005", "order-011"} {
+		if strings.Contains(string(body), notWant) {
+			t.Errorf("unexpected %q", notWant)
+		}
+	}
+}